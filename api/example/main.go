@@ -29,12 +29,19 @@ func main() {
 		log.Fatal(err)
 	}
 
+	chainResp, err := client.GetChainID(context.Background(), &message.ReqChainId{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	tx := &transaction.Transaction{
-		From:   *from,
-		To:     *to,
-		Amount: 100000,
-		Nonce:  nonceResp.Nonce,
-		Time:   time.Now().Unix(),
+		From:    *from,
+		To:      *to,
+		Amount:  100000,
+		Nonce:   nonceResp.Nonce,
+		Time:    time.Now().Unix(),
+		ChainID: chainResp.ChainId,
+		Version: transaction.Version,
 	}
 	tx.HashTransaction()
 	tx.Sign(fWallet.PrivateKey)
@@ -47,6 +54,8 @@ func main() {
 		Time:      tx.Time,
 		Hash:      tx.Hash,
 		Signature: tx.Signature,
+		ChainId:   tx.ChainID,
+		Version:   tx.Version,
 	}
 	resp, err := client.SendSignedTransaction(context.Background(), req)
 	if err != nil {
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"kortho/api/message"
+	"kortho/block"
 	"kortho/config"
 	"kortho/logger"
 	"kortho/p2p/node"
@@ -58,6 +59,12 @@ func newGreeter(cfg *config.RPCConfigInfo, bc blockchain.Blockchains, tp *txpool
 	return grpcServ
 }
 
+// maxHeaders GetHeaders一次返回的最大块头数量
+const maxHeaders = 1000
+
+// maxBlocks GetBlocks一次返回的最大块数量
+const maxBlocks = 500
+
 // RunRPC run rpc service
 func (g *Greeter) RunRPC() {
 	lis, err := net.Listen("tcp", g.Address)
@@ -93,6 +100,10 @@ func txToMsgTxAndOrder(tx *transaction.Transaction) (msgTx message.Tx) {
 	msgTx.Tag = tx.Tag
 	msgTx.PckNum = tx.PckNum
 	msgTx.KtoNum = tx.KtoNum
+	msgTx.ValidUntilHeight = tx.ValidUntilHeight
+	msgTx.ValidUntilTime = tx.ValidUntilTime
+	msgTx.ChainID = tx.ChainID
+	msgTx.Version = tx.Version
 
 	if tx.IsOrderTransaction() {
 		msgTx.Order = &message.Order{}
@@ -108,6 +119,20 @@ func txToMsgTxAndOrder(tx *transaction.Transaction) (msgTx message.Tx) {
 	return
 }
 
+func headerToMsgHeader(h *block.BlockHeader) *message.BlockHeader {
+	return &message.BlockHeader{
+		Height:        h.Height,
+		PrevBlockHash: hex.EncodeToString(h.PrevHash),
+		Root:          hex.EncodeToString(h.Root),
+		Version:       h.Version,
+		Timestamp:     h.Timestamp,
+		Hash:          hex.EncodeToString(h.Hash),
+		Miner:         h.Miner.String(),
+		StateRoot:     hex.EncodeToString(h.StateRoot),
+		TxCount:       h.TxCount,
+	}
+}
+
 func txToMsgTx(tx *transaction.Transaction) (msgTx message.Tx) {
 	msgTx.Hash = hex.EncodeToString(tx.Hash)
 	msgTx.From = string(tx.From.Bytes())
@@ -122,6 +147,10 @@ func txToMsgTx(tx *transaction.Transaction) (msgTx message.Tx) {
 	msgTx.Tag = tx.Tag
 	msgTx.PckNum = tx.PckNum
 	msgTx.KtoNum = tx.KtoNum
+	msgTx.ValidUntilHeight = tx.ValidUntilHeight
+	msgTx.ValidUntilTime = tx.ValidUntilTime
+	msgTx.ChainID = tx.ChainID
+	msgTx.Version = tx.Version
 	return msgTx
 }
 
@@ -162,6 +191,10 @@ func MsgTxToTx(msgTx *message.Tx) (*transaction.Transaction, error) {
 	tx.Fee = msgTx.Fee
 	tx.Root = msgTx.Root
 	tx.Tag = msgTx.Tag
+	tx.ValidUntilHeight = msgTx.ValidUntilHeight
+	tx.ValidUntilTime = msgTx.ValidUntilTime
+	tx.ChainID = msgTx.ChainID
+	tx.Version = msgTx.Version
 
 	tx.Order = &transaction.Order{}
 	if msgTx.Order != nil && len(msgTx.Signature) > 0 && len(msgTx.Order.Signature) > 0 {
@@ -243,6 +276,7 @@ func (g *Greeter) GetBlockByNum(ctx context.Context, in *message.ReqBlockByNumbe
 	respdata.Timestamp = b.Timestamp
 	respdata.Version = b.Version
 	respdata.Miner = b.Miner.String()
+	respdata.StateRoot = hex.EncodeToString(b.StateRoot)
 	return &respdata, nil
 }
 
@@ -268,6 +302,100 @@ func (g *Greeter) GetBlockByHash(ctx context.Context, in *message.ReqBlockByHash
 	respdata.Timestamp = b.Timestamp
 	respdata.Version = b.Version
 	respdata.Miner = b.Miner.String()
+	respdata.StateRoot = hex.EncodeToString(b.StateRoot)
+	return &respdata, nil
+}
+
+// GetBlockHeaderByNum 通过块高获取块头，不加载块中的交易
+func (g *Greeter) GetBlockHeaderByNum(ctx context.Context, in *message.ReqBlockByNumber) (*message.BlockHeader, error) {
+	h, err := g.Bc.GetHeaderByHeight(in.Height)
+	if err != nil {
+		logger.Error("g.Bc.GetHeaderByHeight", zap.Error(err), zap.Uint64("height", in.Height))
+		return nil, grpc.Errorf(codes.NotFound, "height %d not found", in.Height)
+	}
+	return headerToMsgHeader(h), nil
+}
+
+// GetHeaders 获取从from到to的块头，一次最多maxHeaders个
+func (g *Greeter) GetHeaders(ctx context.Context, in *message.ReqHeaders) (*message.RespHeaders, error) {
+	if in.From < 1 || in.From > in.To {
+		return nil, grpc.Errorf(codes.InvalidArgument, "range [%d,%d]", in.From, in.To)
+	}
+	if in.To-in.From >= maxHeaders {
+		return nil, grpc.Errorf(codes.InvalidArgument, "range [%d,%d] exceeds %d headers", in.From, in.To, maxHeaders)
+	}
+
+	hs, err := g.Bc.GetHeaders(in.From, in.To)
+	if err != nil {
+		logger.Error("g.Bc.GetHeaders", zap.Error(err), zap.Uint64("from", in.From), zap.Uint64("to", in.To))
+		return nil, grpc.Errorf(codes.NotFound, "range [%d,%d] not found", in.From, in.To)
+	}
+
+	var respdata message.RespHeaders
+	for _, h := range hs {
+		respdata.Headers = append(respdata.Headers, headerToMsgHeader(h))
+	}
+	return &respdata, nil
+}
+
+// GetBlocks 获取从from到to的序列化的块和提交证书，一次最多maxBlocks个，to超过当前高度时只返回到当前高度
+// 没有提交证书的块对应的commits为空
+func (g *Greeter) GetBlocks(ctx context.Context, in *message.ReqBlocks) (*message.RespBlocks, error) {
+	if in.From < 1 || in.From > in.To {
+		return nil, grpc.Errorf(codes.InvalidArgument, "range [%d,%d]", in.From, in.To)
+	}
+	if in.To-in.From >= maxBlocks {
+		return nil, grpc.Errorf(codes.InvalidArgument, "range [%d,%d] exceeds %d blocks", in.From, in.To, maxBlocks)
+	}
+	height, err := g.Bc.GetHeight()
+	if err != nil {
+		logger.Error("g.Bc.GetHeight", zap.Error(err))
+		return nil, grpc.Errorf(codes.Internal, "failed to get height")
+	}
+	if in.From > height {
+		return nil, grpc.Errorf(codes.NotFound, "height %d is above %d", in.From, height)
+	}
+	if in.To > height {
+		in.To = height
+	}
+
+	bs, err := g.Bc.GetBlockSection(in.From, in.To)
+	if err != nil {
+		logger.Error("g.Bc.GetBlockSection", zap.Error(err), zap.Uint64("from", in.From), zap.Uint64("to", in.To))
+		return nil, grpc.Errorf(codes.NotFound, "range [%d,%d] not found", in.From, in.To)
+	}
+
+	respdata := message.RespBlocks{Height: height}
+	for _, b := range bs {
+		var commit []byte
+		if c, err := g.Bc.GetBlockCommit(b.Height); err == nil {
+			commit = c.Serialize()
+		}
+		respdata.Blocks = append(respdata.Blocks, b.Serialize())
+		respdata.Commits = append(respdata.Commits, commit)
+	}
+	return &respdata, nil
+}
+
+// GetBlockCommit 通过块高获取块的提交证书
+func (g *Greeter) GetBlockCommit(ctx context.Context, in *message.ReqBlockCommit) (*message.RespBlockCommit, error) {
+	c, err := g.Bc.GetBlockCommit(in.Height)
+	if err != nil {
+		logger.Error("g.Bc.GetBlockCommit", zap.Error(err), zap.Uint64("height", in.Height))
+		return nil, grpc.Errorf(codes.NotFound, "commit of height %d not found", in.Height)
+	}
+
+	respdata := message.RespBlockCommit{
+		Height: c.Height,
+		Hash:   hex.EncodeToString(c.Hash),
+		Round:  c.Round,
+	}
+	for _, sig := range c.Signatures {
+		respdata.Signatures = append(respdata.Signatures, &message.CommitSig{
+			Validator: sig.Validator,
+			Signature: hex.EncodeToString(sig.Signature),
+		})
+	}
 	return &respdata, nil
 }
 
@@ -312,6 +440,70 @@ func (g *Greeter) GetTxByHash(ctx context.Context, in *message.ReqTxByHash) (*me
 	return &message.RespTxByHash{Code: -1, Message: "未上链"}, nil
 }
 
+// GetTxProof 获取交易在块默克尔树中的证明路径和块头，用于不下载整个块验证交易
+func (g *Greeter) GetTxProof(ctx context.Context, in *message.ReqTxProof) (*message.RespTxProof, error) {
+	hash, err := hex.DecodeString(in.Hash)
+	if err != nil {
+		logger.Error("Faile to decode hash", zap.Error(err), zap.String("hash", in.Hash))
+		return nil, grpc.Errorf(codes.InvalidArgument, "hash %s", in.Hash)
+	}
+
+	b, index, proof, err := g.Bc.GetTxProof(hash)
+	if err != nil {
+		logger.Error("g.Bc.GetTxProof", zap.Error(err), zap.String("hash", in.Hash))
+		return nil, grpc.Errorf(codes.NotFound, "transaction %s not found", in.Hash)
+	}
+
+	msgTx := txToMsgTxAndOrder(b.Transactions[index])
+	respdata := message.RespTxProof{
+		Header: headerToMsgHeader(b.Header()),
+		Tx:     &msgTx,
+		Data:   hex.EncodeToString(b.TxsBytes()[index]),
+	}
+	for _, step := range proof {
+		respdata.Proof = append(respdata.Proof, &message.ProofStep{
+			Hash: hex.EncodeToString(step.Hash),
+			Left: step.Left,
+		})
+	}
+	return &respdata, nil
+}
+
+// GetAccountProof 获取账户在最新状态树中的证明和对应的块头，块头的状态根为空时用返回的状态根验证
+func (g *Greeter) GetAccountProof(ctx context.Context, in *message.ReqAccountProof) (*message.RespAccountProof, error) {
+	address, err := types.StringToAddress(in.Address)
+	if err != nil {
+		logger.Error("Failed to verify address", zap.Error(err), zap.String("address", in.Address))
+		return nil, grpc.Errorf(codes.InvalidArgument, "address %s", in.Address)
+	}
+
+	height, root, account, proof, err := g.Bc.GetAccountProof(address.Bytes())
+	if err != nil {
+		logger.Error("g.Bc.GetAccountProof", zap.Error(err), zap.String("address", in.Address))
+		return nil, grpc.Errorf(codes.Internal, "failed to get account proof")
+	}
+	respdata := message.RespAccountProof{StateRoot: hex.EncodeToString(root)}
+	//没有创世文件的链高度为0时没有块头
+	if h, err := g.Bc.GetHeaderByHeight(height); err == nil {
+		respdata.Header = headerToMsgHeader(h)
+	} else if height > 0 {
+		logger.Error("g.Bc.GetHeaderByHeight", zap.Error(err), zap.Uint64("height", height))
+		return nil, grpc.Errorf(codes.Internal, "failed to get block header")
+	}
+	if account != nil {
+		respdata.Exist = true
+		respdata.Balance = account.Balance
+		respdata.Nonce = account.Nonce
+		respdata.Freeze = account.Freeze
+		respdata.Pck = account.Pck
+		respdata.Dkto = account.DKto
+	}
+	for _, node := range proof {
+		respdata.Proof = append(respdata.Proof, hex.EncodeToString(node))
+	}
+	return &respdata, nil
+}
+
 // GetAddressNonceAt 获取该address的nonce，nonce是下次发送交易所需。
 func (g *Greeter) GetAddressNonceAt(ctx context.Context, in *message.ReqNonce) (*message.ResposeNonce, error) {
 	nonce, err := g.Bc.GetNonce([]byte(in.Address))
@@ -347,9 +539,12 @@ func (g *Greeter) SendTransaction(ctx context.Context, in *message.ReqTransactio
 		return nil, grpc.Errorf(codes.InvalidArgument, "private key:%s", in.Priv)
 	}
 
-	tx := transaction.ZNewTransaction(in.Nonce, in.Amount, *from, *to)
+	tx := transaction.ZNewTransaction(in.Nonce, in.Amount, *from, *to, transaction.WithFee(in.Fee),
+		transaction.WithValidUntil(in.ValidUntilHeight, in.ValidUntilTime))
+	//订单参与签名，Sign时重新计算hash
 	if in.Order != nil {
 		if len(in.Order.Address) == types.AddressSize {
+			tx.Order = &transaction.Order{}
 			for i, v := range []byte(in.Order.Address) {
 				tx.Order.Address[i] = v
 			}
@@ -427,9 +622,11 @@ func (g *Greeter) SendTransactions(ctx context.Context, in *message.ReqTransacti
 			continue
 		}
 
-		tx := transaction.ZNewTransaction(v.Nonce, v.Amount, *from, *to)
+		tx := transaction.ZNewTransaction(v.Nonce, v.Amount, *from, *to, transaction.WithFee(v.Fee),
+			transaction.WithValidUntil(v.ValidUntilHeight, v.ValidUntilTime))
 		if v.Order != nil {
 			if len(v.Order.Address) == types.AddressSize {
+				tx.Order = &transaction.Order{}
 				for i, v := range []byte(v.Order.Address) {
 					tx.Order.Address[i] = v
 				}
@@ -481,10 +678,70 @@ func (g *Greeter) SendTransactions(ctx context.Context, in *message.ReqTransacti
 	return &message.RespTransactions{HashList: hashList}, nil
 }
 
+// CancelTransaction 取消交易池中From在Nonce处的交易，用手续费更高的0金额自转账替换它
+func (g *Greeter) CancelTransaction(ctx context.Context, in *message.ReqCancelTransaction) (*message.RespCancelTransaction, error) {
+	from, err := types.StringToAddress(in.From)
+	if err != nil {
+		logger.Error("Parameters error", zap.String("from", in.From))
+		return nil, grpc.Errorf(codes.InvalidArgument, "from:%s", in.From)
+	}
+
+	priv := util.Decode(in.Priv)
+	if len(priv) != 64 {
+		logger.Info("private key", zap.String("privateKey", in.Priv))
+		return nil, grpc.Errorf(codes.InvalidArgument, "private key:%s", in.Priv)
+	}
+
+	pooled := g.tp.GetByNonce(*from, in.Nonce)
+	if pooled == nil {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "no pending transaction from %s at nonce %d", in.From, in.Nonce)
+	}
+	if in.Fee <= pooled.Fee {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "fee %d should > pending fee %d", in.Fee, pooled.Fee)
+	}
+
+	tx := transaction.ZNewTransaction(in.Nonce, 0, *from, *from, transaction.WithFee(in.Fee))
+	if err := tx.Sign(priv); err != nil {
+		logger.Error("failed to sign transaction", zap.Error(err))
+		return nil, grpc.Errorf(codes.InvalidArgument, "data error")
+	}
+
+	if err := g.tp.Add(tx, g.Bc); err != nil {
+		logger.Error("failed to add txpool", zap.Error(err))
+		return nil, grpc.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+
+	g.n.Broadcast(tx)
+	return &message.RespCancelTransaction{Hash: hex.EncodeToString(tx.Hash), Replaced: hex.EncodeToString(pooled.Hash)}, nil
+}
+
+// SubscribePoolEvents 推送交易池事件，直到客户端断开
+func (g *Greeter) SubscribePoolEvents(in *message.ReqPoolEvents, stream message.Greeter_SubscribePoolEventsServer) error {
+	ch := g.tp.Subscribe()
+	defer g.tp.Unsubscribe(ch)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case ev := <-ch:
+			msgTx := txToMsgTxAndOrder(ev.Tx)
+			msgEv := &message.PoolEvent{Type: ev.Type.String(), Tx: &msgTx}
+			if ev.Replaced != nil {
+				msgEv.Replaced = hex.EncodeToString(ev.Replaced.Hash)
+			}
+			if err := stream.Send(msgEv); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // SendSignedTransaction 将完整的交易发送到交易池，等待上链
 func (g *Greeter) SendSignedTransaction(ctx context.Context, in *message.ReqSignedTransaction) (*message.RespSignedTransaction, error) {
 
-	if in.From == in.To {
+	//金额为0的自转账是取消交易
+	if in.From == in.To && in.Amount != 0 {
 		logger.Info("From and To are the same", zap.String("from", in.From), zap.String("to", in.To))
 		return nil, grpc.Errorf(codes.InvalidArgument, "from:%s,to:%s", in.From, in.To)
 	}
@@ -509,9 +766,15 @@ func (g *Greeter) SendSignedTransaction(ctx context.Context, in *message.ReqSign
 		To:        *to,
 		Nonce:     in.Nonce,
 		Amount:    in.Amount,
+		Fee:       in.Fee,
 		Time:      in.Time,
 		Hash:      in.Hash,
 		Signature: in.Signature,
+
+		ValidUntilHeight: in.ValidUntilHeight,
+		ValidUntilTime:   in.ValidUntilTime,
+		ChainID:          in.ChainId,
+		Version:          in.Version,
 	}
 
 	if !tx.Verify() {
@@ -560,9 +823,15 @@ func (g *Greeter) SendSignedTransactions(ctx context.Context, in *message.ReqSig
 			To:        *to,
 			Nonce:     reqTx.Nonce,
 			Amount:    reqTx.Amount,
+			Fee:       reqTx.Fee,
 			Time:      reqTx.Time,
 			Hash:      reqTx.Hash,
 			Signature: reqTx.Signature,
+
+			ValidUntilHeight: reqTx.ValidUntilHeight,
+			ValidUntilTime:   reqTx.ValidUntilTime,
+			ChainID:          reqTx.ChainId,
+			Version:          reqTx.Version,
 		}
 
 		if !tx.Verify() {
@@ -842,6 +1111,8 @@ func (g *Greeter) SendSignedToken(ctx context.Context, in *message.ReqTokenTrans
 			Signature: reqTx.Signature,
 			Hash:      reqTx.Hash,
 			Time:      reqTx.Time,
+			ChainID:   reqTx.ChainId,
+			Version:   reqTx.Version,
 		}
 
 		if !tx.Verify() {
@@ -900,6 +1171,60 @@ func (g *Greeter) GetMaxBlockNumber(ctx context.Context, in *message.ReqMaxBlock
 	return &message.RespMaxBlockNumber{MaxNumber: maxNumber}, nil
 }
 
+// GetChainID 获取链ID，签名交易时需要带上
+func (g *Greeter) GetChainID(ctx context.Context, in *message.ReqChainId) (*message.RespChainId, error) {
+	return &message.RespChainId{ChainId: transaction.ChainID}, nil
+}
+
+// GetEmissionSchedule 获取出币计划，以及块高Height的出块奖励和块高1到Height的出块奖励总和，Height为0时使用当前块高
+func (g *Greeter) GetEmissionSchedule(ctx context.Context, in *message.ReqEmissionSchedule) (*message.RespEmissionSchedule, error) {
+	schedule, err := g.Bc.GetEmissionSchedule()
+	if err != nil {
+		logger.Error("failed to get emission schedule", zap.Error(err))
+		return nil, grpc.Errorf(codes.Internal, "failed to get emission schedule")
+	}
+
+	height := in.Height
+	if height == 0 {
+		if height, err = g.Bc.GetHeight(); err != nil {
+			logger.Error("failed to get height", zap.Error(err))
+			return nil, grpc.Errorf(codes.Internal, "failed to get height")
+		}
+	}
+
+	resp := &message.RespEmissionSchedule{Reward: schedule.Reward(height), Emitted: schedule.Emitted(height)}
+	for _, p := range schedule.Periods {
+		period := &message.EmissionPeriod{
+			StartHeight: p.StartHeight,
+			Reward:      p.Reward,
+			DecayPeriod: p.DecayPeriod,
+			DecayRate:   p.DecayRate,
+			Remainder:   p.Remainder,
+		}
+		for _, share := range p.Shares {
+			period.Shares = append(period.Shares, &message.EmissionShare{Recipient: share.Recipient, Weight: share.Weight, Fallback: share.Fallback})
+		}
+		resp.Periods = append(resp.Periods, period)
+	}
+	return resp, nil
+}
+
+// GetSupply 获取发行总量、锁仓总量、兑换pck注入资金池的KTO总量和流通量
+func (g *Greeter) GetSupply(ctx context.Context, in *message.ReqSupply) (*message.RespSupply, error) {
+	supply, err := g.Bc.GetSupply()
+	if err != nil {
+		logger.Error("failed to get supply", zap.Error(err))
+		return nil, grpc.Errorf(codes.Internal, "failed to get supply")
+	}
+	return &message.RespSupply{
+		Height:      supply.Height,
+		Minted:      supply.Minted,
+		Frozen:      supply.Frozen,
+		PckPool:     supply.PckPool,
+		Circulating: supply.Circulating,
+	}, nil
+}
+
 // GetAddrByPriv 通过私钥获取地址
 func (g *Greeter) GetAddrByPriv(ctx context.Context, in *message.ReqAddrByPriv) (*message.RespAddrByPriv, error) {
 	privBytes := util.Decode(in.Priv)
@@ -939,7 +1264,7 @@ func (g *Greeter) SignOrd(ctx context.Context, in *message.ReqSignOrd) (*message
 	hash32 := sha3.Sum256(hashBytes)
 	Hash := hex.EncodeToString(hash32[:])
 	pri := ed25519.PrivateKey(util.Decode(in.Priv))
-	signatures := ed25519.Sign(pri, hash32[:])
+	signatures := ed25519.Sign(pri, transaction.SignHash(hash32[:], transaction.ChainID))
 	Signature := hex.EncodeToString(signatures)
 	return &message.RespSignOrd{Hash: Hash, Signature: Signature}, nil
 }
@@ -987,6 +1312,8 @@ func (g *Greeter) SendFreezeTransactions(ctx context.Context, in *message.ReqSig
 			Hash:      hash,
 			Signature: signature,
 			Tag:       transaction.FreezeTag,
+			ChainID:   freezeTx.ChainId,
+			Version:   freezeTx.Version,
 		}
 		if !tx.Verify() {
 			logger.Error("failed to verify transaction", zap.String("to", tx.To.String()),
@@ -1055,6 +1382,8 @@ func (g *Greeter) SendUnfreezeTransactions(ctx context.Context, in *message.ReqS
 			Hash:      hash,
 			Signature: signature,
 			Tag:       transaction.UnfreezeTag,
+			ChainID:   unfreezeTx.ChainId,
+			Version:   unfreezeTx.Version,
 		}
 
 		if !tx.Verify() {
@@ -1081,6 +1410,100 @@ func (g *Greeter) SendUnfreezeTransactions(ctx context.Context, in *message.ReqS
 	return &message.RespSignedTransactions{HashList: hashList}, nil
 }
 
+// SendValidatorTransaction 管理员签名的添加或删除验证者交易，From和To都是管理员地址
+func (g *Greeter) SendValidatorTransaction(ctx context.Context, in *message.ReqValidatorTransaction) (*message.RespSignedTransaction, error) {
+	addr, err := types.StringToAddress(g.AdminAddr)
+	if err != nil {
+		logger.Error("Faile to change address", zap.String("address", g.AdminAddr))
+		return nil, grpc.Errorf(codes.InvalidArgument, "admin address:%s", g.AdminAddr)
+	}
+	if err := blockchain.CheckValidator(in.Validator); err != nil {
+		logger.Info("invalid validator", zap.Error(err))
+		return nil, grpc.Errorf(codes.InvalidArgument, "validator:%s", in.Validator)
+	}
+
+	tag := transaction.AddValidatorTag
+	if in.Remove {
+		tag = transaction.RemoveValidatorTag
+	}
+	tx := &transaction.Transaction{
+		From:      *addr,
+		To:        *addr,
+		Nonce:     in.Nonce,
+		Time:      in.Time,
+		Script:    in.Validator,
+		Hash:      in.Hash,
+		Signature: in.Signature,
+		Tag:       tag,
+
+		ValidUntilHeight: in.ValidUntilHeight,
+		ValidUntilTime:   in.ValidUntilTime,
+		ChainID:          in.ChainId,
+		Version:          in.Version,
+	}
+	if !tx.Verify() {
+		logger.Error("failed to verify transaction", zap.String("validator", in.Validator))
+		return nil, grpc.Errorf(codes.InvalidArgument, "sign verification failed")
+	}
+
+	if err := g.tp.Add(tx, g.Bc); err != nil {
+		logger.Error("Failed to add txpool", zap.Error(err), zap.String("validator", in.Validator), zap.Uint64("nonce", in.Nonce))
+		return nil, grpc.Errorf(codes.InvalidArgument, "invalid parameter")
+	}
+
+	g.n.Broadcast(tx)
+	return &message.RespSignedTransaction{Hash: hex.EncodeToString(tx.Hash)}, nil
+}
+
+// GetValidators 获取链上的验证者集合
+func (g *Greeter) GetValidators(ctx context.Context, in *message.ReqValidators) (*message.RespValidators, error) {
+	vals, err := g.Bc.GetValidators()
+	if err != nil {
+		logger.Error("failed to get validators", zap.Error(err))
+		return nil, grpc.Errorf(codes.Internal, "failed to get validators")
+	}
+	return &message.RespValidators{Validators: vals}, nil
+}
+
+// GetProposerSchedule 按当前的验证者集合和出块规则返回从下一个块开始的count个块的计划出块者，count为0时返回一轮
+func (g *Greeter) GetProposerSchedule(ctx context.Context, in *message.ReqProposerSchedule) (*message.RespProposerSchedule, error) {
+	params, err := g.Bc.GetChainParams()
+	if err != nil {
+		logger.Error("failed to get chain params", zap.Error(err))
+		return nil, grpc.Errorf(codes.Internal, "failed to get chain params")
+	}
+	term := params.ProposerTerm
+	if term == 0 {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "proposer rotation is disabled")
+	}
+	vals, err := g.Bc.GetValidators()
+	if err != nil {
+		logger.Error("failed to get validators", zap.Error(err))
+		return nil, grpc.Errorf(codes.Internal, "failed to get validators")
+	}
+	if len(vals) == 0 {
+		return nil, grpc.Errorf(codes.FailedPrecondition, "no validator set on chain")
+	}
+	height, err := g.Bc.GetHeight()
+	if err != nil {
+		logger.Error("failed to get height", zap.Error(err))
+		return nil, grpc.Errorf(codes.Internal, "failed to get height")
+	}
+
+	count := in.Count
+	if count == 0 {
+		count = term * uint64(len(vals))
+	}
+	if count > maxHeaders {
+		count = maxHeaders
+	}
+	resp := &message.RespProposerSchedule{Term: term}
+	for h := height + 1; h <= height+count; h++ {
+		resp.Slots = append(resp.Slots, &message.ProposerSlot{Height: h, Validator: blockchain.Proposer(vals, h, term)})
+	}
+	return resp, nil
+}
+
 // GetFreezeBalance 获取address已冻结的的金额
 func (g *Greeter) GetFreezeBalance(ctx context.Context, in *message.ReqGetFreezeBal) (*message.RespGetFreezeBal, error) {
 	var resp message.RespGetFreezeBal
@@ -1136,6 +1559,8 @@ func (s *Greeter) ConvertPck(ctx context.Context, in *message.ReqConvertPck) (*m
 		KtoNum:    in.KtoNum,
 		PckNum:    in.PckNum,
 		Tag:       transaction.ConvertPckTag,
+		ChainID:   in.ChainId,
+		Version:   in.Version,
 	}
 
 	if !tx.ConvertVerify() {
@@ -1181,6 +1606,8 @@ func (s *Greeter) ConvertKto(ctx context.Context, in *message.ReqConvertKto) (*m
 		KtoNum:    in.KtoNum,
 		PckNum:    in.PckNum,
 		Tag:       transaction.ConvertKtoTag,
+		ChainID:   in.ChainId,
+		Version:   in.Version,
 	}
 
 	if !tx.ConvertVerify() {
@@ -135,6 +135,10 @@ type Tx struct {
 	PckNum               uint64   `protobuf:"varint,13,opt,name=pckNum,proto3" json:"pckNum,omitempty"`
 	KtoNum               uint64   `protobuf:"varint,14,opt,name=ktoNum,proto3" json:"ktoNum,omitempty"`
 	Order                *Order   `protobuf:"bytes,15,opt,name=order,proto3" json:"order,omitempty"`
+	ValidUntilHeight     uint64   `protobuf:"varint,16,opt,name=ValidUntilHeight,proto3" json:"ValidUntilHeight,omitempty"`
+	ValidUntilTime       int64    `protobuf:"varint,17,opt,name=ValidUntilTime,proto3" json:"ValidUntilTime,omitempty"`
+	ChainID              uint64   `protobuf:"varint,18,opt,name=ChainID,proto3" json:"ChainID,omitempty"`
+	Version              uint32   `protobuf:"varint,19,opt,name=Version,proto3" json:"Version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -270,6 +274,34 @@ func (m *Tx) GetOrder() *Order {
 	return nil
 }
 
+func (m *Tx) GetValidUntilHeight() uint64 {
+	if m != nil {
+		return m.ValidUntilHeight
+	}
+	return 0
+}
+
+func (m *Tx) GetValidUntilTime() int64 {
+	if m != nil {
+		return m.ValidUntilTime
+	}
+	return 0
+}
+
+func (m *Tx) GetChainID() uint64 {
+	if m != nil {
+		return m.ChainID
+	}
+	return 0
+}
+
+func (m *Tx) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type ResTx struct {
 	Txs                  []*Tx    `protobuf:"bytes,1,rep,name=Txs,proto3" json:"Txs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -607,6 +639,7 @@ type RespBlock struct {
 	Timestamp            int64    `protobuf:"varint,6,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
 	Hash                 string   `protobuf:"bytes,7,opt,name=Hash,proto3" json:"Hash,omitempty"`
 	Miner                string   `protobuf:"bytes,8,opt,name=Miner,proto3" json:"Miner,omitempty"`
+	StateRoot            string   `protobuf:"bytes,9,opt,name=StateRoot,proto3" json:"StateRoot,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -693,662 +726,2378 @@ func (m *RespBlock) GetMiner() string {
 	return ""
 }
 
-type ResposeTxs struct {
-	Txs                  []*Tx    `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+func (m *RespBlock) GetStateRoot() string {
+	if m != nil {
+		return m.StateRoot
+	}
+	return ""
+}
+
+type ReqBlockCommit struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResposeTxs) Reset()         { *m = ResposeTxs{} }
-func (m *ResposeTxs) String() string { return proto.CompactTextString(m) }
-func (*ResposeTxs) ProtoMessage()    {}
-func (*ResposeTxs) Descriptor() ([]byte, []int) {
+func (m *ReqBlockCommit) Reset()         { *m = ReqBlockCommit{} }
+func (m *ReqBlockCommit) String() string { return proto.CompactTextString(m) }
+func (*ReqBlockCommit) ProtoMessage()    {}
+func (*ReqBlockCommit) Descriptor() ([]byte, []int) {
 	return fileDescriptor_33c57e4bae7b9afd, []int{11}
 }
 
-func (m *ResposeTxs) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResposeTxs.Unmarshal(m, b)
+func (m *ReqBlockCommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqBlockCommit.Unmarshal(m, b)
 }
-func (m *ResposeTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResposeTxs.Marshal(b, m, deterministic)
+func (m *ReqBlockCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqBlockCommit.Marshal(b, m, deterministic)
 }
-func (m *ResposeTxs) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResposeTxs.Merge(m, src)
+func (m *ReqBlockCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqBlockCommit.Merge(m, src)
 }
-func (m *ResposeTxs) XXX_Size() int {
-	return xxx_messageInfo_ResposeTxs.Size(m)
+func (m *ReqBlockCommit) XXX_Size() int {
+	return xxx_messageInfo_ReqBlockCommit.Size(m)
 }
-func (m *ResposeTxs) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResposeTxs.DiscardUnknown(m)
+func (m *ReqBlockCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqBlockCommit.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResposeTxs proto.InternalMessageInfo
+var xxx_messageInfo_ReqBlockCommit proto.InternalMessageInfo
 
-func (m *ResposeTxs) GetTxs() []*Tx {
+func (m *ReqBlockCommit) GetHeight() uint64 {
 	if m != nil {
-		return m.Txs
+		return m.Height
 	}
-	return nil
+	return 0
 }
 
-type ResposeNonce struct {
-	Nonce                uint64   `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+type CommitSig struct {
+	Validator            string   `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	Signature            string   `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResposeNonce) Reset()         { *m = ResposeNonce{} }
-func (m *ResposeNonce) String() string { return proto.CompactTextString(m) }
-func (*ResposeNonce) ProtoMessage()    {}
-func (*ResposeNonce) Descriptor() ([]byte, []int) {
+func (m *CommitSig) Reset()         { *m = CommitSig{} }
+func (m *CommitSig) String() string { return proto.CompactTextString(m) }
+func (*CommitSig) ProtoMessage()    {}
+func (*CommitSig) Descriptor() ([]byte, []int) {
 	return fileDescriptor_33c57e4bae7b9afd, []int{12}
 }
 
-func (m *ResposeNonce) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResposeNonce.Unmarshal(m, b)
+func (m *CommitSig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommitSig.Unmarshal(m, b)
 }
-func (m *ResposeNonce) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResposeNonce.Marshal(b, m, deterministic)
+func (m *CommitSig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommitSig.Marshal(b, m, deterministic)
 }
-func (m *ResposeNonce) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResposeNonce.Merge(m, src)
+func (m *CommitSig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommitSig.Merge(m, src)
 }
-func (m *ResposeNonce) XXX_Size() int {
-	return xxx_messageInfo_ResposeNonce.Size(m)
+func (m *CommitSig) XXX_Size() int {
+	return xxx_messageInfo_CommitSig.Size(m)
 }
-func (m *ResposeNonce) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResposeNonce.DiscardUnknown(m)
+func (m *CommitSig) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommitSig.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResposeNonce proto.InternalMessageInfo
+var xxx_messageInfo_CommitSig proto.InternalMessageInfo
 
-func (m *ResposeNonce) GetNonce() uint64 {
+func (m *CommitSig) GetValidator() string {
 	if m != nil {
-		return m.Nonce
+		return m.Validator
+	}
+	return ""
+}
+
+func (m *CommitSig) GetSignature() string {
+	if m != nil {
+		return m.Signature
+	}
+	return ""
+}
+
+type RespBlockCommit struct {
+	Height               uint64       `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash                 string       `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	Round                uint64       `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Signatures           []*CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RespBlockCommit) Reset()         { *m = RespBlockCommit{} }
+func (m *RespBlockCommit) String() string { return proto.CompactTextString(m) }
+func (*RespBlockCommit) ProtoMessage()    {}
+func (*RespBlockCommit) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{13}
+}
+
+func (m *RespBlockCommit) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespBlockCommit.Unmarshal(m, b)
+}
+func (m *RespBlockCommit) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespBlockCommit.Marshal(b, m, deterministic)
+}
+func (m *RespBlockCommit) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespBlockCommit.Merge(m, src)
+}
+func (m *RespBlockCommit) XXX_Size() int {
+	return xxx_messageInfo_RespBlockCommit.Size(m)
+}
+func (m *RespBlockCommit) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespBlockCommit.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespBlockCommit proto.InternalMessageInfo
+
+func (m *RespBlockCommit) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
 	}
 	return 0
 }
 
-type ReqNonce struct {
-	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+func (m *RespBlockCommit) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *RespBlockCommit) GetRound() uint64 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *RespBlockCommit) GetSignatures() []*CommitSig {
+	if m != nil {
+		return m.Signatures
+	}
+	return nil
+}
+
+type ReqTxProof struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReqNonce) Reset()         { *m = ReqNonce{} }
-func (m *ReqNonce) String() string { return proto.CompactTextString(m) }
-func (*ReqNonce) ProtoMessage()    {}
-func (*ReqNonce) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{13}
+func (m *ReqTxProof) Reset()         { *m = ReqTxProof{} }
+func (m *ReqTxProof) String() string { return proto.CompactTextString(m) }
+func (*ReqTxProof) ProtoMessage()    {}
+func (*ReqTxProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{14}
 }
 
-func (m *ReqNonce) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReqNonce.Unmarshal(m, b)
+func (m *ReqTxProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqTxProof.Unmarshal(m, b)
 }
-func (m *ReqNonce) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReqNonce.Marshal(b, m, deterministic)
+func (m *ReqTxProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqTxProof.Marshal(b, m, deterministic)
 }
-func (m *ReqNonce) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReqNonce.Merge(m, src)
+func (m *ReqTxProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqTxProof.Merge(m, src)
 }
-func (m *ReqNonce) XXX_Size() int {
-	return xxx_messageInfo_ReqNonce.Size(m)
+func (m *ReqTxProof) XXX_Size() int {
+	return xxx_messageInfo_ReqTxProof.Size(m)
 }
-func (m *ReqNonce) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReqNonce.DiscardUnknown(m)
+func (m *ReqTxProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqTxProof.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReqNonce proto.InternalMessageInfo
+var xxx_messageInfo_ReqTxProof proto.InternalMessageInfo
 
-func (m *ReqNonce) GetAddress() string {
+func (m *ReqTxProof) GetHash() string {
 	if m != nil {
-		return m.Address
+		return m.Hash
 	}
 	return ""
 }
 
-type ReqTransaction struct {
-	From                 string   `protobuf:"bytes,1,opt,name=From,proto3" json:"From,omitempty"`
-	To                   string   `protobuf:"bytes,2,opt,name=To,proto3" json:"To,omitempty"`
-	Amount               uint64   `protobuf:"varint,3,opt,name=Amount,proto3" json:"Amount,omitempty"`
-	Nonce                uint64   `protobuf:"varint,4,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
-	Priv                 string   `protobuf:"bytes,5,opt,name=Priv,proto3" json:"Priv,omitempty"`
-	Message              string   `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
-	Order                *Order   `protobuf:"bytes,7,opt,name=Order,proto3" json:"Order,omitempty"`
+type BlockHeader struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=Height,proto3" json:"Height,omitempty"`
+	PrevBlockHash        string   `protobuf:"bytes,2,opt,name=PrevBlockHash,proto3" json:"PrevBlockHash,omitempty"`
+	Root                 string   `protobuf:"bytes,3,opt,name=Root,proto3" json:"Root,omitempty"`
+	Version              uint64   `protobuf:"varint,4,opt,name=Version,proto3" json:"Version,omitempty"`
+	Timestamp            int64    `protobuf:"varint,5,opt,name=Timestamp,proto3" json:"Timestamp,omitempty"`
+	Hash                 string   `protobuf:"bytes,6,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Miner                string   `protobuf:"bytes,7,opt,name=Miner,proto3" json:"Miner,omitempty"`
+	StateRoot            string   `protobuf:"bytes,8,opt,name=StateRoot,proto3" json:"StateRoot,omitempty"`
+	TxCount              uint32   `protobuf:"varint,9,opt,name=TxCount,proto3" json:"TxCount,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReqTransaction) Reset()         { *m = ReqTransaction{} }
-func (m *ReqTransaction) String() string { return proto.CompactTextString(m) }
-func (*ReqTransaction) ProtoMessage()    {}
-func (*ReqTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{14}
+func (m *BlockHeader) Reset()         { *m = BlockHeader{} }
+func (m *BlockHeader) String() string { return proto.CompactTextString(m) }
+func (*BlockHeader) ProtoMessage()    {}
+func (*BlockHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{15}
 }
 
-func (m *ReqTransaction) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReqTransaction.Unmarshal(m, b)
+func (m *BlockHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BlockHeader.Unmarshal(m, b)
 }
-func (m *ReqTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReqTransaction.Marshal(b, m, deterministic)
+func (m *BlockHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BlockHeader.Marshal(b, m, deterministic)
 }
-func (m *ReqTransaction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReqTransaction.Merge(m, src)
+func (m *BlockHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockHeader.Merge(m, src)
 }
-func (m *ReqTransaction) XXX_Size() int {
-	return xxx_messageInfo_ReqTransaction.Size(m)
+func (m *BlockHeader) XXX_Size() int {
+	return xxx_messageInfo_BlockHeader.Size(m)
 }
-func (m *ReqTransaction) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReqTransaction.DiscardUnknown(m)
+func (m *BlockHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockHeader.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReqTransaction proto.InternalMessageInfo
+var xxx_messageInfo_BlockHeader proto.InternalMessageInfo
 
-func (m *ReqTransaction) GetFrom() string {
+func (m *BlockHeader) GetHeight() uint64 {
 	if m != nil {
-		return m.From
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockHeader) GetPrevBlockHash() string {
+	if m != nil {
+		return m.PrevBlockHash
 	}
 	return ""
 }
 
-func (m *ReqTransaction) GetTo() string {
+func (m *BlockHeader) GetRoot() string {
 	if m != nil {
-		return m.To
+		return m.Root
 	}
 	return ""
 }
 
-func (m *ReqTransaction) GetAmount() uint64 {
+func (m *BlockHeader) GetVersion() uint64 {
 	if m != nil {
-		return m.Amount
+		return m.Version
 	}
 	return 0
 }
 
-func (m *ReqTransaction) GetNonce() uint64 {
+func (m *BlockHeader) GetTimestamp() int64 {
 	if m != nil {
-		return m.Nonce
+		return m.Timestamp
 	}
 	return 0
 }
 
-func (m *ReqTransaction) GetPriv() string {
+func (m *BlockHeader) GetHash() string {
 	if m != nil {
-		return m.Priv
+		return m.Hash
 	}
 	return ""
 }
 
-func (m *ReqTransaction) GetMessage() string {
+func (m *BlockHeader) GetMiner() string {
 	if m != nil {
-		return m.Message
+		return m.Miner
 	}
 	return ""
 }
 
-func (m *ReqTransaction) GetOrder() *Order {
+func (m *BlockHeader) GetStateRoot() string {
 	if m != nil {
-		return m.Order
+		return m.StateRoot
 	}
-	return nil
+	return ""
 }
 
-type ResTransaction struct {
-	Hash                 string   `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+func (m *BlockHeader) GetTxCount() uint32 {
+	if m != nil {
+		return m.TxCount
+	}
+	return 0
+}
+
+type ReqHeaders struct {
+	From                 uint64   `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ResTransaction) Reset()         { *m = ResTransaction{} }
-func (m *ResTransaction) String() string { return proto.CompactTextString(m) }
-func (*ResTransaction) ProtoMessage()    {}
-func (*ResTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{15}
+func (m *ReqHeaders) Reset()         { *m = ReqHeaders{} }
+func (m *ReqHeaders) String() string { return proto.CompactTextString(m) }
+func (*ReqHeaders) ProtoMessage()    {}
+func (*ReqHeaders) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{16}
 }
 
-func (m *ResTransaction) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ResTransaction.Unmarshal(m, b)
+func (m *ReqHeaders) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqHeaders.Unmarshal(m, b)
 }
-func (m *ResTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ResTransaction.Marshal(b, m, deterministic)
+func (m *ReqHeaders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqHeaders.Marshal(b, m, deterministic)
 }
-func (m *ResTransaction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ResTransaction.Merge(m, src)
+func (m *ReqHeaders) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqHeaders.Merge(m, src)
 }
-func (m *ResTransaction) XXX_Size() int {
-	return xxx_messageInfo_ResTransaction.Size(m)
+func (m *ReqHeaders) XXX_Size() int {
+	return xxx_messageInfo_ReqHeaders.Size(m)
 }
-func (m *ResTransaction) XXX_DiscardUnknown() {
-	xxx_messageInfo_ResTransaction.DiscardUnknown(m)
+func (m *ReqHeaders) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqHeaders.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ResTransaction proto.InternalMessageInfo
+var xxx_messageInfo_ReqHeaders proto.InternalMessageInfo
 
-func (m *ResTransaction) GetHash() string {
+func (m *ReqHeaders) GetFrom() uint64 {
 	if m != nil {
-		return m.Hash
+		return m.From
 	}
-	return ""
+	return 0
 }
 
-type ReqTransactions struct {
-	Txs                  []*ReqTransaction `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *ReqHeaders) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
 }
 
-func (m *ReqTransactions) Reset()         { *m = ReqTransactions{} }
-func (m *ReqTransactions) String() string { return proto.CompactTextString(m) }
-func (*ReqTransactions) ProtoMessage()    {}
-func (*ReqTransactions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{16}
+type RespHeaders struct {
+	Headers              []*BlockHeader `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *ReqTransactions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReqTransactions.Unmarshal(m, b)
+func (m *RespHeaders) Reset()         { *m = RespHeaders{} }
+func (m *RespHeaders) String() string { return proto.CompactTextString(m) }
+func (*RespHeaders) ProtoMessage()    {}
+func (*RespHeaders) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{17}
 }
-func (m *ReqTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReqTransactions.Marshal(b, m, deterministic)
+
+func (m *RespHeaders) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespHeaders.Unmarshal(m, b)
 }
-func (m *ReqTransactions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReqTransactions.Merge(m, src)
+func (m *RespHeaders) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespHeaders.Marshal(b, m, deterministic)
 }
-func (m *ReqTransactions) XXX_Size() int {
-	return xxx_messageInfo_ReqTransactions.Size(m)
+func (m *RespHeaders) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespHeaders.Merge(m, src)
 }
-func (m *ReqTransactions) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReqTransactions.DiscardUnknown(m)
+func (m *RespHeaders) XXX_Size() int {
+	return xxx_messageInfo_RespHeaders.Size(m)
+}
+func (m *RespHeaders) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespHeaders.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReqTransactions proto.InternalMessageInfo
+var xxx_messageInfo_RespHeaders proto.InternalMessageInfo
 
-func (m *ReqTransactions) GetTxs() []*ReqTransaction {
+func (m *RespHeaders) GetHeaders() []*BlockHeader {
 	if m != nil {
-		return m.Txs
+		return m.Headers
 	}
 	return nil
 }
 
-type RespTransactions struct {
-	HashList             []*HashMsg `protobuf:"bytes,1,rep,name=hashList,proto3" json:"hashList,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type ReqBlocks struct {
+	From                 uint64   `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   uint64   `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RespTransactions) Reset()         { *m = RespTransactions{} }
-func (m *RespTransactions) String() string { return proto.CompactTextString(m) }
-func (*RespTransactions) ProtoMessage()    {}
-func (*RespTransactions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{17}
+func (m *ReqBlocks) Reset()         { *m = ReqBlocks{} }
+func (m *ReqBlocks) String() string { return proto.CompactTextString(m) }
+func (*ReqBlocks) ProtoMessage()    {}
+func (*ReqBlocks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{18}
 }
 
-func (m *RespTransactions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RespTransactions.Unmarshal(m, b)
+func (m *ReqBlocks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqBlocks.Unmarshal(m, b)
 }
-func (m *RespTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RespTransactions.Marshal(b, m, deterministic)
+func (m *ReqBlocks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqBlocks.Marshal(b, m, deterministic)
 }
-func (m *RespTransactions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RespTransactions.Merge(m, src)
+func (m *ReqBlocks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqBlocks.Merge(m, src)
 }
-func (m *RespTransactions) XXX_Size() int {
-	return xxx_messageInfo_RespTransactions.Size(m)
+func (m *ReqBlocks) XXX_Size() int {
+	return xxx_messageInfo_ReqBlocks.Size(m)
 }
-func (m *RespTransactions) XXX_DiscardUnknown() {
-	xxx_messageInfo_RespTransactions.DiscardUnknown(m)
+func (m *ReqBlocks) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqBlocks.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RespTransactions proto.InternalMessageInfo
+var xxx_messageInfo_ReqBlocks proto.InternalMessageInfo
 
-func (m *RespTransactions) GetHashList() []*HashMsg {
+func (m *ReqBlocks) GetFrom() uint64 {
 	if m != nil {
-		return m.HashList
+		return m.From
 	}
-	return nil
+	return 0
 }
 
-type ReqSignedTransaction struct {
-	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
-	To                   string   `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
-	Amount               uint64   `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Nonce                uint64   `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	Time                 int64    `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
-	Hash                 []byte   `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"`
-	Signature            []byte   `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+func (m *ReqBlocks) GetTo() uint64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type RespBlocks struct {
+	Blocks               [][]byte `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	Commits              [][]byte `protobuf:"bytes,2,rep,name=commits,proto3" json:"commits,omitempty"`
+	Height               uint64   `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReqSignedTransaction) Reset()         { *m = ReqSignedTransaction{} }
-func (m *ReqSignedTransaction) String() string { return proto.CompactTextString(m) }
-func (*ReqSignedTransaction) ProtoMessage()    {}
-func (*ReqSignedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{18}
+func (m *RespBlocks) Reset()         { *m = RespBlocks{} }
+func (m *RespBlocks) String() string { return proto.CompactTextString(m) }
+func (*RespBlocks) ProtoMessage()    {}
+func (*RespBlocks) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{19}
 }
 
-func (m *ReqSignedTransaction) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReqSignedTransaction.Unmarshal(m, b)
+func (m *RespBlocks) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespBlocks.Unmarshal(m, b)
 }
-func (m *ReqSignedTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReqSignedTransaction.Marshal(b, m, deterministic)
+func (m *RespBlocks) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespBlocks.Marshal(b, m, deterministic)
 }
-func (m *ReqSignedTransaction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReqSignedTransaction.Merge(m, src)
+func (m *RespBlocks) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespBlocks.Merge(m, src)
 }
-func (m *ReqSignedTransaction) XXX_Size() int {
-	return xxx_messageInfo_ReqSignedTransaction.Size(m)
+func (m *RespBlocks) XXX_Size() int {
+	return xxx_messageInfo_RespBlocks.Size(m)
 }
-func (m *ReqSignedTransaction) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReqSignedTransaction.DiscardUnknown(m)
+func (m *RespBlocks) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespBlocks.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReqSignedTransaction proto.InternalMessageInfo
+var xxx_messageInfo_RespBlocks proto.InternalMessageInfo
 
-func (m *ReqSignedTransaction) GetFrom() string {
+func (m *RespBlocks) GetBlocks() [][]byte {
 	if m != nil {
-		return m.From
+		return m.Blocks
 	}
-	return ""
+	return nil
 }
 
-func (m *ReqSignedTransaction) GetTo() string {
+func (m *RespBlocks) GetCommits() [][]byte {
 	if m != nil {
-		return m.To
+		return m.Commits
 	}
-	return ""
+	return nil
 }
 
-func (m *ReqSignedTransaction) GetAmount() uint64 {
+func (m *RespBlocks) GetHeight() uint64 {
 	if m != nil {
-		return m.Amount
+		return m.Height
 	}
 	return 0
 }
 
-func (m *ReqSignedTransaction) GetNonce() uint64 {
+type ProofStep struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Left                 bool     `protobuf:"varint,2,opt,name=left,proto3" json:"left,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProofStep) Reset()         { *m = ProofStep{} }
+func (m *ProofStep) String() string { return proto.CompactTextString(m) }
+func (*ProofStep) ProtoMessage()    {}
+func (*ProofStep) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{20}
+}
+
+func (m *ProofStep) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProofStep.Unmarshal(m, b)
+}
+func (m *ProofStep) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProofStep.Marshal(b, m, deterministic)
+}
+func (m *ProofStep) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProofStep.Merge(m, src)
+}
+func (m *ProofStep) XXX_Size() int {
+	return xxx_messageInfo_ProofStep.Size(m)
+}
+func (m *ProofStep) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProofStep.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProofStep proto.InternalMessageInfo
+
+func (m *ProofStep) GetHash() string {
 	if m != nil {
-		return m.Nonce
+		return m.Hash
 	}
-	return 0
+	return ""
 }
 
-func (m *ReqSignedTransaction) GetTime() int64 {
+func (m *ProofStep) GetLeft() bool {
 	if m != nil {
-		return m.Time
+		return m.Left
 	}
-	return 0
+	return false
 }
 
-func (m *ReqSignedTransaction) GetHash() []byte {
+type RespTxProof struct {
+	Header               *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	Tx                   *Tx          `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	Data                 string       `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Proof                []*ProofStep `protobuf:"bytes,4,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RespTxProof) Reset()         { *m = RespTxProof{} }
+func (m *RespTxProof) String() string { return proto.CompactTextString(m) }
+func (*RespTxProof) ProtoMessage()    {}
+func (*RespTxProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{21}
+}
+
+func (m *RespTxProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespTxProof.Unmarshal(m, b)
+}
+func (m *RespTxProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespTxProof.Marshal(b, m, deterministic)
+}
+func (m *RespTxProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespTxProof.Merge(m, src)
+}
+func (m *RespTxProof) XXX_Size() int {
+	return xxx_messageInfo_RespTxProof.Size(m)
+}
+func (m *RespTxProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespTxProof.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespTxProof proto.InternalMessageInfo
+
+func (m *RespTxProof) GetHeader() *BlockHeader {
 	if m != nil {
-		return m.Hash
+		return m.Header
 	}
 	return nil
 }
 
-func (m *ReqSignedTransaction) GetSignature() []byte {
+func (m *RespTxProof) GetTx() *Tx {
 	if m != nil {
-		return m.Signature
+		return m.Tx
 	}
 	return nil
 }
 
-type RespSignedTransaction struct {
-	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+func (m *RespTxProof) GetData() string {
+	if m != nil {
+		return m.Data
+	}
+	return ""
+}
+
+func (m *RespTxProof) GetProof() []*ProofStep {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
+type ReqAccountProof struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RespSignedTransaction) Reset()         { *m = RespSignedTransaction{} }
-func (m *RespSignedTransaction) String() string { return proto.CompactTextString(m) }
-func (*RespSignedTransaction) ProtoMessage()    {}
-func (*RespSignedTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{19}
+func (m *ReqAccountProof) Reset()         { *m = ReqAccountProof{} }
+func (m *ReqAccountProof) String() string { return proto.CompactTextString(m) }
+func (*ReqAccountProof) ProtoMessage()    {}
+func (*ReqAccountProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{22}
 }
 
-func (m *RespSignedTransaction) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RespSignedTransaction.Unmarshal(m, b)
+func (m *ReqAccountProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqAccountProof.Unmarshal(m, b)
 }
-func (m *RespSignedTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RespSignedTransaction.Marshal(b, m, deterministic)
+func (m *ReqAccountProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqAccountProof.Marshal(b, m, deterministic)
 }
-func (m *RespSignedTransaction) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RespSignedTransaction.Merge(m, src)
+func (m *ReqAccountProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqAccountProof.Merge(m, src)
 }
-func (m *RespSignedTransaction) XXX_Size() int {
-	return xxx_messageInfo_RespSignedTransaction.Size(m)
+func (m *ReqAccountProof) XXX_Size() int {
+	return xxx_messageInfo_ReqAccountProof.Size(m)
 }
-func (m *RespSignedTransaction) XXX_DiscardUnknown() {
-	xxx_messageInfo_RespSignedTransaction.DiscardUnknown(m)
+func (m *ReqAccountProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqAccountProof.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RespSignedTransaction proto.InternalMessageInfo
+var xxx_messageInfo_ReqAccountProof proto.InternalMessageInfo
 
-func (m *RespSignedTransaction) GetHash() string {
+func (m *ReqAccountProof) GetAddress() string {
 	if m != nil {
-		return m.Hash
+		return m.Address
 	}
 	return ""
 }
 
-type HashMsg struct {
-	Code                 int64    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Hash                 string   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type RespAccountProof struct {
+	Header               *BlockHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	StateRoot            string       `protobuf:"bytes,2,opt,name=stateRoot,proto3" json:"stateRoot,omitempty"`
+	Exist                bool         `protobuf:"varint,3,opt,name=exist,proto3" json:"exist,omitempty"`
+	Balance              uint64       `protobuf:"varint,4,opt,name=balance,proto3" json:"balance,omitempty"`
+	Nonce                uint64       `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Freeze               uint64       `protobuf:"varint,6,opt,name=freeze,proto3" json:"freeze,omitempty"`
+	Pck                  uint64       `protobuf:"varint,7,opt,name=pck,proto3" json:"pck,omitempty"`
+	Dkto                 uint64       `protobuf:"varint,8,opt,name=dkto,proto3" json:"dkto,omitempty"`
+	Proof                []string     `protobuf:"bytes,9,rep,name=proof,proto3" json:"proof,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *RespAccountProof) Reset()         { *m = RespAccountProof{} }
+func (m *RespAccountProof) String() string { return proto.CompactTextString(m) }
+func (*RespAccountProof) ProtoMessage()    {}
+func (*RespAccountProof) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{23}
 }
 
-func (m *HashMsg) Reset()         { *m = HashMsg{} }
-func (m *HashMsg) String() string { return proto.CompactTextString(m) }
-func (*HashMsg) ProtoMessage()    {}
-func (*HashMsg) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{20}
+func (m *RespAccountProof) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespAccountProof.Unmarshal(m, b)
+}
+func (m *RespAccountProof) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespAccountProof.Marshal(b, m, deterministic)
+}
+func (m *RespAccountProof) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespAccountProof.Merge(m, src)
+}
+func (m *RespAccountProof) XXX_Size() int {
+	return xxx_messageInfo_RespAccountProof.Size(m)
+}
+func (m *RespAccountProof) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespAccountProof.DiscardUnknown(m)
 }
 
-func (m *HashMsg) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_HashMsg.Unmarshal(m, b)
+var xxx_messageInfo_RespAccountProof proto.InternalMessageInfo
+
+func (m *RespAccountProof) GetHeader() *BlockHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
 }
-func (m *HashMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_HashMsg.Marshal(b, m, deterministic)
+
+func (m *RespAccountProof) GetStateRoot() string {
+	if m != nil {
+		return m.StateRoot
+	}
+	return ""
 }
-func (m *HashMsg) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HashMsg.Merge(m, src)
+
+func (m *RespAccountProof) GetExist() bool {
+	if m != nil {
+		return m.Exist
+	}
+	return false
 }
-func (m *HashMsg) XXX_Size() int {
-	return xxx_messageInfo_HashMsg.Size(m)
+
+func (m *RespAccountProof) GetBalance() uint64 {
+	if m != nil {
+		return m.Balance
+	}
+	return 0
 }
-func (m *HashMsg) XXX_DiscardUnknown() {
-	xxx_messageInfo_HashMsg.DiscardUnknown(m)
+
+func (m *RespAccountProof) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
 }
 
-var xxx_messageInfo_HashMsg proto.InternalMessageInfo
+func (m *RespAccountProof) GetFreeze() uint64 {
+	if m != nil {
+		return m.Freeze
+	}
+	return 0
+}
 
-func (m *HashMsg) GetCode() int64 {
+func (m *RespAccountProof) GetPck() uint64 {
 	if m != nil {
-		return m.Code
+		return m.Pck
 	}
 	return 0
 }
 
-func (m *HashMsg) GetMessage() string {
+func (m *RespAccountProof) GetDkto() uint64 {
 	if m != nil {
-		return m.Message
+		return m.Dkto
 	}
-	return ""
+	return 0
 }
 
-func (m *HashMsg) GetHash() string {
+func (m *RespAccountProof) GetProof() []string {
 	if m != nil {
-		return m.Hash
+		return m.Proof
 	}
-	return ""
+	return nil
 }
 
-type ReqSignedTransactions struct {
-	Txs                  []*ReqSignedTransaction `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+type ResposeTxs struct {
+	Txs                  []*Tx    `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReqSignedTransactions) Reset()         { *m = ReqSignedTransactions{} }
-func (m *ReqSignedTransactions) String() string { return proto.CompactTextString(m) }
-func (*ReqSignedTransactions) ProtoMessage()    {}
-func (*ReqSignedTransactions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{21}
+func (m *ResposeTxs) Reset()         { *m = ResposeTxs{} }
+func (m *ResposeTxs) String() string { return proto.CompactTextString(m) }
+func (*ResposeTxs) ProtoMessage()    {}
+func (*ResposeTxs) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{24}
 }
 
-func (m *ReqSignedTransactions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReqSignedTransactions.Unmarshal(m, b)
+func (m *ResposeTxs) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResposeTxs.Unmarshal(m, b)
 }
-func (m *ReqSignedTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReqSignedTransactions.Marshal(b, m, deterministic)
+func (m *ResposeTxs) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResposeTxs.Marshal(b, m, deterministic)
 }
-func (m *ReqSignedTransactions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReqSignedTransactions.Merge(m, src)
+func (m *ResposeTxs) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResposeTxs.Merge(m, src)
 }
-func (m *ReqSignedTransactions) XXX_Size() int {
-	return xxx_messageInfo_ReqSignedTransactions.Size(m)
+func (m *ResposeTxs) XXX_Size() int {
+	return xxx_messageInfo_ResposeTxs.Size(m)
 }
-func (m *ReqSignedTransactions) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReqSignedTransactions.DiscardUnknown(m)
+func (m *ResposeTxs) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResposeTxs.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReqSignedTransactions proto.InternalMessageInfo
+var xxx_messageInfo_ResposeTxs proto.InternalMessageInfo
 
-func (m *ReqSignedTransactions) GetTxs() []*ReqSignedTransaction {
+func (m *ResposeTxs) GetTxs() []*Tx {
 	if m != nil {
 		return m.Txs
 	}
 	return nil
 }
 
-type RespSignedTransactions struct {
-	HashList             []*HashMsg `protobuf:"bytes,1,rep,name=hashList,proto3" json:"hashList,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type ResposeNonce struct {
+	Nonce                uint64   `protobuf:"varint,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResposeNonce) Reset()         { *m = ResposeNonce{} }
+func (m *ResposeNonce) String() string { return proto.CompactTextString(m) }
+func (*ResposeNonce) ProtoMessage()    {}
+func (*ResposeNonce) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{25}
+}
+
+func (m *ResposeNonce) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResposeNonce.Unmarshal(m, b)
+}
+func (m *ResposeNonce) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResposeNonce.Marshal(b, m, deterministic)
+}
+func (m *ResposeNonce) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResposeNonce.Merge(m, src)
+}
+func (m *ResposeNonce) XXX_Size() int {
+	return xxx_messageInfo_ResposeNonce.Size(m)
+}
+func (m *ResposeNonce) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResposeNonce.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResposeNonce proto.InternalMessageInfo
+
+func (m *ResposeNonce) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+type ReqNonce struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqNonce) Reset()         { *m = ReqNonce{} }
+func (m *ReqNonce) String() string { return proto.CompactTextString(m) }
+func (*ReqNonce) ProtoMessage()    {}
+func (*ReqNonce) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{26}
+}
+
+func (m *ReqNonce) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqNonce.Unmarshal(m, b)
+}
+func (m *ReqNonce) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqNonce.Marshal(b, m, deterministic)
+}
+func (m *ReqNonce) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqNonce.Merge(m, src)
+}
+func (m *ReqNonce) XXX_Size() int {
+	return xxx_messageInfo_ReqNonce.Size(m)
+}
+func (m *ReqNonce) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqNonce.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqNonce proto.InternalMessageInfo
+
+func (m *ReqNonce) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+type ReqTransaction struct {
+	From    string `protobuf:"bytes,1,opt,name=From,proto3" json:"From,omitempty"`
+	To      string `protobuf:"bytes,2,opt,name=To,proto3" json:"To,omitempty"`
+	Amount  uint64 `protobuf:"varint,3,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Nonce   uint64 `protobuf:"varint,4,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	Priv    string `protobuf:"bytes,5,opt,name=Priv,proto3" json:"Priv,omitempty"`
+	Message string `protobuf:"bytes,6,opt,name=message,proto3" json:"message,omitempty"`
+	Order   *Order `protobuf:"bytes,7,opt,name=Order,proto3" json:"Order,omitempty"`
+	Fee     uint64 `protobuf:"varint,8,opt,name=Fee,proto3" json:"Fee,omitempty"`
+	//交易可以上链的最大块高和最晚时间，0表示不限制
+	ValidUntilHeight     uint64   `protobuf:"varint,9,opt,name=ValidUntilHeight,proto3" json:"ValidUntilHeight,omitempty"`
+	ValidUntilTime       int64    `protobuf:"varint,10,opt,name=ValidUntilTime,proto3" json:"ValidUntilTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqTransaction) Reset()         { *m = ReqTransaction{} }
+func (m *ReqTransaction) String() string { return proto.CompactTextString(m) }
+func (*ReqTransaction) ProtoMessage()    {}
+func (*ReqTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{27}
+}
+
+func (m *ReqTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqTransaction.Unmarshal(m, b)
+}
+func (m *ReqTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqTransaction.Marshal(b, m, deterministic)
+}
+func (m *ReqTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqTransaction.Merge(m, src)
+}
+func (m *ReqTransaction) XXX_Size() int {
+	return xxx_messageInfo_ReqTransaction.Size(m)
+}
+func (m *ReqTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqTransaction proto.InternalMessageInfo
+
+func (m *ReqTransaction) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ReqTransaction) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ReqTransaction) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReqTransaction) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ReqTransaction) GetPriv() string {
+	if m != nil {
+		return m.Priv
+	}
+	return ""
+}
+
+func (m *ReqTransaction) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ReqTransaction) GetOrder() *Order {
+	if m != nil {
+		return m.Order
+	}
+	return nil
+}
+
+func (m *ReqTransaction) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *ReqTransaction) GetValidUntilHeight() uint64 {
+	if m != nil {
+		return m.ValidUntilHeight
+	}
+	return 0
+}
+
+func (m *ReqTransaction) GetValidUntilTime() int64 {
+	if m != nil {
+		return m.ValidUntilTime
+	}
+	return 0
+}
+
+type ResTransaction struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResTransaction) Reset()         { *m = ResTransaction{} }
+func (m *ResTransaction) String() string { return proto.CompactTextString(m) }
+func (*ResTransaction) ProtoMessage()    {}
+func (*ResTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{28}
+}
+
+func (m *ResTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResTransaction.Unmarshal(m, b)
+}
+func (m *ResTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResTransaction.Marshal(b, m, deterministic)
+}
+func (m *ResTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResTransaction.Merge(m, src)
+}
+func (m *ResTransaction) XXX_Size() int {
+	return xxx_messageInfo_ResTransaction.Size(m)
+}
+func (m *ResTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResTransaction proto.InternalMessageInfo
+
+func (m *ResTransaction) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+// 取消交易池中from在nonce处的交易，fee需高于被取消交易的手续费
+type ReqCancelTransaction struct {
+	From                 string   `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Nonce                uint64   `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Fee                  uint64   `protobuf:"varint,3,opt,name=fee,proto3" json:"fee,omitempty"`
+	Priv                 string   `protobuf:"bytes,4,opt,name=priv,proto3" json:"priv,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqCancelTransaction) Reset()         { *m = ReqCancelTransaction{} }
+func (m *ReqCancelTransaction) String() string { return proto.CompactTextString(m) }
+func (*ReqCancelTransaction) ProtoMessage()    {}
+func (*ReqCancelTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{29}
+}
+
+func (m *ReqCancelTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqCancelTransaction.Unmarshal(m, b)
+}
+func (m *ReqCancelTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqCancelTransaction.Marshal(b, m, deterministic)
+}
+func (m *ReqCancelTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqCancelTransaction.Merge(m, src)
+}
+func (m *ReqCancelTransaction) XXX_Size() int {
+	return xxx_messageInfo_ReqCancelTransaction.Size(m)
+}
+func (m *ReqCancelTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqCancelTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqCancelTransaction proto.InternalMessageInfo
+
+func (m *ReqCancelTransaction) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ReqCancelTransaction) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ReqCancelTransaction) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *ReqCancelTransaction) GetPriv() string {
+	if m != nil {
+		return m.Priv
+	}
+	return ""
+}
+
+type RespCancelTransaction struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Replaced             string   `protobuf:"bytes,2,opt,name=replaced,proto3" json:"replaced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespCancelTransaction) Reset()         { *m = RespCancelTransaction{} }
+func (m *RespCancelTransaction) String() string { return proto.CompactTextString(m) }
+func (*RespCancelTransaction) ProtoMessage()    {}
+func (*RespCancelTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{30}
+}
+
+func (m *RespCancelTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespCancelTransaction.Unmarshal(m, b)
+}
+func (m *RespCancelTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespCancelTransaction.Marshal(b, m, deterministic)
+}
+func (m *RespCancelTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespCancelTransaction.Merge(m, src)
+}
+func (m *RespCancelTransaction) XXX_Size() int {
+	return xxx_messageInfo_RespCancelTransaction.Size(m)
+}
+func (m *RespCancelTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespCancelTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespCancelTransaction proto.InternalMessageInfo
+
+func (m *RespCancelTransaction) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+func (m *RespCancelTransaction) GetReplaced() string {
+	if m != nil {
+		return m.Replaced
+	}
+	return ""
+}
+
+type ReqPoolEvents struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqPoolEvents) Reset()         { *m = ReqPoolEvents{} }
+func (m *ReqPoolEvents) String() string { return proto.CompactTextString(m) }
+func (*ReqPoolEvents) ProtoMessage()    {}
+func (*ReqPoolEvents) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{31}
+}
+
+func (m *ReqPoolEvents) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqPoolEvents.Unmarshal(m, b)
+}
+func (m *ReqPoolEvents) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqPoolEvents.Marshal(b, m, deterministic)
+}
+func (m *ReqPoolEvents) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqPoolEvents.Merge(m, src)
+}
+func (m *ReqPoolEvents) XXX_Size() int {
+	return xxx_messageInfo_ReqPoolEvents.Size(m)
+}
+func (m *ReqPoolEvents) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqPoolEvents.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqPoolEvents proto.InternalMessageInfo
+
+type PoolEvent struct {
+	Type                 string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Tx                   *Tx      `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+	Replaced             string   `protobuf:"bytes,3,opt,name=replaced,proto3" json:"replaced,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PoolEvent) Reset()         { *m = PoolEvent{} }
+func (m *PoolEvent) String() string { return proto.CompactTextString(m) }
+func (*PoolEvent) ProtoMessage()    {}
+func (*PoolEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{32}
+}
+
+func (m *PoolEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PoolEvent.Unmarshal(m, b)
+}
+func (m *PoolEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PoolEvent.Marshal(b, m, deterministic)
+}
+func (m *PoolEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PoolEvent.Merge(m, src)
+}
+func (m *PoolEvent) XXX_Size() int {
+	return xxx_messageInfo_PoolEvent.Size(m)
+}
+func (m *PoolEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_PoolEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PoolEvent proto.InternalMessageInfo
+
+func (m *PoolEvent) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *PoolEvent) GetTx() *Tx {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+func (m *PoolEvent) GetReplaced() string {
+	if m != nil {
+		return m.Replaced
+	}
+	return ""
+}
+
+type ReqTransactions struct {
+	Txs                  []*ReqTransaction `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ReqTransactions) Reset()         { *m = ReqTransactions{} }
+func (m *ReqTransactions) String() string { return proto.CompactTextString(m) }
+func (*ReqTransactions) ProtoMessage()    {}
+func (*ReqTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{33}
+}
+
+func (m *ReqTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqTransactions.Unmarshal(m, b)
+}
+func (m *ReqTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqTransactions.Marshal(b, m, deterministic)
+}
+func (m *ReqTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqTransactions.Merge(m, src)
+}
+func (m *ReqTransactions) XXX_Size() int {
+	return xxx_messageInfo_ReqTransactions.Size(m)
+}
+func (m *ReqTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqTransactions proto.InternalMessageInfo
+
+func (m *ReqTransactions) GetTxs() []*ReqTransaction {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+type RespTransactions struct {
+	HashList             []*HashMsg `protobuf:"bytes,1,rep,name=hashList,proto3" json:"hashList,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RespTransactions) Reset()         { *m = RespTransactions{} }
+func (m *RespTransactions) String() string { return proto.CompactTextString(m) }
+func (*RespTransactions) ProtoMessage()    {}
+func (*RespTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{34}
+}
+
+func (m *RespTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespTransactions.Unmarshal(m, b)
+}
+func (m *RespTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespTransactions.Marshal(b, m, deterministic)
+}
+func (m *RespTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespTransactions.Merge(m, src)
+}
+func (m *RespTransactions) XXX_Size() int {
+	return xxx_messageInfo_RespTransactions.Size(m)
+}
+func (m *RespTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespTransactions proto.InternalMessageInfo
+
+func (m *RespTransactions) GetHashList() []*HashMsg {
+	if m != nil {
+		return m.HashList
+	}
+	return nil
+}
+
+type ReqSignedTransaction struct {
+	From             string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To               string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	Amount           uint64 `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Nonce            uint64 `protobuf:"varint,4,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Time             int64  `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`
+	Hash             []byte `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"`
+	Signature        []byte `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
+	Fee              uint64 `protobuf:"varint,8,opt,name=fee,proto3" json:"fee,omitempty"`
+	ValidUntilHeight uint64 `protobuf:"varint,9,opt,name=valid_until_height,json=validUntilHeight,proto3" json:"valid_until_height,omitempty"`
+	ValidUntilTime   int64  `protobuf:"varint,10,opt,name=valid_until_time,json=validUntilTime,proto3" json:"valid_until_time,omitempty"`
+	//交易所属链的ID，参与hash
+	ChainId              uint64   `protobuf:"varint,11,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              uint32   `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqSignedTransaction) Reset()         { *m = ReqSignedTransaction{} }
+func (m *ReqSignedTransaction) String() string { return proto.CompactTextString(m) }
+func (*ReqSignedTransaction) ProtoMessage()    {}
+func (*ReqSignedTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{35}
+}
+
+func (m *ReqSignedTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqSignedTransaction.Unmarshal(m, b)
+}
+func (m *ReqSignedTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqSignedTransaction.Marshal(b, m, deterministic)
+}
+func (m *ReqSignedTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqSignedTransaction.Merge(m, src)
+}
+func (m *ReqSignedTransaction) XXX_Size() int {
+	return xxx_messageInfo_ReqSignedTransaction.Size(m)
+}
+func (m *ReqSignedTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqSignedTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqSignedTransaction proto.InternalMessageInfo
+
+func (m *ReqSignedTransaction) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *ReqSignedTransaction) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *ReqSignedTransaction) GetAmount() uint64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReqSignedTransaction) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ReqSignedTransaction) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReqSignedTransaction) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ReqSignedTransaction) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *ReqSignedTransaction) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+func (m *ReqSignedTransaction) GetValidUntilHeight() uint64 {
+	if m != nil {
+		return m.ValidUntilHeight
+	}
+	return 0
+}
+
+func (m *ReqSignedTransaction) GetValidUntilTime() int64 {
+	if m != nil {
+		return m.ValidUntilTime
+	}
+	return 0
+}
+
+func (m *ReqSignedTransaction) GetChainId() uint64 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *ReqSignedTransaction) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type RespSignedTransaction struct {
+	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespSignedTransaction) Reset()         { *m = RespSignedTransaction{} }
+func (m *RespSignedTransaction) String() string { return proto.CompactTextString(m) }
+func (*RespSignedTransaction) ProtoMessage()    {}
+func (*RespSignedTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{36}
+}
+
+func (m *RespSignedTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespSignedTransaction.Unmarshal(m, b)
+}
+func (m *RespSignedTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespSignedTransaction.Marshal(b, m, deterministic)
+}
+func (m *RespSignedTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespSignedTransaction.Merge(m, src)
+}
+func (m *RespSignedTransaction) XXX_Size() int {
+	return xxx_messageInfo_RespSignedTransaction.Size(m)
+}
+func (m *RespSignedTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespSignedTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespSignedTransaction proto.InternalMessageInfo
+
+func (m *RespSignedTransaction) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type HashMsg struct {
+	Code                 int64    `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message              string   `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Hash                 string   `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HashMsg) Reset()         { *m = HashMsg{} }
+func (m *HashMsg) String() string { return proto.CompactTextString(m) }
+func (*HashMsg) ProtoMessage()    {}
+func (*HashMsg) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{37}
+}
+
+func (m *HashMsg) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HashMsg.Unmarshal(m, b)
+}
+func (m *HashMsg) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HashMsg.Marshal(b, m, deterministic)
+}
+func (m *HashMsg) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HashMsg.Merge(m, src)
+}
+func (m *HashMsg) XXX_Size() int {
+	return xxx_messageInfo_HashMsg.Size(m)
+}
+func (m *HashMsg) XXX_DiscardUnknown() {
+	xxx_messageInfo_HashMsg.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HashMsg proto.InternalMessageInfo
+
+func (m *HashMsg) GetCode() int64 {
+	if m != nil {
+		return m.Code
+	}
+	return 0
+}
+
+func (m *HashMsg) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *HashMsg) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type ReqSignedTransactions struct {
+	Txs                  []*ReqSignedTransaction `protobuf:"bytes,1,rep,name=txs,proto3" json:"txs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *ReqSignedTransactions) Reset()         { *m = ReqSignedTransactions{} }
+func (m *ReqSignedTransactions) String() string { return proto.CompactTextString(m) }
+func (*ReqSignedTransactions) ProtoMessage()    {}
+func (*ReqSignedTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{38}
+}
+
+func (m *ReqSignedTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqSignedTransactions.Unmarshal(m, b)
+}
+func (m *ReqSignedTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqSignedTransactions.Marshal(b, m, deterministic)
+}
+func (m *ReqSignedTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqSignedTransactions.Merge(m, src)
+}
+func (m *ReqSignedTransactions) XXX_Size() int {
+	return xxx_messageInfo_ReqSignedTransactions.Size(m)
+}
+func (m *ReqSignedTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqSignedTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqSignedTransactions proto.InternalMessageInfo
+
+func (m *ReqSignedTransactions) GetTxs() []*ReqSignedTransaction {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
+// 管理员签名的验证者交易，validator格式为"address@host:port"
+type ReqValidatorTransaction struct {
+	Validator            string   `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator,omitempty"`
+	Remove               bool     `protobuf:"varint,2,opt,name=remove,proto3" json:"remove,omitempty"`
+	Nonce                uint64   `protobuf:"varint,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Time                 int64    `protobuf:"varint,4,opt,name=time,proto3" json:"time,omitempty"`
+	Hash                 []byte   `protobuf:"bytes,5,opt,name=hash,proto3" json:"hash,omitempty"`
+	Signature            []byte   `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	ValidUntilHeight     uint64   `protobuf:"varint,7,opt,name=valid_until_height,json=validUntilHeight,proto3" json:"valid_until_height,omitempty"`
+	ValidUntilTime       int64    `protobuf:"varint,8,opt,name=valid_until_time,json=validUntilTime,proto3" json:"valid_until_time,omitempty"`
+	ChainId              uint64   `protobuf:"varint,9,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              uint32   `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqValidatorTransaction) Reset()         { *m = ReqValidatorTransaction{} }
+func (m *ReqValidatorTransaction) String() string { return proto.CompactTextString(m) }
+func (*ReqValidatorTransaction) ProtoMessage()    {}
+func (*ReqValidatorTransaction) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{39}
+}
+
+func (m *ReqValidatorTransaction) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqValidatorTransaction.Unmarshal(m, b)
+}
+func (m *ReqValidatorTransaction) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqValidatorTransaction.Marshal(b, m, deterministic)
+}
+func (m *ReqValidatorTransaction) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqValidatorTransaction.Merge(m, src)
+}
+func (m *ReqValidatorTransaction) XXX_Size() int {
+	return xxx_messageInfo_ReqValidatorTransaction.Size(m)
+}
+func (m *ReqValidatorTransaction) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqValidatorTransaction.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqValidatorTransaction proto.InternalMessageInfo
+
+func (m *ReqValidatorTransaction) GetValidator() string {
+	if m != nil {
+		return m.Validator
+	}
+	return ""
+}
+
+func (m *ReqValidatorTransaction) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
+}
+
+func (m *ReqValidatorTransaction) GetNonce() uint64 {
+	if m != nil {
+		return m.Nonce
+	}
+	return 0
+}
+
+func (m *ReqValidatorTransaction) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *ReqValidatorTransaction) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *ReqValidatorTransaction) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *ReqValidatorTransaction) GetValidUntilHeight() uint64 {
+	if m != nil {
+		return m.ValidUntilHeight
+	}
+	return 0
+}
+
+func (m *ReqValidatorTransaction) GetValidUntilTime() int64 {
+	if m != nil {
+		return m.ValidUntilTime
+	}
+	return 0
+}
+
+func (m *ReqValidatorTransaction) GetChainId() uint64 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *ReqValidatorTransaction) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
+type ReqValidators struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqValidators) Reset()         { *m = ReqValidators{} }
+func (m *ReqValidators) String() string { return proto.CompactTextString(m) }
+func (*ReqValidators) ProtoMessage()    {}
+func (*ReqValidators) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{40}
+}
+
+func (m *ReqValidators) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqValidators.Unmarshal(m, b)
+}
+func (m *ReqValidators) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqValidators.Marshal(b, m, deterministic)
+}
+func (m *ReqValidators) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqValidators.Merge(m, src)
+}
+func (m *ReqValidators) XXX_Size() int {
+	return xxx_messageInfo_ReqValidators.Size(m)
+}
+func (m *ReqValidators) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqValidators.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqValidators proto.InternalMessageInfo
+
+type RespValidators struct {
+	Validators           []string `protobuf:"bytes,1,rep,name=validators,proto3" json:"validators,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespValidators) Reset()         { *m = RespValidators{} }
+func (m *RespValidators) String() string { return proto.CompactTextString(m) }
+func (*RespValidators) ProtoMessage()    {}
+func (*RespValidators) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{41}
+}
+
+func (m *RespValidators) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespValidators.Unmarshal(m, b)
+}
+func (m *RespValidators) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespValidators.Marshal(b, m, deterministic)
+}
+func (m *RespValidators) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespValidators.Merge(m, src)
+}
+func (m *RespValidators) XXX_Size() int {
+	return xxx_messageInfo_RespValidators.Size(m)
+}
+func (m *RespValidators) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespValidators.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespValidators proto.InternalMessageInfo
+
+func (m *RespValidators) GetValidators() []string {
+	if m != nil {
+		return m.Validators
+	}
+	return nil
+}
+
+type ReqProposerSchedule struct {
+	Count                uint64   `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqProposerSchedule) Reset()         { *m = ReqProposerSchedule{} }
+func (m *ReqProposerSchedule) String() string { return proto.CompactTextString(m) }
+func (*ReqProposerSchedule) ProtoMessage()    {}
+func (*ReqProposerSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{42}
+}
+
+func (m *ReqProposerSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqProposerSchedule.Unmarshal(m, b)
+}
+func (m *ReqProposerSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqProposerSchedule.Marshal(b, m, deterministic)
+}
+func (m *ReqProposerSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqProposerSchedule.Merge(m, src)
+}
+func (m *ReqProposerSchedule) XXX_Size() int {
+	return xxx_messageInfo_ReqProposerSchedule.Size(m)
+}
+func (m *ReqProposerSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqProposerSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqProposerSchedule proto.InternalMessageInfo
+
+func (m *ReqProposerSchedule) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type ProposerSlot struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Validator            string   `protobuf:"bytes,2,opt,name=validator,proto3" json:"validator,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProposerSlot) Reset()         { *m = ProposerSlot{} }
+func (m *ProposerSlot) String() string { return proto.CompactTextString(m) }
+func (*ProposerSlot) ProtoMessage()    {}
+func (*ProposerSlot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{43}
+}
+
+func (m *ProposerSlot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProposerSlot.Unmarshal(m, b)
+}
+func (m *ProposerSlot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProposerSlot.Marshal(b, m, deterministic)
+}
+func (m *ProposerSlot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProposerSlot.Merge(m, src)
+}
+func (m *ProposerSlot) XXX_Size() int {
+	return xxx_messageInfo_ProposerSlot.Size(m)
+}
+func (m *ProposerSlot) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProposerSlot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProposerSlot proto.InternalMessageInfo
+
+func (m *ProposerSlot) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ProposerSlot) GetValidator() string {
+	if m != nil {
+		return m.Validator
+	}
+	return ""
+}
+
+type RespProposerSchedule struct {
+	Term                 uint64          `protobuf:"varint,1,opt,name=term,proto3" json:"term,omitempty"`
+	Slots                []*ProposerSlot `protobuf:"bytes,2,rep,name=slots,proto3" json:"slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *RespProposerSchedule) Reset()         { *m = RespProposerSchedule{} }
+func (m *RespProposerSchedule) String() string { return proto.CompactTextString(m) }
+func (*RespProposerSchedule) ProtoMessage()    {}
+func (*RespProposerSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{44}
+}
+
+func (m *RespProposerSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespProposerSchedule.Unmarshal(m, b)
+}
+func (m *RespProposerSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespProposerSchedule.Marshal(b, m, deterministic)
+}
+func (m *RespProposerSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespProposerSchedule.Merge(m, src)
+}
+func (m *RespProposerSchedule) XXX_Size() int {
+	return xxx_messageInfo_RespProposerSchedule.Size(m)
+}
+func (m *RespProposerSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespProposerSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespProposerSchedule proto.InternalMessageInfo
+
+func (m *RespProposerSchedule) GetTerm() uint64 {
+	if m != nil {
+		return m.Term
+	}
+	return 0
+}
+
+func (m *RespProposerSchedule) GetSlots() []*ProposerSlot {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+type RespSignedTransactions struct {
+	HashList             []*HashMsg `protobuf:"bytes,1,rep,name=hashList,proto3" json:"hashList,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *RespSignedTransactions) Reset()         { *m = RespSignedTransactions{} }
+func (m *RespSignedTransactions) String() string { return proto.CompactTextString(m) }
+func (*RespSignedTransactions) ProtoMessage()    {}
+func (*RespSignedTransactions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{45}
+}
+
+func (m *RespSignedTransactions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespSignedTransactions.Unmarshal(m, b)
+}
+func (m *RespSignedTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespSignedTransactions.Marshal(b, m, deterministic)
+}
+func (m *RespSignedTransactions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespSignedTransactions.Merge(m, src)
+}
+func (m *RespSignedTransactions) XXX_Size() int {
+	return xxx_messageInfo_RespSignedTransactions.Size(m)
+}
+func (m *RespSignedTransactions) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespSignedTransactions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespSignedTransactions proto.InternalMessageInfo
+
+func (m *RespSignedTransactions) GetHashList() []*HashMsg {
+	if m != nil {
+		return m.HashList
+	}
+	return nil
+}
+
+type ReqCreateAddr struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqCreateAddr) Reset()         { *m = ReqCreateAddr{} }
+func (m *ReqCreateAddr) String() string { return proto.CompactTextString(m) }
+func (*ReqCreateAddr) ProtoMessage()    {}
+func (*ReqCreateAddr) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{46}
+}
+
+func (m *ReqCreateAddr) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqCreateAddr.Unmarshal(m, b)
+}
+func (m *ReqCreateAddr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqCreateAddr.Marshal(b, m, deterministic)
+}
+func (m *ReqCreateAddr) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqCreateAddr.Merge(m, src)
+}
+func (m *ReqCreateAddr) XXX_Size() int {
+	return xxx_messageInfo_ReqCreateAddr.Size(m)
+}
+func (m *ReqCreateAddr) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqCreateAddr.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqCreateAddr proto.InternalMessageInfo
+
+type RespCreateAddr struct {
+	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Privkey              string   `protobuf:"bytes,2,opt,name=privkey,proto3" json:"privkey,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespCreateAddr) Reset()         { *m = RespCreateAddr{} }
+func (m *RespCreateAddr) String() string { return proto.CompactTextString(m) }
+func (*RespCreateAddr) ProtoMessage()    {}
+func (*RespCreateAddr) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{47}
+}
+
+func (m *RespCreateAddr) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespCreateAddr.Unmarshal(m, b)
+}
+func (m *RespCreateAddr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespCreateAddr.Marshal(b, m, deterministic)
+}
+func (m *RespCreateAddr) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespCreateAddr.Merge(m, src)
+}
+func (m *RespCreateAddr) XXX_Size() int {
+	return xxx_messageInfo_RespCreateAddr.Size(m)
+}
+func (m *RespCreateAddr) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespCreateAddr.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespCreateAddr proto.InternalMessageInfo
+
+func (m *RespCreateAddr) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
+func (m *RespCreateAddr) GetPrivkey() string {
+	if m != nil {
+		return m.Privkey
+	}
+	return ""
+}
+
+type ReqChainId struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqChainId) Reset()         { *m = ReqChainId{} }
+func (m *ReqChainId) String() string { return proto.CompactTextString(m) }
+func (*ReqChainId) ProtoMessage()    {}
+func (*ReqChainId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{48}
+}
+
+func (m *ReqChainId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqChainId.Unmarshal(m, b)
+}
+func (m *ReqChainId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqChainId.Marshal(b, m, deterministic)
+}
+func (m *ReqChainId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqChainId.Merge(m, src)
+}
+func (m *ReqChainId) XXX_Size() int {
+	return xxx_messageInfo_ReqChainId.Size(m)
+}
+func (m *ReqChainId) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqChainId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqChainId proto.InternalMessageInfo
+
+type RespChainId struct {
+	ChainId              uint64   `protobuf:"varint,1,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RespChainId) Reset()         { *m = RespChainId{} }
+func (m *RespChainId) String() string { return proto.CompactTextString(m) }
+func (*RespChainId) ProtoMessage()    {}
+func (*RespChainId) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{49}
+}
+
+func (m *RespChainId) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespChainId.Unmarshal(m, b)
+}
+func (m *RespChainId) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespChainId.Marshal(b, m, deterministic)
+}
+func (m *RespChainId) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespChainId.Merge(m, src)
+}
+func (m *RespChainId) XXX_Size() int {
+	return xxx_messageInfo_RespChainId.Size(m)
+}
+func (m *RespChainId) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespChainId.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RespChainId proto.InternalMessageInfo
+
+func (m *RespChainId) GetChainId() uint64 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+type EmissionShare struct {
+	Recipient            string   `protobuf:"bytes,1,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Weight               uint64   `protobuf:"varint,2,opt,name=weight,proto3" json:"weight,omitempty"`
+	Fallback             string   `protobuf:"bytes,3,opt,name=fallback,proto3" json:"fallback,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EmissionShare) Reset()         { *m = EmissionShare{} }
+func (m *EmissionShare) String() string { return proto.CompactTextString(m) }
+func (*EmissionShare) ProtoMessage()    {}
+func (*EmissionShare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{50}
+}
+
+func (m *EmissionShare) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionShare.Unmarshal(m, b)
+}
+func (m *EmissionShare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionShare.Marshal(b, m, deterministic)
+}
+func (m *EmissionShare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionShare.Merge(m, src)
+}
+func (m *EmissionShare) XXX_Size() int {
+	return xxx_messageInfo_EmissionShare.Size(m)
+}
+func (m *EmissionShare) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionShare.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmissionShare proto.InternalMessageInfo
+
+func (m *EmissionShare) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
+	}
+	return ""
+}
+
+func (m *EmissionShare) GetWeight() uint64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *EmissionShare) GetFallback() string {
+	if m != nil {
+		return m.Fallback
+	}
+	return ""
+}
+
+type EmissionPeriod struct {
+	StartHeight          uint64           `protobuf:"varint,1,opt,name=start_height,json=startHeight,proto3" json:"start_height,omitempty"`
+	Reward               uint64           `protobuf:"varint,2,opt,name=reward,proto3" json:"reward,omitempty"`
+	DecayPeriod          uint64           `protobuf:"varint,3,opt,name=decay_period,json=decayPeriod,proto3" json:"decay_period,omitempty"`
+	DecayRate            uint64           `protobuf:"varint,4,opt,name=decay_rate,json=decayRate,proto3" json:"decay_rate,omitempty"`
+	Shares               []*EmissionShare `protobuf:"bytes,5,rep,name=shares,proto3" json:"shares,omitempty"`
+	Remainder            string           `protobuf:"bytes,6,opt,name=remainder,proto3" json:"remainder,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *EmissionPeriod) Reset()         { *m = EmissionPeriod{} }
+func (m *EmissionPeriod) String() string { return proto.CompactTextString(m) }
+func (*EmissionPeriod) ProtoMessage()    {}
+func (*EmissionPeriod) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{51}
+}
+
+func (m *EmissionPeriod) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EmissionPeriod.Unmarshal(m, b)
+}
+func (m *EmissionPeriod) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EmissionPeriod.Marshal(b, m, deterministic)
+}
+func (m *EmissionPeriod) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EmissionPeriod.Merge(m, src)
+}
+func (m *EmissionPeriod) XXX_Size() int {
+	return xxx_messageInfo_EmissionPeriod.Size(m)
+}
+func (m *EmissionPeriod) XXX_DiscardUnknown() {
+	xxx_messageInfo_EmissionPeriod.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EmissionPeriod proto.InternalMessageInfo
+
+func (m *EmissionPeriod) GetStartHeight() uint64 {
+	if m != nil {
+		return m.StartHeight
+	}
+	return 0
+}
+
+func (m *EmissionPeriod) GetReward() uint64 {
+	if m != nil {
+		return m.Reward
+	}
+	return 0
+}
+
+func (m *EmissionPeriod) GetDecayPeriod() uint64 {
+	if m != nil {
+		return m.DecayPeriod
+	}
+	return 0
+}
+
+func (m *EmissionPeriod) GetDecayRate() uint64 {
+	if m != nil {
+		return m.DecayRate
+	}
+	return 0
+}
+
+func (m *EmissionPeriod) GetShares() []*EmissionShare {
+	if m != nil {
+		return m.Shares
+	}
+	return nil
+}
+
+func (m *EmissionPeriod) GetRemainder() string {
+	if m != nil {
+		return m.Remainder
+	}
+	return ""
+}
+
+type ReqEmissionSchedule struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReqEmissionSchedule) Reset()         { *m = ReqEmissionSchedule{} }
+func (m *ReqEmissionSchedule) String() string { return proto.CompactTextString(m) }
+func (*ReqEmissionSchedule) ProtoMessage()    {}
+func (*ReqEmissionSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{52}
+}
+
+func (m *ReqEmissionSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqEmissionSchedule.Unmarshal(m, b)
+}
+func (m *ReqEmissionSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqEmissionSchedule.Marshal(b, m, deterministic)
+}
+func (m *ReqEmissionSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqEmissionSchedule.Merge(m, src)
+}
+func (m *ReqEmissionSchedule) XXX_Size() int {
+	return xxx_messageInfo_ReqEmissionSchedule.Size(m)
+}
+func (m *ReqEmissionSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqEmissionSchedule.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReqEmissionSchedule proto.InternalMessageInfo
+
+func (m *ReqEmissionSchedule) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+type RespEmissionSchedule struct {
+	Periods              []*EmissionPeriod `protobuf:"bytes,1,rep,name=periods,proto3" json:"periods,omitempty"`
+	Reward               uint64            `protobuf:"varint,2,opt,name=reward,proto3" json:"reward,omitempty"`
+	Emitted              uint64            `protobuf:"varint,3,opt,name=emitted,proto3" json:"emitted,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *RespSignedTransactions) Reset()         { *m = RespSignedTransactions{} }
-func (m *RespSignedTransactions) String() string { return proto.CompactTextString(m) }
-func (*RespSignedTransactions) ProtoMessage()    {}
-func (*RespSignedTransactions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{22}
+func (m *RespEmissionSchedule) Reset()         { *m = RespEmissionSchedule{} }
+func (m *RespEmissionSchedule) String() string { return proto.CompactTextString(m) }
+func (*RespEmissionSchedule) ProtoMessage()    {}
+func (*RespEmissionSchedule) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{53}
 }
 
-func (m *RespSignedTransactions) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RespSignedTransactions.Unmarshal(m, b)
+func (m *RespEmissionSchedule) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespEmissionSchedule.Unmarshal(m, b)
 }
-func (m *RespSignedTransactions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RespSignedTransactions.Marshal(b, m, deterministic)
+func (m *RespEmissionSchedule) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespEmissionSchedule.Marshal(b, m, deterministic)
 }
-func (m *RespSignedTransactions) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RespSignedTransactions.Merge(m, src)
+func (m *RespEmissionSchedule) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespEmissionSchedule.Merge(m, src)
 }
-func (m *RespSignedTransactions) XXX_Size() int {
-	return xxx_messageInfo_RespSignedTransactions.Size(m)
+func (m *RespEmissionSchedule) XXX_Size() int {
+	return xxx_messageInfo_RespEmissionSchedule.Size(m)
 }
-func (m *RespSignedTransactions) XXX_DiscardUnknown() {
-	xxx_messageInfo_RespSignedTransactions.DiscardUnknown(m)
+func (m *RespEmissionSchedule) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespEmissionSchedule.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RespSignedTransactions proto.InternalMessageInfo
+var xxx_messageInfo_RespEmissionSchedule proto.InternalMessageInfo
 
-func (m *RespSignedTransactions) GetHashList() []*HashMsg {
+func (m *RespEmissionSchedule) GetPeriods() []*EmissionPeriod {
 	if m != nil {
-		return m.HashList
+		return m.Periods
 	}
 	return nil
 }
 
-type ReqCreateAddr struct {
+func (m *RespEmissionSchedule) GetReward() uint64 {
+	if m != nil {
+		return m.Reward
+	}
+	return 0
+}
+
+func (m *RespEmissionSchedule) GetEmitted() uint64 {
+	if m != nil {
+		return m.Emitted
+	}
+	return 0
+}
+
+type ReqSupply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ReqCreateAddr) Reset()         { *m = ReqCreateAddr{} }
-func (m *ReqCreateAddr) String() string { return proto.CompactTextString(m) }
-func (*ReqCreateAddr) ProtoMessage()    {}
-func (*ReqCreateAddr) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{23}
+func (m *ReqSupply) Reset()         { *m = ReqSupply{} }
+func (m *ReqSupply) String() string { return proto.CompactTextString(m) }
+func (*ReqSupply) ProtoMessage()    {}
+func (*ReqSupply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{54}
 }
 
-func (m *ReqCreateAddr) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReqCreateAddr.Unmarshal(m, b)
+func (m *ReqSupply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReqSupply.Unmarshal(m, b)
 }
-func (m *ReqCreateAddr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReqCreateAddr.Marshal(b, m, deterministic)
+func (m *ReqSupply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReqSupply.Marshal(b, m, deterministic)
 }
-func (m *ReqCreateAddr) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReqCreateAddr.Merge(m, src)
+func (m *ReqSupply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReqSupply.Merge(m, src)
 }
-func (m *ReqCreateAddr) XXX_Size() int {
-	return xxx_messageInfo_ReqCreateAddr.Size(m)
+func (m *ReqSupply) XXX_Size() int {
+	return xxx_messageInfo_ReqSupply.Size(m)
 }
-func (m *ReqCreateAddr) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReqCreateAddr.DiscardUnknown(m)
+func (m *ReqSupply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReqSupply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReqCreateAddr proto.InternalMessageInfo
+var xxx_messageInfo_ReqSupply proto.InternalMessageInfo
 
-type RespCreateAddr struct {
-	Address              string   `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Privkey              string   `protobuf:"bytes,2,opt,name=privkey,proto3" json:"privkey,omitempty"`
+type RespSupply struct {
+	Height               uint64   `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Minted               uint64   `protobuf:"varint,2,opt,name=minted,proto3" json:"minted,omitempty"`
+	Frozen               uint64   `protobuf:"varint,3,opt,name=frozen,proto3" json:"frozen,omitempty"`
+	PckPool              uint64   `protobuf:"varint,4,opt,name=pck_pool,json=pckPool,proto3" json:"pck_pool,omitempty"`
+	Circulating          uint64   `protobuf:"varint,5,opt,name=circulating,proto3" json:"circulating,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RespCreateAddr) Reset()         { *m = RespCreateAddr{} }
-func (m *RespCreateAddr) String() string { return proto.CompactTextString(m) }
-func (*RespCreateAddr) ProtoMessage()    {}
-func (*RespCreateAddr) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{24}
+func (m *RespSupply) Reset()         { *m = RespSupply{} }
+func (m *RespSupply) String() string { return proto.CompactTextString(m) }
+func (*RespSupply) ProtoMessage()    {}
+func (*RespSupply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_33c57e4bae7b9afd, []int{55}
 }
 
-func (m *RespCreateAddr) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RespCreateAddr.Unmarshal(m, b)
+func (m *RespSupply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RespSupply.Unmarshal(m, b)
 }
-func (m *RespCreateAddr) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RespCreateAddr.Marshal(b, m, deterministic)
+func (m *RespSupply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RespSupply.Marshal(b, m, deterministic)
 }
-func (m *RespCreateAddr) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RespCreateAddr.Merge(m, src)
+func (m *RespSupply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RespSupply.Merge(m, src)
 }
-func (m *RespCreateAddr) XXX_Size() int {
-	return xxx_messageInfo_RespCreateAddr.Size(m)
+func (m *RespSupply) XXX_Size() int {
+	return xxx_messageInfo_RespSupply.Size(m)
 }
-func (m *RespCreateAddr) XXX_DiscardUnknown() {
-	xxx_messageInfo_RespCreateAddr.DiscardUnknown(m)
+func (m *RespSupply) XXX_DiscardUnknown() {
+	xxx_messageInfo_RespSupply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RespCreateAddr proto.InternalMessageInfo
+var xxx_messageInfo_RespSupply proto.InternalMessageInfo
 
-func (m *RespCreateAddr) GetAddress() string {
+func (m *RespSupply) GetHeight() uint64 {
 	if m != nil {
-		return m.Address
+		return m.Height
 	}
-	return ""
+	return 0
 }
 
-func (m *RespCreateAddr) GetPrivkey() string {
+func (m *RespSupply) GetMinted() uint64 {
 	if m != nil {
-		return m.Privkey
+		return m.Minted
 	}
-	return ""
+	return 0
+}
+
+func (m *RespSupply) GetFrozen() uint64 {
+	if m != nil {
+		return m.Frozen
+	}
+	return 0
+}
+
+func (m *RespSupply) GetPckPool() uint64 {
+	if m != nil {
+		return m.PckPool
+	}
+	return 0
+}
+
+func (m *RespSupply) GetCirculating() uint64 {
+	if m != nil {
+		return m.Circulating
+	}
+	return 0
 }
 
 type ReqMaxBlockNumber struct {
@@ -1361,7 +3110,7 @@ func (m *ReqMaxBlockNumber) Reset()         { *m = ReqMaxBlockNumber{} }
 func (m *ReqMaxBlockNumber) String() string { return proto.CompactTextString(m) }
 func (*ReqMaxBlockNumber) ProtoMessage()    {}
 func (*ReqMaxBlockNumber) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{25}
+	return fileDescriptor_33c57e4bae7b9afd, []int{56}
 }
 
 func (m *ReqMaxBlockNumber) XXX_Unmarshal(b []byte) error {
@@ -1393,7 +3142,7 @@ func (m *RespMaxBlockNumber) Reset()         { *m = RespMaxBlockNumber{} }
 func (m *RespMaxBlockNumber) String() string { return proto.CompactTextString(m) }
 func (*RespMaxBlockNumber) ProtoMessage()    {}
 func (*RespMaxBlockNumber) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{26}
+	return fileDescriptor_33c57e4bae7b9afd, []int{57}
 }
 
 func (m *RespMaxBlockNumber) XXX_Unmarshal(b []byte) error {
@@ -1432,7 +3181,7 @@ func (m *ReqAddrByPriv) Reset()         { *m = ReqAddrByPriv{} }
 func (m *ReqAddrByPriv) String() string { return proto.CompactTextString(m) }
 func (*ReqAddrByPriv) ProtoMessage()    {}
 func (*ReqAddrByPriv) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{27}
+	return fileDescriptor_33c57e4bae7b9afd, []int{58}
 }
 
 func (m *ReqAddrByPriv) XXX_Unmarshal(b []byte) error {
@@ -1471,7 +3220,7 @@ func (m *RespAddrByPriv) Reset()         { *m = RespAddrByPriv{} }
 func (m *RespAddrByPriv) String() string { return proto.CompactTextString(m) }
 func (*RespAddrByPriv) ProtoMessage()    {}
 func (*RespAddrByPriv) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{28}
+	return fileDescriptor_33c57e4bae7b9afd, []int{59}
 }
 
 func (m *RespAddrByPriv) XXX_Unmarshal(b []byte) error {
@@ -1511,7 +3260,7 @@ func (m *ReqSignOrd) Reset()         { *m = ReqSignOrd{} }
 func (m *ReqSignOrd) String() string { return proto.CompactTextString(m) }
 func (*ReqSignOrd) ProtoMessage()    {}
 func (*ReqSignOrd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{29}
+	return fileDescriptor_33c57e4bae7b9afd, []int{60}
 }
 
 func (m *ReqSignOrd) XXX_Unmarshal(b []byte) error {
@@ -1558,7 +3307,7 @@ func (m *RespSignOrd) Reset()         { *m = RespSignOrd{} }
 func (m *RespSignOrd) String() string { return proto.CompactTextString(m) }
 func (*RespSignOrd) ProtoMessage()    {}
 func (*RespSignOrd) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{30}
+	return fileDescriptor_33c57e4bae7b9afd, []int{61}
 }
 
 func (m *RespSignOrd) XXX_Unmarshal(b []byte) error {
@@ -1612,7 +3361,7 @@ func (m *ReqTokenCreate) Reset()         { *m = ReqTokenCreate{} }
 func (m *ReqTokenCreate) String() string { return proto.CompactTextString(m) }
 func (*ReqTokenCreate) ProtoMessage()    {}
 func (*ReqTokenCreate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{31}
+	return fileDescriptor_33c57e4bae7b9afd, []int{62}
 }
 
 func (m *ReqTokenCreate) XXX_Unmarshal(b []byte) error {
@@ -1707,7 +3456,7 @@ func (m *RespTokenCreate) Reset()         { *m = RespTokenCreate{} }
 func (m *RespTokenCreate) String() string { return proto.CompactTextString(m) }
 func (*RespTokenCreate) ProtoMessage()    {}
 func (*RespTokenCreate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{32}
+	return fileDescriptor_33c57e4bae7b9afd, []int{63}
 }
 
 func (m *RespTokenCreate) XXX_Unmarshal(b []byte) error {
@@ -1747,7 +3496,7 @@ func (m *ReqTokenBalance) Reset()         { *m = ReqTokenBalance{} }
 func (m *ReqTokenBalance) String() string { return proto.CompactTextString(m) }
 func (*ReqTokenBalance) ProtoMessage()    {}
 func (*ReqTokenBalance) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{33}
+	return fileDescriptor_33c57e4bae7b9afd, []int{64}
 }
 
 func (m *ReqTokenBalance) XXX_Unmarshal(b []byte) error {
@@ -1794,7 +3543,7 @@ func (m *RespTokenBalance) Reset()         { *m = RespTokenBalance{} }
 func (m *RespTokenBalance) String() string { return proto.CompactTextString(m) }
 func (*RespTokenBalance) ProtoMessage()    {}
 func (*RespTokenBalance) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{34}
+	return fileDescriptor_33c57e4bae7b9afd, []int{65}
 }
 
 func (m *RespTokenBalance) XXX_Unmarshal(b []byte) error {
@@ -1841,6 +3590,8 @@ type ReqTokenTransaction struct {
 	Time                 int64    `protobuf:"varint,9,opt,name=time,proto3" json:"time,omitempty"`
 	Hash                 []byte   `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
 	Signature            []byte   `protobuf:"bytes,11,opt,name=signature,proto3" json:"signature,omitempty"`
+	ChainId              uint64   `protobuf:"varint,12,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Version              uint32   `protobuf:"varint,13,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1850,7 +3601,7 @@ func (m *ReqTokenTransaction) Reset()         { *m = ReqTokenTransaction{} }
 func (m *ReqTokenTransaction) String() string { return proto.CompactTextString(m) }
 func (*ReqTokenTransaction) ProtoMessage()    {}
 func (*ReqTokenTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{35}
+	return fileDescriptor_33c57e4bae7b9afd, []int{66}
 }
 
 func (m *ReqTokenTransaction) XXX_Unmarshal(b []byte) error {
@@ -1948,6 +3699,20 @@ func (m *ReqTokenTransaction) GetSignature() []byte {
 	return nil
 }
 
+func (m *ReqTokenTransaction) GetChainId() uint64 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *ReqTokenTransaction) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type RespTokenTransaction struct {
 	Hash                 string   `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1959,7 +3724,7 @@ func (m *RespTokenTransaction) Reset()         { *m = RespTokenTransaction{} }
 func (m *RespTokenTransaction) String() string { return proto.CompactTextString(m) }
 func (*RespTokenTransaction) ProtoMessage()    {}
 func (*RespTokenTransaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{36}
+	return fileDescriptor_33c57e4bae7b9afd, []int{67}
 }
 
 func (m *RespTokenTransaction) XXX_Unmarshal(b []byte) error {
@@ -1998,7 +3763,7 @@ func (m *ReqTokenTransactions) Reset()         { *m = ReqTokenTransactions{} }
 func (m *ReqTokenTransactions) String() string { return proto.CompactTextString(m) }
 func (*ReqTokenTransactions) ProtoMessage()    {}
 func (*ReqTokenTransactions) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{37}
+	return fileDescriptor_33c57e4bae7b9afd, []int{68}
 }
 
 func (m *ReqTokenTransactions) XXX_Unmarshal(b []byte) error {
@@ -2039,7 +3804,7 @@ func (m *FreezeBalance) Reset()         { *m = FreezeBalance{} }
 func (m *FreezeBalance) String() string { return proto.CompactTextString(m) }
 func (*FreezeBalance) ProtoMessage()    {}
 func (*FreezeBalance) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{38}
+	return fileDescriptor_33c57e4bae7b9afd, []int{69}
 }
 
 func (m *FreezeBalance) XXX_Unmarshal(b []byte) error {
@@ -2092,7 +3857,7 @@ func (m *ReqGetFreezeBal) Reset()         { *m = ReqGetFreezeBal{} }
 func (m *ReqGetFreezeBal) String() string { return proto.CompactTextString(m) }
 func (*ReqGetFreezeBal) ProtoMessage()    {}
 func (*ReqGetFreezeBal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{39}
+	return fileDescriptor_33c57e4bae7b9afd, []int{70}
 }
 
 func (m *ReqGetFreezeBal) XXX_Unmarshal(b []byte) error {
@@ -2131,7 +3896,7 @@ func (m *RespGetFreezeBal) Reset()         { *m = RespGetFreezeBal{} }
 func (m *RespGetFreezeBal) String() string { return proto.CompactTextString(m) }
 func (*RespGetFreezeBal) ProtoMessage()    {}
 func (*RespGetFreezeBal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{40}
+	return fileDescriptor_33c57e4bae7b9afd, []int{71}
 }
 
 func (m *RespGetFreezeBal) XXX_Unmarshal(b []byte) error {
@@ -2167,6 +3932,8 @@ type ReqConvertPck struct {
 	Nonce                uint64   `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	KtoNum               uint64   `protobuf:"varint,6,opt,name=ktoNum,proto3" json:"ktoNum,omitempty"`
 	PckNum               uint64   `protobuf:"varint,7,opt,name=pckNum,proto3" json:"pckNum,omitempty"`
+	ChainId              uint64   `protobuf:"varint,8,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	Version              uint32   `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2176,7 +3943,7 @@ func (m *ReqConvertPck) Reset()         { *m = ReqConvertPck{} }
 func (m *ReqConvertPck) String() string { return proto.CompactTextString(m) }
 func (*ReqConvertPck) ProtoMessage()    {}
 func (*ReqConvertPck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{41}
+	return fileDescriptor_33c57e4bae7b9afd, []int{72}
 }
 
 func (m *ReqConvertPck) XXX_Unmarshal(b []byte) error {
@@ -2246,6 +4013,20 @@ func (m *ReqConvertPck) GetPckNum() uint64 {
 	return 0
 }
 
+func (m *ReqConvertPck) GetChainId() uint64 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *ReqConvertPck) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type ReqPckBal struct {
 	Addr                 string   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -2257,7 +4038,7 @@ func (m *ReqPckBal) Reset()         { *m = ReqPckBal{} }
 func (m *ReqPckBal) String() string { return proto.CompactTextString(m) }
 func (*ReqPckBal) ProtoMessage()    {}
 func (*ReqPckBal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{42}
+	return fileDescriptor_33c57e4bae7b9afd, []int{73}
 }
 
 func (m *ReqPckBal) XXX_Unmarshal(b []byte) error {
@@ -2296,7 +4077,7 @@ func (m *RespPckBal) Reset()         { *m = RespPckBal{} }
 func (m *RespPckBal) String() string { return proto.CompactTextString(m) }
 func (*RespPckBal) ProtoMessage()    {}
 func (*RespPckBal) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{43}
+	return fileDescriptor_33c57e4bae7b9afd, []int{74}
 }
 
 func (m *RespPckBal) XXX_Unmarshal(b []byte) error {
@@ -2335,7 +4116,7 @@ func (m *ReqKtoNum) Reset()         { *m = ReqKtoNum{} }
 func (m *ReqKtoNum) String() string { return proto.CompactTextString(m) }
 func (*ReqKtoNum) ProtoMessage()    {}
 func (*ReqKtoNum) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{44}
+	return fileDescriptor_33c57e4bae7b9afd, []int{75}
 }
 
 func (m *ReqKtoNum) XXX_Unmarshal(b []byte) error {
@@ -2374,7 +4155,7 @@ func (m *RespKtoNum) Reset()         { *m = RespKtoNum{} }
 func (m *RespKtoNum) String() string { return proto.CompactTextString(m) }
 func (*RespKtoNum) ProtoMessage()    {}
 func (*RespKtoNum) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{45}
+	return fileDescriptor_33c57e4bae7b9afd, []int{76}
 }
 
 func (m *RespKtoNum) XXX_Unmarshal(b []byte) error {
@@ -2410,6 +4191,8 @@ type ReqConvertKto struct {
 	Nonce                uint64   `protobuf:"varint,5,opt,name=nonce,proto3" json:"nonce,omitempty"`
 	KtoNum               uint64   `protobuf:"varint,6,opt,name=ktoNum,proto3" json:"ktoNum,omitempty"`
 	PckNum               uint64   `protobuf:"varint,7,opt,name=pckNum,proto3" json:"pckNum,omitempty"`
+	ChainId              uint64   `protobuf:"varint,8,opt,name=chainId,proto3" json:"chainId,omitempty"`
+	Version              uint32   `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -2419,7 +4202,7 @@ func (m *ReqConvertKto) Reset()         { *m = ReqConvertKto{} }
 func (m *ReqConvertKto) String() string { return proto.CompactTextString(m) }
 func (*ReqConvertKto) ProtoMessage()    {}
 func (*ReqConvertKto) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{46}
+	return fileDescriptor_33c57e4bae7b9afd, []int{77}
 }
 
 func (m *ReqConvertKto) XXX_Unmarshal(b []byte) error {
@@ -2489,6 +4272,20 @@ func (m *ReqConvertKto) GetPckNum() uint64 {
 	return 0
 }
 
+func (m *ReqConvertKto) GetChainId() uint64 {
+	if m != nil {
+		return m.ChainId
+	}
+	return 0
+}
+
+func (m *ReqConvertKto) GetVersion() uint32 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 type ReqTotalPck struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -2499,7 +4296,7 @@ func (m *ReqTotalPck) Reset()         { *m = ReqTotalPck{} }
 func (m *ReqTotalPck) String() string { return proto.CompactTextString(m) }
 func (*ReqTotalPck) ProtoMessage()    {}
 func (*ReqTotalPck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{47}
+	return fileDescriptor_33c57e4bae7b9afd, []int{78}
 }
 
 func (m *ReqTotalPck) XXX_Unmarshal(b []byte) error {
@@ -2531,7 +4328,7 @@ func (m *RespTotalPck) Reset()         { *m = RespTotalPck{} }
 func (m *RespTotalPck) String() string { return proto.CompactTextString(m) }
 func (*RespTotalPck) ProtoMessage()    {}
 func (*RespTotalPck) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{48}
+	return fileDescriptor_33c57e4bae7b9afd, []int{79}
 }
 
 func (m *RespTotalPck) XXX_Unmarshal(b []byte) error {
@@ -2569,7 +4366,7 @@ func (m *ReqTotalKto) Reset()         { *m = ReqTotalKto{} }
 func (m *ReqTotalKto) String() string { return proto.CompactTextString(m) }
 func (*ReqTotalKto) ProtoMessage()    {}
 func (*ReqTotalKto) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{49}
+	return fileDescriptor_33c57e4bae7b9afd, []int{80}
 }
 
 func (m *ReqTotalKto) XXX_Unmarshal(b []byte) error {
@@ -2601,7 +4398,7 @@ func (m *RespTotalKto) Reset()         { *m = RespTotalKto{} }
 func (m *RespTotalKto) String() string { return proto.CompactTextString(m) }
 func (*RespTotalKto) ProtoMessage()    {}
 func (*RespTotalKto) Descriptor() ([]byte, []int) {
-	return fileDescriptor_33c57e4bae7b9afd, []int{50}
+	return fileDescriptor_33c57e4bae7b9afd, []int{81}
 }
 
 func (m *RespTotalKto) XXX_Unmarshal(b []byte) error {
@@ -2641,20 +4438,51 @@ func init() {
 	proto.RegisterType((*ReqBlockByNumber)(nil), "message.req_block_by_number")
 	proto.RegisterType((*ReqBlockByHash)(nil), "message.req_block_by_hash")
 	proto.RegisterType((*RespBlock)(nil), "message.resp_block")
+	proto.RegisterType((*ReqBlockCommit)(nil), "message.req_block_commit")
+	proto.RegisterType((*CommitSig)(nil), "message.commit_sig")
+	proto.RegisterType((*RespBlockCommit)(nil), "message.resp_block_commit")
+	proto.RegisterType((*ReqTxProof)(nil), "message.req_tx_proof")
+	proto.RegisterType((*BlockHeader)(nil), "message.block_header")
+	proto.RegisterType((*ReqHeaders)(nil), "message.req_headers")
+	proto.RegisterType((*RespHeaders)(nil), "message.resp_headers")
+	proto.RegisterType((*ReqBlocks)(nil), "message.req_blocks")
+	proto.RegisterType((*RespBlocks)(nil), "message.resp_blocks")
+	proto.RegisterType((*ProofStep)(nil), "message.proof_step")
+	proto.RegisterType((*RespTxProof)(nil), "message.resp_tx_proof")
+	proto.RegisterType((*ReqAccountProof)(nil), "message.req_account_proof")
+	proto.RegisterType((*RespAccountProof)(nil), "message.resp_account_proof")
 	proto.RegisterType((*ResposeTxs)(nil), "message.respose_txs")
 	proto.RegisterType((*ResposeNonce)(nil), "message.respose_nonce")
 	proto.RegisterType((*ReqNonce)(nil), "message.req_nonce")
 	proto.RegisterType((*ReqTransaction)(nil), "message.req_transaction")
 	proto.RegisterType((*ResTransaction)(nil), "message.res_transaction")
+	proto.RegisterType((*ReqCancelTransaction)(nil), "message.req_cancel_transaction")
+	proto.RegisterType((*RespCancelTransaction)(nil), "message.resp_cancel_transaction")
+	proto.RegisterType((*ReqPoolEvents)(nil), "message.req_pool_events")
+	proto.RegisterType((*PoolEvent)(nil), "message.pool_event")
 	proto.RegisterType((*ReqTransactions)(nil), "message.req_transactions")
 	proto.RegisterType((*RespTransactions)(nil), "message.resp_transactions")
 	proto.RegisterType((*ReqSignedTransaction)(nil), "message.req_signed_transaction")
 	proto.RegisterType((*RespSignedTransaction)(nil), "message.resp_signed_transaction")
 	proto.RegisterType((*HashMsg)(nil), "message.hashMsg")
 	proto.RegisterType((*ReqSignedTransactions)(nil), "message.req_signed_transactions")
+	proto.RegisterType((*ReqValidatorTransaction)(nil), "message.req_validator_transaction")
+	proto.RegisterType((*ReqValidators)(nil), "message.req_validators")
+	proto.RegisterType((*RespValidators)(nil), "message.resp_validators")
+	proto.RegisterType((*ReqProposerSchedule)(nil), "message.req_proposer_schedule")
+	proto.RegisterType((*ProposerSlot)(nil), "message.proposer_slot")
+	proto.RegisterType((*RespProposerSchedule)(nil), "message.resp_proposer_schedule")
 	proto.RegisterType((*RespSignedTransactions)(nil), "message.resp_signed_transactions")
 	proto.RegisterType((*ReqCreateAddr)(nil), "message.req_create_addr")
 	proto.RegisterType((*RespCreateAddr)(nil), "message.resp_create_addr")
+	proto.RegisterType((*ReqChainId)(nil), "message.req_chain_id")
+	proto.RegisterType((*RespChainId)(nil), "message.resp_chain_id")
+	proto.RegisterType((*EmissionShare)(nil), "message.emission_share")
+	proto.RegisterType((*EmissionPeriod)(nil), "message.emission_period")
+	proto.RegisterType((*ReqEmissionSchedule)(nil), "message.req_emission_schedule")
+	proto.RegisterType((*RespEmissionSchedule)(nil), "message.resp_emission_schedule")
+	proto.RegisterType((*ReqSupply)(nil), "message.req_supply")
+	proto.RegisterType((*RespSupply)(nil), "message.resp_supply")
 	proto.RegisterType((*ReqMaxBlockNumber)(nil), "message.req_max_block_number")
 	proto.RegisterType((*RespMaxBlockNumber)(nil), "message.resp_max_block_number")
 	proto.RegisterType((*ReqAddrByPriv)(nil), "message.req_addr_by_priv")
@@ -2688,126 +4516,207 @@ func init() {
 }
 
 var fileDescriptor_33c57e4bae7b9afd = []byte{
-	// 1897 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x59, 0xef, 0x6e, 0x1b, 0xb9,
-	0x11, 0xd7, 0x7f, 0x45, 0xe3, 0xbf, 0x61, 0x1c, 0x65, 0x6f, 0x2f, 0x97, 0xe8, 0x88, 0xe4, 0x62,
-	0x14, 0xb9, 0xc3, 0x35, 0x45, 0x5b, 0xe0, 0x3e, 0x14, 0xb5, 0xdd, 0xc6, 0x4e, 0x9d, 0x7f, 0xd8,
-	0xa8, 0x2d, 0x0a, 0x14, 0x50, 0xd7, 0x12, 0x6d, 0x2f, 0x24, 0xed, 0x6e, 0x96, 0xb4, 0x21, 0xf7,
-	0x25, 0xfa, 0x2a, 0xfd, 0xd6, 0x0f, 0x7d, 0x87, 0xf6, 0x05, 0x8a, 0xf6, 0x55, 0x8a, 0x19, 0x72,
-	0x57, 0xe4, 0x4a, 0x8a, 0xda, 0x03, 0xfc, 0xe1, 0x3e, 0x89, 0x43, 0x0e, 0x7f, 0x3b, 0x33, 0x9c,
-	0xf9, 0x71, 0x68, 0xc3, 0xd6, 0x54, 0x48, 0x19, 0x5e, 0x88, 0x6f, 0xd2, 0x2c, 0x51, 0x09, 0x6b,
-	0x1b, 0x91, 0xff, 0xb3, 0x0a, 0xcd, 0x24, 0x1b, 0x89, 0x8c, 0x6d, 0x43, 0xed, 0xd5, 0xc8, 0xab,
-	0xf6, 0xaa, 0xfb, 0x9d, 0xa0, 0xf6, 0x6a, 0xc4, 0x3c, 0x68, 0x1f, 0x8c, 0x46, 0x99, 0x90, 0xd2,
-	0xab, 0xd1, 0x64, 0x2e, 0xb2, 0x3d, 0x68, 0xbe, 0xcf, 0xa2, 0xa1, 0xf0, 0xea, 0xbd, 0xea, 0x7e,
-	0x23, 0xd0, 0x02, 0x63, 0xd0, 0x38, 0x09, 0xe5, 0xa5, 0xd7, 0x20, 0x65, 0x1a, 0xb3, 0x87, 0xd0,
-	0xf9, 0x10, 0x5d, 0xc4, 0xa1, 0xba, 0xca, 0x84, 0xd7, 0xa4, 0x85, 0xf9, 0x04, 0x7b, 0x04, 0x70,
-	0x14, 0xa5, 0x97, 0x22, 0x53, 0x62, 0xa6, 0xbc, 0x16, 0x2d, 0x5b, 0x33, 0xb8, 0xbb, 0x9f, 0x85,
-	0x23, 0x11, 0x87, 0x53, 0xe1, 0xb5, 0xf5, 0xee, 0x62, 0x82, 0x75, 0xa1, 0x15, 0x88, 0x8b, 0x28,
-	0x89, 0xbd, 0x3b, 0xb4, 0x64, 0x24, 0xfe, 0xef, 0x1a, 0xd4, 0xfa, 0x33, 0x34, 0xf2, 0x6d, 0x12,
-	0x0f, 0x05, 0x79, 0xd4, 0x08, 0xb4, 0xc0, 0x7c, 0xb8, 0x73, 0x38, 0x49, 0x86, 0xe3, 0xb7, 0x57,
-	0x53, 0xf2, 0xaa, 0x11, 0x14, 0x32, 0x02, 0x1e, 0x4c, 0x93, 0xab, 0x58, 0x19, 0xbf, 0x8c, 0x84,
-	0x8e, 0xbd, 0xcc, 0x92, 0x69, 0xee, 0x18, 0x8e, 0x31, 0x58, 0xfd, 0xc4, 0x78, 0x54, 0xeb, 0x27,
-	0x85, 0xf3, 0xad, 0x55, 0xce, 0xb7, 0xcb, 0xce, 0x33, 0x68, 0xf4, 0xa3, 0xa9, 0x20, 0xe3, 0xeb,
-	0x01, 0x8d, 0xd1, 0x82, 0x0f, 0xc3, 0x2c, 0x4a, 0x95, 0xd7, 0xd1, 0x2e, 0x69, 0x89, 0xed, 0x42,
-	0xfd, 0xa5, 0x10, 0x1e, 0x90, 0x59, 0x38, 0xc4, 0xdd, 0x41, 0x92, 0x28, 0x6f, 0xa3, 0x57, 0xdd,
-	0xdf, 0x0c, 0x68, 0x8c, 0x5a, 0xfd, 0xf0, 0xc2, 0xdb, 0xec, 0x55, 0xf7, 0x9b, 0x01, 0x0e, 0x11,
-	0x2f, 0xd5, 0xbe, 0x6e, 0x69, 0x8f, 0xd2, 0xc2, 0xd3, 0xb1, 0x4a, 0x70, 0x7e, 0x5b, 0xcf, 0x6b,
-	0x89, 0x3d, 0x31, 0xb9, 0xe0, 0xed, 0xf4, 0xaa, 0xfb, 0x1b, 0x2f, 0xb6, 0xbf, 0xc9, 0x93, 0x86,
-	0x66, 0x03, 0xbd, 0xc8, 0x9f, 0x41, 0x2b, 0x13, 0x72, 0xa0, 0x66, 0xec, 0x0b, 0xa8, 0xf7, 0x67,
-	0xd2, 0xab, 0xf6, 0xea, 0xfb, 0x1b, 0x2f, 0x36, 0x0a, 0xed, 0xfe, 0x2c, 0xc0, 0x79, 0xce, 0x51,
-	0xf1, 0x23, 0x2a, 0x7a, 0xd0, 0x0e, 0x4d, 0x2e, 0xe9, 0x04, 0xcb, 0x45, 0xfe, 0x04, 0xb6, 0xb5,
-	0xce, 0xe0, 0xec, 0x66, 0x70, 0x89, 0x61, 0x63, 0xd0, 0xc0, 0x5f, 0xa3, 0x48, 0x63, 0xfe, 0x27,
-	0xd8, 0xc9, 0x84, 0x4c, 0x4b, 0x6a, 0xc3, 0x64, 0xa4, 0x8f, 0xb7, 0x19, 0xd0, 0x18, 0x3f, 0x63,
-	0x6c, 0xc8, 0x53, 0xd6, 0x88, 0xec, 0x31, 0x34, 0x46, 0xa1, 0x0a, 0xe9, 0x64, 0x4b, 0xa6, 0xd2,
-	0x02, 0x7f, 0x06, 0x1b, 0x68, 0xc7, 0x59, 0x38, 0x09, 0x31, 0x4f, 0x56, 0x1b, 0xfc, 0x14, 0x15,
-	0x65, 0xa1, 0xd8, 0x85, 0xd6, 0x59, 0x38, 0x99, 0xe7, 0x99, 0x91, 0xf8, 0xd7, 0x70, 0x8f, 0xf0,
-	0x30, 0xb9, 0xd0, 0xe6, 0xf8, 0x6a, 0x7a, 0x26, 0x32, 0x54, 0xbf, 0x14, 0xd1, 0xc5, 0xa5, 0xca,
-	0xd5, 0xb5, 0xc4, 0x9f, 0xc1, 0x5d, 0x47, 0x7d, 0x65, 0x24, 0xfe, 0x53, 0x05, 0xa0, 0x50, 0x90,
-	0x2a, 0xe2, 0x9d, 0x38, 0x78, 0x5a, 0x62, 0x4f, 0x60, 0xeb, 0x7d, 0x26, 0xae, 0x29, 0xb7, 0x29,
-	0x31, 0x75, 0x3c, 0xdc, 0xc9, 0xfc, 0xfc, 0xea, 0xcb, 0xcf, 0xaf, 0x48, 0x32, 0x93, 0xf8, 0x94,
-	0x64, 0x1e, 0xb4, 0x7f, 0x27, 0x32, 0x89, 0x65, 0xd7, 0xa4, 0x2f, 0xe6, 0x22, 0x55, 0x6b, 0x34,
-	0x15, 0x52, 0x85, 0xd3, 0x94, 0xea, 0xa0, 0x1e, 0xcc, 0x27, 0x8a, 0x02, 0x69, 0x5b, 0x05, 0xb2,
-	0x07, 0xcd, 0x37, 0x51, 0x2c, 0x32, 0x53, 0xc0, 0x5a, 0xe0, 0xcf, 0x29, 0xc0, 0x69, 0x22, 0xc5,
-	0x40, 0xcd, 0x24, 0xda, 0xa8, 0x56, 0xe4, 0x98, 0x9a, 0xe1, 0x71, 0x6c, 0xe5, 0xda, 0x31, 0x55,
-	0xf8, 0x1e, 0x34, 0x63, 0xbb, 0xee, 0x49, 0xe0, 0x4f, 0xa1, 0x83, 0xf1, 0xd5, 0x2a, 0xab, 0x0f,
-	0xf7, 0x6f, 0x55, 0x4c, 0xb4, 0x8f, 0x03, 0x95, 0x85, 0xb1, 0x0c, 0x87, 0x0a, 0xfd, 0xca, 0xcb,
-	0xbf, 0xba, 0x50, 0xfe, 0xb5, 0xa2, 0xfc, 0x57, 0x51, 0x47, 0x41, 0x42, 0x0d, 0x9b, 0x84, 0x18,
-	0x34, 0xde, 0x67, 0xd1, 0xb5, 0xa1, 0x0f, 0x1a, 0xdb, 0xa9, 0xdb, 0x72, 0x53, 0xf7, 0x09, 0x34,
-	0xdf, 0x51, 0x51, 0xb6, 0x97, 0x17, 0x25, 0x2d, 0xf2, 0xa7, 0x54, 0x21, 0x65, 0xc3, 0x4f, 0xac,
-	0xf4, 0xc1, 0x31, 0xff, 0x05, 0xec, 0x96, 0xfc, 0x93, 0xec, 0x47, 0x76, 0x84, 0xbd, 0x02, 0xbe,
-	0xa4, 0xa7, 0xc3, 0x7d, 0x80, 0x79, 0x8a, 0x85, 0x68, 0x03, 0x3c, 0x87, 0x3b, 0x98, 0x9b, 0xaf,
-	0x23, 0xa9, 0x0c, 0xca, 0x6e, 0x81, 0x82, 0x0b, 0x6f, 0xe4, 0x45, 0x50, 0x68, 0xf0, 0xbf, 0x56,
-	0xa1, 0x8b, 0xd8, 0x32, 0xba, 0x88, 0xc5, 0xa8, 0x6c, 0xf1, 0xb9, 0x15, 0xea, 0x73, 0x13, 0x6a,
-	0x55, 0x84, 0x5a, 0x51, 0xa8, 0x43, 0x27, 0xd4, 0x61, 0x11, 0xea, 0xd8, 0x0e, 0x75, 0x9c, 0x87,
-	0x5a, 0x21, 0xcb, 0x36, 0x35, 0xcb, 0xe2, 0xb8, 0x28, 0xab, 0x96, 0xe6, 0xce, 0x4b, 0xc3, 0xd5,
-	0xd2, 0xe1, 0xea, 0xcd, 0x60, 0x3e, 0xc1, 0xbf, 0x86, 0x07, 0xe4, 0xf5, 0x72, 0x93, 0x17, 0x6a,
-	0xf4, 0x14, 0xda, 0xc6, 0x6d, 0x87, 0xa5, 0xea, 0x6b, 0x59, 0x2a, 0x07, 0xab, 0x5b, 0x60, 0xaf,
-	0xf1, 0xdb, 0xcb, 0xa2, 0x25, 0xd9, 0x8f, 0xed, 0x83, 0x7b, 0xec, 0x1c, 0xdc, 0xa2, 0xba, 0x3e,
-	0xbf, 0x13, 0xf0, 0x56, 0x78, 0xf2, 0xff, 0x1e, 0xe3, 0x5d, 0x5d, 0x29, 0xc3, 0x4c, 0x84, 0x4a,
-	0x0c, 0xb0, 0x80, 0xf8, 0x4b, 0x4c, 0x2e, 0x99, 0xda, 0x73, 0xab, 0x6b, 0x0d, 0x57, 0xd2, 0x2c,
-	0xba, 0x1e, 0x8b, 0x9b, 0x3c, 0x0c, 0x46, 0xe4, 0x5d, 0xd8, 0x43, 0xe8, 0x69, 0x38, 0x33, 0x84,
-	0xa8, 0xc9, 0x93, 0xff, 0x14, 0xee, 0x13, 0x7e, 0x79, 0x01, 0x4f, 0x6f, 0x1a, 0xce, 0xde, 0x92,
-	0x60, 0xea, 0x7e, 0x3e, 0xc1, 0xbf, 0xd2, 0x39, 0x8f, 0xdf, 0x45, 0x6a, 0xc5, 0xaf, 0x60, 0xa4,
-	0xf1, 0x37, 0x3f, 0x36, 0x1c, 0x6b, 0x0e, 0x96, 0xe9, 0x82, 0x22, 0xca, 0xb9, 0x22, 0xf9, 0x79,
-	0x02, 0x9b, 0x79, 0x8c, 0x07, 0x49, 0x36, 0x5a, 0x06, 0x36, 0xaf, 0xda, 0xda, 0xa7, 0xaa, 0xf6,
-	0x40, 0xb3, 0x97, 0x03, 0x55, 0x4e, 0x27, 0x37, 0x37, 0x75, 0xa8, 0xac, 0xdc, 0xfc, 0x47, 0xd5,
-	0x94, 0x74, 0x32, 0x16, 0xb1, 0x09, 0xfd, 0xed, 0x14, 0x52, 0x6a, 0x71, 0x16, 0xf9, 0xd8, 0x85,
-	0x96, 0xbc, 0x99, 0x9e, 0x25, 0x13, 0x43, 0x59, 0x46, 0x42, 0x04, 0x95, 0xa8, 0x70, 0x42, 0x85,
-	0xd4, 0x08, 0xb4, 0x80, 0xed, 0xc9, 0xb9, 0xd0, 0xfd, 0x4e, 0x23, 0xc0, 0x21, 0xea, 0x8d, 0xc4,
-	0x34, 0x1a, 0x52, 0xb7, 0xd3, 0x08, 0xb4, 0x50, 0x1c, 0x43, 0xd9, 0xa1, 0x85, 0x32, 0xfb, 0xb5,
-	0xbe, 0x33, 0xb5, 0xde, 0xda, 0x8b, 0xdb, 0xb2, 0xb6, 0x66, 0x5b, 0xcb, 0x0f, 0x81, 0x59, 0xdf,
-	0x5b, 0x73, 0xaf, 0xcf, 0x6d, 0xae, 0xd9, 0x36, 0xff, 0xa5, 0x86, 0xa9, 0x99, 0xdb, 0x72, 0xeb,
-	0x94, 0xb6, 0x70, 0x12, 0x3d, 0xd8, 0xa0, 0x4f, 0x9b, 0x4b, 0xa8, 0x45, 0xfa, 0xf6, 0x94, 0xe5,
-	0x7d, 0xdb, 0x39, 0xab, 0xc5, 0x53, 0xc9, 0x29, 0xb3, 0xb3, 0x84, 0x32, 0x61, 0x15, 0x65, 0x6e,
-	0x94, 0x29, 0xf3, 0x39, 0x92, 0x7c, 0x11, 0xd5, 0x75, 0x8c, 0xf9, 0x1b, 0x7d, 0x25, 0x2c, 0x28,
-	0x4b, 0xf6, 0xad, 0xcd, 0x71, 0x8f, 0xdc, 0xcb, 0xa9, 0xac, 0xad, 0x29, 0xee, 0x0f, 0xb0, 0x75,
-	0x9e, 0x09, 0xf1, 0x67, 0x71, 0xb8, 0x36, 0x25, 0x3c, 0x68, 0x9b, 0xf3, 0x36, 0xc7, 0x99, 0x8b,
-	0x18, 0x7a, 0xa9, 0x42, 0xa5, 0x9f, 0x38, 0xcd, 0x40, 0x0b, 0xfc, 0x67, 0x98, 0x2a, 0x1f, 0x07,
-	0x17, 0x42, 0x0d, 0xf4, 0x27, 0x30, 0x5d, 0x30, 0xf8, 0x06, 0xb0, 0xa0, 0xce, 0x4e, 0x60, 0x4f,
-	0xf1, 0x63, 0x6c, 0x06, 0x65, 0x5a, 0xde, 0xf8, 0x2d, 0xb4, 0x33, 0x21, 0xaf, 0x26, 0x2a, 0xf7,
-	0xaf, 0x5b, 0xf8, 0xe7, 0x78, 0x10, 0xe4, 0x6a, 0xfc, 0xef, 0xa6, 0x3f, 0x19, 0x26, 0xf1, 0xb5,
-	0xc8, 0xd4, 0x20, 0x1d, 0x8e, 0x97, 0x31, 0x54, 0x11, 0xe3, 0xda, 0x2a, 0x1a, 0xa9, 0x97, 0x68,
-	0x04, 0x57, 0x55, 0xd1, 0xbd, 0x35, 0x74, 0xf7, 0x56, 0x4c, 0xcc, 0x33, 0xb1, 0x69, 0x67, 0xe2,
-	0xfc, 0x19, 0xd1, 0x72, 0x9e, 0x11, 0xf3, 0x67, 0x47, 0xdb, 0x7e, 0x76, 0xf0, 0x2f, 0x75, 0x8f,
-	0x9d, 0x62, 0x8b, 0x1b, 0x4e, 0x96, 0x52, 0x6b, 0x0f, 0xa9, 0x55, 0xa6, 0x85, 0xce, 0x2e, 0xd4,
-	0xe3, 0xab, 0xa9, 0xa9, 0x41, 0x1c, 0xe6, 0x20, 0x63, 0x95, 0x20, 0xfb, 0x7f, 0x12, 0x24, 0xd7,
-	0x59, 0x04, 0x29, 0xc7, 0x71, 0xac, 0x92, 0x1f, 0x50, 0x1c, 0x77, 0xf0, 0xd6, 0xc0, 0xfc, 0x57,
-	0xe1, 0x04, 0x23, 0xc5, 0xbf, 0xc2, 0x47, 0x14, 0x15, 0x9b, 0x99, 0x99, 0x53, 0x70, 0xd5, 0xa2,
-	0x60, 0x77, 0xe3, 0x58, 0x25, 0x7c, 0xdf, 0xd9, 0x38, 0xd6, 0x5c, 0x34, 0x12, 0x93, 0x53, 0x95,
-	0xe4, 0xbc, 0xa7, 0xa5, 0x17, 0xff, 0xda, 0x85, 0xf6, 0x71, 0x26, 0x84, 0x12, 0x19, 0x3b, 0x81,
-	0xad, 0x63, 0xa1, 0x0e, 0x46, 0xa3, 0xec, 0xf0, 0x86, 0x9a, 0xd7, 0xcf, 0x9c, 0xba, 0xb4, 0xef,
-	0x4f, 0xdf, 0xb7, 0x96, 0x4a, 0x77, 0x2b, 0xaf, 0xb0, 0xef, 0x00, 0x8e, 0x85, 0xca, 0x0b, 0x75,
-	0xcf, 0x81, 0x31, 0xa5, 0xe8, 0xdb, 0xb3, 0xc5, 0xbb, 0x8b, 0x57, 0xd8, 0x6b, 0xd8, 0x99, 0xef,
-	0xed, 0x23, 0x17, 0x30, 0x7f, 0x09, 0x3f, 0xe4, 0x30, 0x9f, 0xbb, 0x86, 0x38, 0x8b, 0xbc, 0xc2,
-	0x8e, 0xe0, 0x1e, 0xfa, 0x74, 0x1d, 0x46, 0x93, 0xf0, 0x6c, 0x22, 0xbe, 0x9f, 0x49, 0xef, 0x60,
-	0xf7, 0x58, 0xa8, 0x97, 0x0e, 0xfb, 0x7c, 0xee, 0x20, 0xb8, 0x0c, 0xe0, 0x3f, 0x74, 0x8d, 0x72,
-	0x57, 0x79, 0x85, 0x1d, 0xc0, 0x5d, 0x13, 0x69, 0x21, 0x25, 0x3d, 0x1e, 0x0e, 0x14, 0x63, 0x0e,
-	0x22, 0xa5, 0x90, 0xdf, 0x75, 0x80, 0x8a, 0xd7, 0x10, 0xaf, 0xb0, 0x9f, 0xc3, 0xe6, 0xb1, 0x50,
-	0xfd, 0x99, 0x3c, 0xbc, 0x41, 0x1c, 0xb6, 0xe3, 0xc6, 0x68, 0xe6, 0x3a, 0x93, 0x3f, 0xbb, 0x78,
-	0x85, 0x1d, 0xc2, 0x06, 0x6d, 0x3c, 0xbc, 0xa1, 0xc7, 0xda, 0x83, 0xd2, 0xbe, 0xfc, 0x95, 0xea,
-	0x7b, 0xa5, 0xc0, 0x16, 0x2b, 0xbc, 0xc2, 0xfa, 0x64, 0xff, 0x9b, 0x70, 0x96, 0xff, 0x91, 0x05,
-	0xbb, 0xb5, 0x2f, 0x1c, 0xa4, 0x72, 0x33, 0xe7, 0x3f, 0x72, 0xf1, 0x16, 0xba, 0xc0, 0x0a, 0xfb,
-	0x15, 0xe5, 0x1f, 0x41, 0x1e, 0xde, 0x60, 0xa1, 0x3c, 0x74, 0x4f, 0xc9, 0x7d, 0x73, 0xfb, 0xf7,
-	0x5c, 0x40, 0x5a, 0xa6, 0x13, 0xdf, 0x9e, 0xa3, 0x90, 0x8b, 0xfe, 0x72, 0x18, 0xf2, 0x72, 0x05,
-	0xc8, 0x31, 0xec, 0x7c, 0x10, 0xf1, 0xa8, 0x6f, 0xdd, 0x6f, 0x2b, 0x5f, 0x50, 0x6e, 0xa4, 0xec,
-	0x15, 0x5e, 0x61, 0xa7, 0xb0, 0x5b, 0x02, 0x92, 0xa5, 0xb2, 0xb2, 0xaf, 0xc5, 0x72, 0x59, 0xd9,
-	0x6b, 0xbc, 0xc2, 0xfe, 0x08, 0xf7, 0x11, 0xec, 0x03, 0x35, 0xf9, 0xb6, 0x6d, 0xeb, 0x1e, 0x09,
-	0x7e, 0xcf, 0xc5, 0x5d, 0xd4, 0xe0, 0x15, 0x36, 0x80, 0xee, 0x52, 0x74, 0xc9, 0x7a, 0x6b, 0xe0,
-	0xa5, 0xff, 0xe5, 0x3a, 0x7c, 0x39, 0xff, 0x80, 0xae, 0xa3, 0xdb, 0xf8, 0x40, 0x08, 0x1e, 0x7e,
-	0xe0, 0xb7, 0xf1, 0xf9, 0xad, 0x7d, 0xe2, 0x2d, 0x74, 0xe8, 0x3c, 0x89, 0x97, 0xd6, 0xf4, 0x2d,
-	0xfe, 0xe3, 0x65, 0xdc, 0xe4, 0x06, 0xfd, 0xf7, 0x3a, 0xd1, 0x4c, 0xd0, 0x09, 0xf5, 0xf1, 0xa7,
-	0x51, 0xff, 0x47, 0x43, 0x8f, 0x00, 0x8e, 0xa8, 0xc7, 0x26, 0x76, 0x70, 0x93, 0xd7, 0x7a, 0xc8,
-	0xf9, 0x9f, 0xb9, 0x60, 0xf6, 0xbb, 0xaf, 0xc2, 0x5e, 0xc1, 0xb6, 0x06, 0x39, 0x4a, 0x62, 0x95,
-	0x85, 0x43, 0x55, 0xce, 0x5d, 0xab, 0x97, 0x5f, 0xc8, 0x5d, 0x6b, 0x8d, 0x8a, 0xbb, 0xf3, 0x26,
-	0x8a, 0x95, 0x76, 0xf1, 0x7b, 0xa3, 0x7c, 0x07, 0x6d, 0x0c, 0xd5, 0xbb, 0x6c, 0xc4, 0xee, 0x2f,
-	0x1c, 0x28, 0xbe, 0xb4, 0x4a, 0x8c, 0x59, 0xcc, 0xeb, 0x4b, 0xe9, 0x48, 0xf7, 0x05, 0xef, 0x87,
-	0xe3, 0x72, 0x44, 0xe6, 0x8d, 0x97, 0xbf, 0xf0, 0x3a, 0x76, 0xf6, 0x9e, 0xaa, 0x64, 0xc5, 0xde,
-	0xb1, 0x4a, 0x56, 0xec, 0xed, 0x1c, 0x0b, 0xfc, 0x26, 0x52, 0x9a, 0x7b, 0xf1, 0x98, 0x76, 0xc8,
-	0xbf, 0xef, 0x1a, 0x6d, 0xa6, 0x8b, 0xbd, 0xa7, 0xba, 0x6f, 0x70, 0xf7, 0x9a, 0x2e, 0xa8, 0xbc,
-	0xd7, 0x4c, 0xf3, 0x0a, 0xfb, 0xa5, 0x26, 0x7a, 0x6c, 0x01, 0xd0, 0xe1, 0x6e, 0x29, 0xe6, 0xa6,
-	0xa5, 0xf0, 0x1f, 0x94, 0x03, 0x9e, 0x77, 0x1f, 0x0e, 0x02, 0xba, 0xbd, 0x0c, 0x01, 0x9d, 0x5e,
-	0x8a, 0x80, 0x6d, 0x48, 0xe5, 0xac, 0x45, 0xff, 0x96, 0xf8, 0xc9, 0x7f, 0x03, 0x00, 0x00, 0xff,
-	0xff, 0x52, 0xc0, 0xa5, 0x15, 0xa7, 0x18, 0x00, 0x00,
+	// 3195 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0x4b, 0x73, 0x1c, 0xb7,
+	0xf1, 0xdf, 0xf7, 0x72, 0x9b, 0x4f, 0x81, 0x12, 0xb5, 0x5a, 0x3f, 0x44, 0xa3, 0x64, 0x8b, 0x76,
+	0x49, 0xb6, 0x2c, 0xff, 0x5f, 0xe5, 0x7f, 0xe5, 0x41, 0xd2, 0x12, 0xa9, 0xe8, 0x61, 0xd6, 0x70,
+	0x2d, 0xc7, 0xa9, 0x54, 0x6d, 0x86, 0xbb, 0x20, 0x39, 0xb5, 0xbb, 0x33, 0xeb, 0x19, 0x90, 0x5e,
+	0xfa, 0x90, 0x0f, 0x90, 0x63, 0x8e, 0xa9, 0xca, 0x17, 0xc9, 0x39, 0xe7, 0xf8, 0x13, 0xe4, 0x9a,
+	0x6b, 0x2e, 0x39, 0x25, 0x95, 0xaa, 0x54, 0xa3, 0x81, 0x99, 0xc1, 0x3c, 0x48, 0xd9, 0x29, 0x5d,
+	0x72, 0xda, 0xe9, 0x46, 0xa3, 0x01, 0x74, 0xff, 0xd0, 0xdd, 0x00, 0x16, 0x96, 0xa7, 0x22, 0x8a,
+	0xdc, 0x13, 0xf1, 0xe1, 0x2c, 0x0c, 0x64, 0xc0, 0xda, 0x9a, 0xe4, 0xdf, 0x55, 0xa1, 0x19, 0x84,
+	0x23, 0x11, 0xb2, 0x15, 0xa8, 0x3d, 0x19, 0x75, 0xab, 0x9b, 0xd5, 0xad, 0x8e, 0x53, 0x7b, 0x32,
+	0x62, 0x5d, 0x68, 0x6f, 0x8f, 0x46, 0xa1, 0x88, 0xa2, 0x6e, 0x4d, 0x31, 0x0d, 0xc9, 0xae, 0x43,
+	0xf3, 0x20, 0xf4, 0x86, 0xa2, 0x5b, 0xdf, 0xac, 0x6e, 0x35, 0x1c, 0x22, 0x18, 0x83, 0xc6, 0xbe,
+	0x1b, 0x9d, 0x76, 0x1b, 0x4a, 0x58, 0x7d, 0xb3, 0x37, 0xa1, 0x73, 0xe8, 0x9d, 0xf8, 0xae, 0x3c,
+	0x0b, 0x45, 0xb7, 0xa9, 0x1a, 0x12, 0x06, 0x7b, 0x1b, 0x60, 0xd7, 0x9b, 0x9d, 0x8a, 0x50, 0x8a,
+	0xb9, 0xec, 0xb6, 0x54, 0x73, 0x8a, 0x83, 0xbd, 0xfb, 0xa1, 0x3b, 0x12, 0xbe, 0x3b, 0x15, 0xdd,
+	0x36, 0xf5, 0x8e, 0x19, 0x6c, 0x03, 0x5a, 0x8e, 0x38, 0xf1, 0x02, 0xbf, 0xbb, 0xa0, 0x9a, 0x34,
+	0xc5, 0xff, 0x52, 0x87, 0x5a, 0x7f, 0x8e, 0x93, 0x7c, 0x11, 0xf8, 0x43, 0xa1, 0x56, 0xd4, 0x70,
+	0x88, 0x60, 0x3d, 0x58, 0xd8, 0x99, 0x04, 0xc3, 0xf1, 0x8b, 0xb3, 0xa9, 0x5a, 0x55, 0xc3, 0x89,
+	0x69, 0x54, 0xb8, 0x3d, 0x0d, 0xce, 0x7c, 0xa9, 0xd7, 0xa5, 0x29, 0x5c, 0xd8, 0xe3, 0x30, 0x98,
+	0x9a, 0x85, 0xe1, 0x37, 0x1a, 0xab, 0x1f, 0xe8, 0x15, 0xd5, 0xfa, 0x41, 0xbc, 0xf8, 0x56, 0xd9,
+	0xe2, 0xdb, 0xd9, 0xc5, 0x33, 0x68, 0xf4, 0xbd, 0xa9, 0x50, 0x93, 0xaf, 0x3b, 0xea, 0x1b, 0x67,
+	0x70, 0x38, 0x0c, 0xbd, 0x99, 0xec, 0x76, 0x68, 0x49, 0x44, 0xb1, 0x35, 0xa8, 0x3f, 0x16, 0xa2,
+	0x0b, 0x6a, 0x5a, 0xf8, 0x89, 0xbd, 0x9d, 0x20, 0x90, 0xdd, 0xc5, 0xcd, 0xea, 0xd6, 0x92, 0xa3,
+	0xbe, 0x51, 0xaa, 0xef, 0x9e, 0x74, 0x97, 0x36, 0xab, 0x5b, 0x4d, 0x07, 0x3f, 0x51, 0xdf, 0x8c,
+	0xd6, 0xba, 0x4c, 0x2b, 0x9a, 0xc5, 0x2b, 0x1d, 0xcb, 0x00, 0xf9, 0x2b, 0xc4, 0x27, 0x8a, 0xdd,
+	0xd1, 0x58, 0xe8, 0xae, 0x6e, 0x56, 0xb7, 0x16, 0x1f, 0xae, 0x7c, 0x68, 0x40, 0xa3, 0xb8, 0x0e,
+	0x35, 0xb2, 0x0f, 0x60, 0xed, 0xa5, 0x3b, 0xf1, 0x46, 0x5f, 0xf8, 0xd2, 0x9b, 0xec, 0x0b, 0xef,
+	0xe4, 0x54, 0x76, 0xd7, 0x94, 0x9e, 0x1c, 0x9f, 0xbd, 0x07, 0x2b, 0x09, 0x4f, 0xad, 0xf7, 0x9a,
+	0x5a, 0x6f, 0x86, 0x8b, 0x60, 0xdb, 0x3d, 0x75, 0x3d, 0xff, 0xc9, 0x67, 0x5d, 0xa6, 0x54, 0x19,
+	0x12, 0x5b, 0x5e, 0x8a, 0x30, 0x42, 0x3f, 0xaf, 0x6f, 0x56, 0xb7, 0x96, 0x1d, 0x43, 0xf2, 0xbb,
+	0xd0, 0x0a, 0x45, 0x34, 0x90, 0x73, 0xf6, 0x16, 0xd4, 0xfb, 0xf3, 0xa8, 0x5b, 0xdd, 0xac, 0x6f,
+	0x2d, 0x3e, 0x5c, 0x8c, 0x67, 0xdd, 0x9f, 0x3b, 0xc8, 0xe7, 0x1c, 0x05, 0xbf, 0x46, 0xc1, 0x2e,
+	0xb4, 0x5d, 0x8d, 0x69, 0x02, 0xba, 0x21, 0xf9, 0x1d, 0x58, 0x21, 0x99, 0xc1, 0xd1, 0xc5, 0xe0,
+	0x14, 0xdd, 0xc7, 0xa0, 0x81, 0xbf, 0x5a, 0x50, 0x7d, 0xf3, 0x5f, 0xc1, 0x6a, 0x28, 0xa2, 0x59,
+	0x46, 0x6c, 0x18, 0x8c, 0x08, 0x66, 0x4d, 0x47, 0x7d, 0xe3, 0x30, 0x7a, 0x0e, 0x66, 0xeb, 0x68,
+	0x92, 0xdd, 0x86, 0xc6, 0xc8, 0x95, 0xae, 0x42, 0x58, 0x66, 0xaa, 0xaa, 0x81, 0xdf, 0x85, 0x45,
+	0x9c, 0xc7, 0x91, 0x3b, 0x71, 0x11, 0xaf, 0xe5, 0x13, 0x7e, 0x17, 0x05, 0xa3, 0x58, 0x70, 0x03,
+	0x5a, 0x47, 0xee, 0x24, 0xc1, 0xbb, 0xa6, 0xf8, 0x7d, 0x58, 0x57, 0xfa, 0x10, 0xe4, 0x38, 0x67,
+	0xff, 0x6c, 0x7a, 0x24, 0x42, 0x14, 0x3f, 0x25, 0xcf, 0x69, 0x71, 0xa2, 0xf8, 0x5d, 0xb8, 0x66,
+	0x89, 0x97, 0x5a, 0xe2, 0x9f, 0x55, 0x00, 0x65, 0x0a, 0x25, 0x8a, 0xfa, 0xf6, 0x2d, 0x7d, 0x44,
+	0xb1, 0x3b, 0xb0, 0x7c, 0x10, 0x8a, 0x73, 0xb5, 0xc7, 0xd4, 0x06, 0x21, 0x7b, 0xd8, 0x4c, 0xe3,
+	0xbf, 0x7a, 0xb1, 0xff, 0x62, 0xb0, 0xeb, 0x0d, 0x88, 0xdf, 0x69, 0x58, 0x34, 0x09, 0x30, 0x9a,
+	0x54, 0x51, 0xc3, 0x9b, 0x8a, 0x48, 0xba, 0xd3, 0x99, 0xda, 0x8f, 0x75, 0x27, 0x61, 0xc4, 0x1b,
+	0xb5, 0x9d, 0xda, 0xa8, 0xd7, 0xa1, 0xf9, 0xdc, 0xf3, 0x45, 0xa8, 0x03, 0x09, 0x11, 0x6a, 0xfb,
+	0x4a, 0x57, 0x0a, 0x35, 0x74, 0x47, 0x6f, 0x5f, 0xc3, 0xe0, 0x1f, 0xc0, 0x5a, 0x62, 0xa8, 0x61,
+	0x30, 0x9d, 0x7a, 0xb2, 0xd4, 0xa8, 0xfb, 0x00, 0x24, 0x31, 0x88, 0xbc, 0x13, 0xd4, 0x7b, 0x8e,
+	0xe0, 0x77, 0x65, 0x10, 0x6a, 0x93, 0x26, 0x0c, 0x6c, 0x8d, 0xe2, 0xa0, 0x41, 0xc6, 0x4a, 0x18,
+	0xfc, 0x37, 0x55, 0xb8, 0x96, 0x58, 0xfd, 0x8a, 0x71, 0x63, 0xbf, 0xd5, 0x12, 0xbf, 0xe1, 0x5a,
+	0xc3, 0xe0, 0xcc, 0x1f, 0x99, 0xd8, 0xad, 0x08, 0xf6, 0x09, 0x40, 0x3c, 0x48, 0xd4, 0x6d, 0x28,
+	0x3f, 0xac, 0xc7, 0x7e, 0x48, 0x26, 0xef, 0xa4, 0xc4, 0x38, 0x87, 0x25, 0xbd, 0x65, 0x66, 0x61,
+	0x10, 0x1c, 0x17, 0xc2, 0xe4, 0xef, 0x55, 0x58, 0xa2, 0xb9, 0x9e, 0x0a, 0x77, 0x44, 0xc0, 0xfb,
+	0x37, 0x80, 0x62, 0x90, 0x50, 0x2f, 0x46, 0x42, 0xe3, 0x12, 0x24, 0x34, 0xcb, 0x90, 0xd0, 0x2a,
+	0x42, 0x42, 0xbb, 0x14, 0x09, 0x0b, 0x19, 0x24, 0xe0, 0xf8, 0xfd, 0xf9, 0xae, 0xca, 0x1b, 0x1d,
+	0x0a, 0x50, 0x9a, 0xe4, 0x1f, 0xd3, 0x5e, 0xa6, 0x95, 0x2b, 0x18, 0x1f, 0x63, 0x1e, 0xa1, 0x85,
+	0x37, 0x8e, 0x75, 0x1e, 0x91, 0x81, 0xce, 0x44, 0x35, 0x19, 0xf0, 0x9f, 0xa0, 0x4d, 0xa3, 0x59,
+	0xdc, 0xe7, 0x23, 0x68, 0xeb, 0x4f, 0x1d, 0xdd, 0x6e, 0xc4, 0x5e, 0x49, 0x9b, 0xd5, 0x31, 0x52,
+	0xfc, 0x01, 0x40, 0x8c, 0xcb, 0x57, 0x1b, 0xf2, 0x4b, 0x58, 0x4c, 0x20, 0x15, 0xa9, 0x40, 0xa2,
+	0xbe, 0xd4, 0x80, 0x4b, 0x8e, 0xa6, 0x70, 0x99, 0x84, 0x03, 0x2c, 0x07, 0xb0, 0xc1, 0x90, 0x29,
+	0xf8, 0xd5, 0x2d, 0xd8, 0xff, 0x17, 0x80, 0x02, 0xc6, 0x20, 0x92, 0x62, 0x56, 0x84, 0x0e, 0xe4,
+	0x4d, 0xc4, 0xb1, 0x54, 0x93, 0x59, 0x70, 0xd4, 0x37, 0xff, 0x5d, 0x15, 0x96, 0x4d, 0x8c, 0x25,
+	0x5c, 0xdd, 0x87, 0x16, 0xad, 0x4e, 0xf5, 0x2d, 0x35, 0x81, 0x16, 0x62, 0x6f, 0x40, 0x4d, 0xce,
+	0x95, 0xca, 0x4c, 0x2c, 0xa9, 0xc9, 0x39, 0x8e, 0x18, 0xc7, 0xdf, 0x0e, 0x85, 0x5c, 0xf6, 0x3e,
+	0x34, 0xd5, 0x40, 0x39, 0xdc, 0x27, 0xb3, 0x77, 0x48, 0x82, 0xdf, 0xa7, 0xf0, 0xe8, 0x0e, 0x87,
+	0xe8, 0x60, 0x3d, 0xbf, 0xf2, 0x18, 0xfd, 0x8f, 0x2a, 0x30, 0xb5, 0x16, 0xbb, 0xc3, 0xf7, 0x5c,
+	0x10, 0x86, 0x84, 0x18, 0x7e, 0x26, 0x24, 0x18, 0x06, 0x42, 0x56, 0xcc, 0xbd, 0x88, 0x8c, 0xbf,
+	0xe0, 0x10, 0x81, 0x73, 0xd2, 0x99, 0xc1, 0x6c, 0x0a, 0x4d, 0xa2, 0xbc, 0xaf, 0xea, 0x22, 0x0a,
+	0x9b, 0x44, 0xa0, 0x0f, 0x8f, 0x43, 0x21, 0xbe, 0x15, 0x6a, 0x3b, 0x34, 0x1c, 0x4d, 0x61, 0x4d,
+	0x31, 0x1b, 0x8e, 0xd5, 0x76, 0x68, 0x38, 0xf8, 0xa9, 0x2c, 0x38, 0x96, 0x81, 0xda, 0x07, 0x0d,
+	0x47, 0x7d, 0xa3, 0x4e, 0xb2, 0x60, 0x67, 0xb3, 0x8e, 0xdb, 0x86, 0x8c, 0x75, 0x8f, 0x80, 0x15,
+	0x44, 0x62, 0x20, 0xe7, 0x11, 0x06, 0x79, 0x59, 0x92, 0xa4, 0xe5, 0x1c, 0xf3, 0xd9, 0xb2, 0x91,
+	0xf6, 0x03, 0x6b, 0xa2, 0xd5, 0xd4, 0x44, 0xf9, 0xbb, 0xd0, 0x41, 0x0f, 0x90, 0x48, 0xb9, 0xe5,
+	0x7f, 0x5f, 0xc3, 0x4c, 0xfd, 0xf5, 0x40, 0x86, 0xae, 0x1f, 0xb9, 0x43, 0x89, 0xe1, 0xc0, 0xd4,
+	0x71, 0xd5, 0x5c, 0x1d, 0x57, 0x8b, 0xeb, 0xb8, 0xb2, 0x1a, 0x30, 0xae, 0x26, 0x1b, 0xe9, 0x6a,
+	0x92, 0x41, 0xe3, 0x20, 0xf4, 0xce, 0x75, 0x1d, 0xa8, 0xbe, 0xd3, 0xb9, 0xbf, 0x65, 0xe7, 0xfe,
+	0x3b, 0xd0, 0xfc, 0x5c, 0x55, 0x57, 0xed, 0xe2, 0xea, 0x4a, 0x35, 0x9a, 0x5a, 0x6f, 0x21, 0xa9,
+	0xf5, 0x8a, 0xea, 0xad, 0xce, 0x2b, 0xd7, 0x5b, 0x50, 0x54, 0x6f, 0xf1, 0x77, 0x55, 0x21, 0x93,
+	0x35, 0xcf, 0x7e, 0x6a, 0x83, 0xe2, 0x37, 0x3f, 0x85, 0x0d, 0xb4, 0xe2, 0x10, 0x91, 0x33, 0xc9,
+	0x4a, 0x1f, 0xa7, 0x8c, 0x89, 0xdf, 0x89, 0xc7, 0x6a, 0x69, 0x68, 0xad, 0x41, 0xfd, 0x58, 0x98,
+	0xb3, 0x02, 0x7e, 0x62, 0xdf, 0x19, 0x9a, 0x4d, 0xe7, 0x73, 0xfc, 0xe6, 0x4f, 0xe0, 0xa6, 0xda,
+	0x29, 0xc5, 0x43, 0xe5, 0x22, 0x47, 0x0f, 0x16, 0x42, 0x31, 0x9b, 0xb8, 0x43, 0x31, 0xd2, 0xde,
+	0x8b, 0x69, 0x7e, 0x8d, 0x5c, 0x3f, 0x0b, 0x82, 0xc9, 0x40, 0x9c, 0x0b, 0x5f, 0x46, 0xfc, 0x2b,
+	0x80, 0x84, 0x44, 0x85, 0xf2, 0x62, 0x26, 0x8c, 0x42, 0xfc, 0xbe, 0x3c, 0x6a, 0xa4, 0x47, 0xab,
+	0x67, 0x46, 0xfb, 0x31, 0x15, 0x02, 0xa9, 0x09, 0x47, 0xec, 0x83, 0x34, 0xd4, 0xbb, 0xb1, 0xb6,
+	0x8c, 0x1c, 0xe1, 0x7e, 0x5b, 0x67, 0x74, 0x4b, 0xc1, 0x3d, 0x58, 0xc0, 0x65, 0x3e, 0xc3, 0x7d,
+	0x4d, 0x5a, 0xd6, 0x62, 0x2d, 0xd8, 0xf0, 0x3c, 0x3a, 0x71, 0x62, 0x09, 0xfe, 0x5d, 0x8d, 0xdc,
+	0x84, 0xb9, 0x59, 0x8c, 0xae, 0x74, 0x53, 0x92, 0x00, 0x3a, 0x98, 0x00, 0x10, 0xf3, 0xae, 0x85,
+	0x79, 0x37, 0xc6, 0xbc, 0x9f, 0xc6, 0xbc, 0x6f, 0x30, 0x2f, 0x11, 0x57, 0x94, 0x4f, 0xd5, 0x77,
+	0xec, 0xa1, 0x16, 0x9d, 0x46, 0x4e, 0xf5, 0xe9, 0x27, 0xb2, 0x4e, 0x3f, 0x4b, 0xa9, 0x42, 0xc6,
+	0x80, 0x62, 0x21, 0x01, 0xc5, 0x3d, 0x60, 0xaa, 0x0a, 0x1a, 0x9c, 0x21, 0x48, 0x07, 0xa7, 0x16,
+	0xce, 0xcf, 0xb3, 0x38, 0xdf, 0x82, 0xb5, 0xb4, 0xb4, 0x4c, 0x21, 0xfd, 0xdc, 0x3e, 0x59, 0xdc,
+	0x82, 0x85, 0x21, 0x1e, 0x25, 0x06, 0xde, 0x48, 0x9d, 0x96, 0x1a, 0x4e, 0x5b, 0xd1, 0x74, 0xc2,
+	0x3d, 0xd7, 0x95, 0xc3, 0x12, 0x65, 0x6e, 0x4d, 0xf2, 0xfb, 0x1a, 0x8d, 0xc5, 0x16, 0xcd, 0x55,
+	0x39, 0x4f, 0xa1, 0xad, 0xbd, 0x62, 0x1d, 0x07, 0xea, 0x57, 0x1e, 0x07, 0x8c, 0xb2, 0x7a, 0x4a,
+	0xd9, 0x33, 0xb8, 0x59, 0xec, 0xcc, 0x88, 0x7d, 0x9c, 0xc6, 0xd5, 0x6d, 0x0b, 0x57, 0x79, 0x71,
+	0x82, 0xd7, 0x1f, 0x6a, 0x70, 0x0b, 0xdb, 0xe3, 0x0a, 0xd3, 0x5a, 0xcc, 0xe5, 0xb5, 0xe8, 0x06,
+	0x9e, 0x9b, 0xa6, 0xc1, 0xb9, 0xd0, 0x09, 0x5a, 0x53, 0x09, 0x30, 0xea, 0x45, 0xc0, 0x68, 0x14,
+	0x00, 0xa3, 0x59, 0x06, 0x8c, 0x56, 0x16, 0x18, 0xc5, 0x30, 0x68, 0x7f, 0x0f, 0x18, 0x2c, 0x5c,
+	0x09, 0x83, 0x4e, 0x29, 0x0c, 0xc0, 0x86, 0xc1, 0x1a, 0x1d, 0x0a, 0x63, 0x8b, 0x44, 0xfc, 0x63,
+	0x7d, 0x00, 0x4c, 0x58, 0x78, 0x8b, 0x91, 0x50, 0xca, 0x37, 0x1d, 0x27, 0xc5, 0xe1, 0xf7, 0xe1,
+	0x86, 0x0a, 0x47, 0x61, 0x80, 0xc9, 0x2d, 0x1c, 0x44, 0xc3, 0x53, 0x31, 0x3a, 0x9b, 0x28, 0x33,
+	0xaa, 0xaa, 0xc0, 0x24, 0x38, 0x45, 0xf0, 0x47, 0xb0, 0x9c, 0x88, 0x4e, 0x82, 0xf2, 0xea, 0xde,
+	0xf2, 0x5d, 0x2d, 0xe3, 0x3b, 0xfe, 0x0b, 0x0c, 0x09, 0xd1, 0xac, 0x60, 0x58, 0xf4, 0x93, 0x08,
+	0xe3, 0x9a, 0x10, 0xbf, 0xd9, 0x3d, 0x68, 0xe2, 0x58, 0x54, 0xda, 0x2d, 0x3e, 0xdc, 0x48, 0x97,
+	0x40, 0xc9, 0x54, 0x1c, 0x12, 0xe2, 0xfb, 0xd0, 0x2d, 0xd9, 0x1d, 0xdf, 0x37, 0x72, 0xe9, 0x50,
+	0x3d, 0x0c, 0x85, 0x2b, 0xc5, 0x00, 0x93, 0x37, 0x7f, 0x0c, 0x6b, 0x94, 0x08, 0x12, 0x5e, 0x79,
+	0x9e, 0xc7, 0x16, 0x4c, 0x1f, 0x63, 0x71, 0x61, 0xb6, 0x96, 0x26, 0xf9, 0x0a, 0x9d, 0x4e, 0x8c,
+	0xd3, 0xf9, 0xfb, 0xba, 0xac, 0x34, 0x0c, 0xec, 0xaa, 0x11, 0xa0, 0x4d, 0x61, 0x48, 0x7e, 0x04,
+	0x2b, 0x62, 0xea, 0x45, 0x08, 0x81, 0x41, 0x74, 0xea, 0x86, 0x02, 0x6d, 0x1d, 0x8a, 0xa1, 0x37,
+	0xf3, 0x84, 0x76, 0x57, 0xc7, 0x49, 0x18, 0xe8, 0xa1, 0x6f, 0xc8, 0x43, 0x94, 0xf8, 0x34, 0x85,
+	0x69, 0xe3, 0xd8, 0x9d, 0x4c, 0x8e, 0xdc, 0xe1, 0xd8, 0xa4, 0x0d, 0x43, 0xf3, 0x3f, 0x57, 0x61,
+	0x35, 0x1e, 0x64, 0x26, 0x42, 0x2f, 0x18, 0xb1, 0x77, 0x60, 0x29, 0x92, 0x6e, 0x28, 0x07, 0x96,
+	0xbf, 0x17, 0x15, 0x4f, 0x03, 0x5e, 0x6d, 0xc9, 0x6f, 0xdc, 0x70, 0x64, 0x86, 0x22, 0x0a, 0xbb,
+	0x8e, 0xc4, 0xd0, 0xbd, 0xd0, 0xaa, 0xf4, 0xce, 0x5c, 0x54, 0xbc, 0x03, 0xd2, 0xfe, 0x16, 0x00,
+	0x89, 0x84, 0xae, 0x34, 0x31, 0xbd, 0xa3, 0x38, 0x8e, 0x2b, 0x05, 0xfb, 0x08, 0x5a, 0x6a, 0xad,
+	0x51, 0xb7, 0xa9, 0xdc, 0x76, 0x33, 0x76, 0x9b, 0x6d, 0x0b, 0x47, 0x8b, 0x91, 0x4d, 0xa6, 0xae,
+	0xe7, 0x63, 0x49, 0xd3, 0x32, 0x36, 0xd1, 0x0c, 0xfe, 0x11, 0xa1, 0x3e, 0xe9, 0x6b, 0xe0, 0x57,
+	0x76, 0x48, 0xfe, 0xb5, 0x06, 0x6c, 0xbe, 0xc7, 0x43, 0x68, 0xd3, 0xaa, 0xf2, 0x19, 0x35, 0x63,
+	0x41, 0xc7, 0x08, 0x96, 0xda, 0xa9, 0x0b, 0x6d, 0x31, 0xf5, 0xa4, 0x14, 0xc6, 0x44, 0x86, 0xe4,
+	0x4b, 0x74, 0x70, 0x8a, 0xce, 0x66, 0xb3, 0xc9, 0x05, 0xff, 0x6d, 0x55, 0x9f, 0x8a, 0x88, 0x2e,
+	0xdd, 0x84, 0x1b, 0xd0, 0x9a, 0x7a, 0xbe, 0x14, 0xf1, 0x38, 0x44, 0x51, 0x3d, 0x1d, 0x7c, 0x2b,
+	0x7c, 0x93, 0x53, 0x89, 0xc2, 0x30, 0x34, 0x1b, 0x8e, 0x55, 0x6d, 0x62, 0x0a, 0xf3, 0xd9, 0x70,
+	0x7c, 0x10, 0x04, 0x13, 0xb6, 0x09, 0x8b, 0x43, 0x2f, 0x1c, 0x9e, 0x4d, 0x5c, 0xe9, 0xf9, 0x27,
+	0xba, 0x3c, 0x4f, 0xb3, 0xf8, 0x06, 0x5c, 0xc7, 0x29, 0x4e, 0xdd, 0xb9, 0x3e, 0xff, 0xd3, 0x65,
+	0x0e, 0xff, 0x6f, 0xb4, 0x75, 0x34, 0xcb, 0x35, 0xa0, 0x8b, 0xa6, 0xee, 0xfc, 0x85, 0x22, 0xf4,
+	0xc4, 0x13, 0x06, 0x7f, 0x8f, 0x2a, 0x17, 0xdc, 0x4a, 0x78, 0xd5, 0x83, 0x1b, 0x27, 0x2e, 0xcd,
+	0xaa, 0xa9, 0xd2, 0xec, 0xae, 0xae, 0x50, 0xb2, 0x82, 0x48, 0x1b, 0x41, 0xfc, 0xe6, 0xfb, 0xb4,
+	0xe5, 0x30, 0x2c, 0x0c, 0x82, 0x70, 0x54, 0xa4, 0x2c, 0x29, 0x82, 0x6b, 0x97, 0x14, 0xc1, 0x7c,
+	0x5b, 0x6f, 0xd6, 0xb4, 0xaa, 0x5c, 0x0d, 0x78, 0xf9, 0x55, 0xc9, 0x9f, 0xaa, 0xba, 0x30, 0x0b,
+	0xc6, 0xc2, 0xd7, 0xd1, 0xe4, 0xf5, 0x94, 0x43, 0xb3, 0xd4, 0x11, 0x40, 0xad, 0x71, 0x03, 0x5a,
+	0xd1, 0xc5, 0xf4, 0x28, 0x98, 0xe8, 0x6d, 0xa1, 0x29, 0xd4, 0x20, 0x03, 0xe9, 0x4e, 0x74, 0x3a,
+	0x23, 0xa2, 0xa0, 0x14, 0xba, 0x0e, 0xcd, 0x91, 0x98, 0x7a, 0x43, 0x9d, 0xa8, 0x88, 0x88, 0xdd,
+	0x90, 0x5d, 0x50, 0xae, 0x1a, 0x79, 0x44, 0x87, 0x54, 0x92, 0xbb, 0xf2, 0x22, 0x31, 0x35, 0xdb,
+	0x5a, 0x7a, 0xb6, 0x7c, 0x47, 0x9f, 0x5d, 0x6d, 0x3d, 0x25, 0xf7, 0x8c, 0xc9, 0x9c, 0x6b, 0xe9,
+	0x39, 0xff, 0xb1, 0x46, 0x61, 0x80, 0x74, 0xbc, 0xf6, 0xc2, 0x34, 0xe7, 0x89, 0x4d, 0x58, 0x54,
+	0x43, 0xeb, 0x33, 0x1d, 0x9d, 0x6d, 0xd3, 0xac, 0xd4, 0xea, 0xdb, 0x96, 0xaf, 0xf2, 0x5e, 0x31,
+	0xf5, 0x4d, 0xa7, 0xa0, 0xbe, 0x81, 0xb2, 0xfa, 0x66, 0x31, 0x5b, 0xdf, 0xa4, 0xeb, 0x90, 0xa5,
+	0xd2, 0x3a, 0x64, 0xd9, 0xae, 0x43, 0xee, 0xe9, 0xd8, 0x58, 0x68, 0xc6, 0x9c, 0xff, 0x7f, 0x46,
+	0xa7, 0x81, 0x9c, 0x70, 0xc4, 0x1e, 0xa4, 0xeb, 0xc7, 0xb7, 0xed, 0x73, 0x49, 0x56, 0x9a, 0xca,
+	0xc7, 0xaf, 0x60, 0x99, 0x6e, 0x02, 0x76, 0xae, 0xc4, 0x51, 0xea, 0xca, 0xa1, 0x96, 0xbb, 0x72,
+	0x50, 0xf7, 0x15, 0xca, 0x8d, 0x4d, 0x87, 0x08, 0xfe, 0x3f, 0x88, 0xaf, 0xaf, 0x07, 0x27, 0x42,
+	0x0e, 0x68, 0x08, 0xc4, 0x18, 0x7a, 0x4c, 0x2b, 0x8c, 0x4b, 0x88, 0x8e, 0x93, 0x66, 0xf1, 0x3d,
+	0xbc, 0xd1, 0x8e, 0x66, 0xd9, 0x8e, 0x0f, 0xa0, 0x1d, 0x8a, 0xe8, 0x6c, 0x22, 0xcd, 0xfa, 0x92,
+	0x22, 0xc6, 0x5a, 0x81, 0x63, 0xc4, 0xf8, 0x5f, 0xab, 0xba, 0xfa, 0x08, 0xfc, 0x73, 0x11, 0xca,
+	0x81, 0xbe, 0xdd, 0xc8, 0x86, 0xb5, 0xc2, 0x6b, 0x54, 0xcb, 0xc9, 0xf5, 0x4c, 0xec, 0xc1, 0x56,
+	0x19, 0x5f, 0x3c, 0x52, 0x3d, 0x9c, 0x30, 0xca, 0x6f, 0x60, 0xf4, 0x9b, 0x4c, 0xcb, 0x7a, 0x93,
+	0x49, 0xde, 0x70, 0xda, 0xd6, 0x1b, 0x4e, 0xaa, 0x7c, 0x59, 0x28, 0xc5, 0x51, 0xc7, 0xc6, 0xd1,
+	0x3b, 0x74, 0x21, 0x89, 0x19, 0x08, 0x4d, 0x56, 0x14, 0xc3, 0x37, 0xf5, 0x05, 0xa4, 0x91, 0x59,
+	0x83, 0xba, 0x7f, 0x66, 0x8a, 0x45, 0xfc, 0x34, 0x4a, 0xc6, 0x32, 0xc0, 0x34, 0x73, 0xa9, 0x12,
+	0x23, 0x93, 0x57, 0x92, 0xb5, 0x3d, 0xde, 0x22, 0xfd, 0x67, 0xdb, 0x7e, 0x15, 0x96, 0x69, 0x9f,
+	0x49, 0x77, 0x82, 0xd6, 0xe5, 0xef, 0xc1, 0x8a, 0xde, 0xd4, 0x9a, 0x93, 0xe4, 0x87, 0x6a, 0x2a,
+	0x3f, 0xd8, 0x1d, 0xc7, 0x32, 0xe0, 0x5b, 0x56, 0xc7, 0x31, 0x05, 0xca, 0x91, 0x98, 0x3c, 0x95,
+	0x81, 0x09, 0xca, 0x44, 0x3d, 0xfc, 0x5b, 0x17, 0xda, 0x7b, 0xa1, 0x10, 0x52, 0x84, 0x6c, 0x1f,
+	0x96, 0xf7, 0x84, 0xc4, 0x27, 0xdc, 0x9d, 0x0b, 0x75, 0x51, 0x75, 0xcb, 0xda, 0xff, 0xe9, 0xe4,
+	0xde, 0xeb, 0xa5, 0x9a, 0x32, 0x89, 0x9f, 0x57, 0xd8, 0xa7, 0x00, 0x7b, 0x42, 0x9a, 0x80, 0x70,
+	0xdd, 0x52, 0xa3, 0xb7, 0x7c, 0x2f, 0xcd, 0x8d, 0x1f, 0xa9, 0x78, 0x85, 0x3d, 0x83, 0xd5, 0xa4,
+	0x6f, 0x1f, 0x63, 0x0e, 0xeb, 0x15, 0xc4, 0x21, 0xa3, 0xe6, 0x0d, 0x7b, 0x22, 0x56, 0x23, 0xaf,
+	0xb0, 0x5d, 0x58, 0xc7, 0x35, 0x9d, 0xbb, 0xde, 0xc4, 0x3d, 0x9a, 0x88, 0x1f, 0x36, 0xa5, 0xcf,
+	0x61, 0x6d, 0x4f, 0xc8, 0xc7, 0x56, 0x94, 0x7b, 0xc3, 0xd2, 0x60, 0x47, 0x9a, 0xde, 0x9b, 0xf6,
+	0xa4, 0xec, 0x56, 0x5e, 0x61, 0xdb, 0x70, 0x4d, 0x5b, 0x5a, 0x44, 0x91, 0xba, 0x28, 0xdc, 0x96,
+	0x8c, 0x59, 0x1a, 0x15, 0xec, 0x7a, 0x1b, 0x96, 0xa2, 0xf8, 0xe6, 0x93, 0x57, 0xd8, 0xff, 0xc2,
+	0xd2, 0x9e, 0x90, 0xfd, 0x79, 0xb4, 0x73, 0x81, 0x7a, 0xd8, 0xaa, 0x6d, 0xa3, 0x79, 0xef, 0x7a,
+	0xae, 0x2b, 0x46, 0xeb, 0x0a, 0xdb, 0x81, 0x45, 0xd5, 0x71, 0xe7, 0x42, 0xbd, 0x67, 0xdc, 0xcc,
+	0xf4, 0x33, 0x4f, 0x7a, 0xbd, 0x6e, 0xc6, 0xb0, 0x71, 0x0b, 0xaf, 0xb0, 0xbe, 0x9a, 0xff, 0x73,
+	0x77, 0x6e, 0x5e, 0xc6, 0xb1, 0x94, 0x7c, 0xcb, 0xd2, 0x94, 0xad, 0x34, 0x7b, 0x6f, 0xdb, 0xfa,
+	0xb2, 0xed, 0xbc, 0xc2, 0x7e, 0xa4, 0x50, 0x63, 0x5e, 0x75, 0x6f, 0x58, 0xea, 0x4c, 0x46, 0xcc,
+	0x58, 0x24, 0xe6, 0xf3, 0x0a, 0xfb, 0xb9, 0x72, 0xf5, 0x23, 0x5d, 0xef, 0x1f, 0x9a, 0xb3, 0x81,
+	0x9d, 0xc4, 0x72, 0x67, 0x87, 0xde, 0x6d, 0x5b, 0x61, 0x4e, 0x80, 0x57, 0xd8, 0xff, 0x41, 0x67,
+	0x4f, 0xc8, 0x43, 0xaa, 0xf3, 0xd7, 0x2d, 0x7d, 0x54, 0xfc, 0x67, 0x8c, 0xad, 0xb9, 0xbc, 0xc2,
+	0x3e, 0x53, 0x5b, 0x4a, 0x59, 0x69, 0xe7, 0x02, 0xe3, 0xc2, 0x9b, 0x36, 0xf0, 0xec, 0x37, 0xd7,
+	0xde, 0xba, 0xad, 0x46, 0x35, 0x2b, 0x10, 0xaf, 0x24, 0x5a, 0x94, 0xd7, 0x7a, 0xc5, 0x6a, 0x94,
+	0xe3, 0x4a, 0x94, 0x3c, 0x83, 0x75, 0xa3, 0x64, 0x5f, 0xbd, 0x1a, 0xbc, 0xca, 0x84, 0x8a, 0xdf,
+	0x1d, 0x78, 0x85, 0xfd, 0xbf, 0xf2, 0x15, 0x29, 0x8a, 0x32, 0xdb, 0x89, 0x84, 0xa2, 0xde, 0x0d,
+	0x7b, 0x22, 0x9a, 0x1d, 0xdb, 0x73, 0x87, 0x5e, 0x8d, 0xd6, 0xf3, 0x13, 0x88, 0xb2, 0xf6, 0x24,
+	0x2e, 0xaf, 0xb0, 0x27, 0x89, 0x25, 0x76, 0xe9, 0x65, 0xf3, 0x56, 0xc1, 0xfc, 0xe9, 0xd9, 0x29,
+	0x1b, 0xa3, 0xd2, 0x6d, 0x31, 0xda, 0xfa, 0xf3, 0x03, 0xf5, 0xe0, 0x72, 0x23, 0xbb, 0x0d, 0xd4,
+	0x03, 0x45, 0x16, 0x6d, 0x86, 0x1f, 0x87, 0xa9, 0x6d, 0x7a, 0xb5, 0x21, 0x1d, 0xb6, 0x53, 0xac,
+	0x07, 0x9d, 0x6c, 0x98, 0xb2, 0x1a, 0x79, 0x85, 0xed, 0xc1, 0xea, 0xa1, 0xf0, 0x47, 0xfd, 0x54,
+	0xdd, 0x56, 0x7a, 0x29, 0x6c, 0xef, 0xcc, 0x74, 0x0b, 0xaf, 0xb0, 0xa7, 0xb0, 0x96, 0x51, 0x14,
+	0x65, 0x4c, 0x94, 0x92, 0x8f, 0xb2, 0x26, 0x4a, 0xb7, 0xf1, 0x0a, 0xfb, 0x25, 0xdc, 0x40, 0x65,
+	0x87, 0xea, 0x12, 0x27, 0x3d, 0xb7, 0xab, 0x2e, 0x16, 0x7b, 0x9b, 0x99, 0xfd, 0x90, 0x93, 0xe0,
+	0x15, 0x36, 0x80, 0x8d, 0x42, 0xed, 0x11, 0xdb, 0xbc, 0x42, 0x7d, 0xd4, 0x7b, 0xe7, 0x2a, 0xfd,
+	0x51, 0x32, 0x00, 0xc5, 0xed, 0xd7, 0x31, 0x80, 0x0b, 0x5d, 0x1c, 0xe0, 0x0b, 0xff, 0xf8, 0xb5,
+	0x0d, 0x71, 0x44, 0x43, 0xbc, 0x34, 0xb7, 0x76, 0x69, 0x2f, 0x70, 0x6b, 0x88, 0xc2, 0xeb, 0xdb,
+	0x57, 0x72, 0x04, 0x05, 0xa9, 0x97, 0xc9, 0x7d, 0xe5, 0xcd, 0x62, 0xc5, 0x51, 0x36, 0x27, 0x24,
+	0x2d, 0x71, 0xf8, 0x3d, 0xd0, 0xb7, 0x81, 0x25, 0xe1, 0x37, 0x77, 0xd7, 0x98, 0x0d, 0xbf, 0x39,
+	0x01, 0xa5, 0xf9, 0xda, 0xae, 0x7a, 0xf3, 0x29, 0x87, 0x60, 0xfe, 0x4d, 0x28, 0xbb, 0xf2, 0xbc,
+	0x04, 0xaf, 0xb0, 0x7d, 0x58, 0x3f, 0x3c, 0x3b, 0x8a, 0x86, 0xa1, 0x77, 0x24, 0xf0, 0x86, 0xe5,
+	0x91, 0x7a, 0x0b, 0xca, 0x6c, 0xbd, 0xd4, 0x2b, 0x51, 0x2a, 0xb6, 0x26, 0x5c, 0x5e, 0x79, 0x50,
+	0x65, 0x2f, 0xa0, 0xa3, 0xf6, 0x9d, 0xaa, 0x57, 0xae, 0x38, 0x37, 0x65, 0xd7, 0x9c, 0x13, 0xe0,
+	0x15, 0xf6, 0x25, 0xac, 0xa6, 0x36, 0x87, 0xd2, 0x7a, 0xfb, 0x72, 0xad, 0xaf, 0x08, 0xa8, 0x5d,
+	0x80, 0x5d, 0x75, 0x31, 0xa0, 0xaa, 0x06, 0x7b, 0xa5, 0xa9, 0x0b, 0xd5, 0xde, 0xad, 0x8c, 0xf9,
+	0x92, 0x26, 0x0a, 0xc3, 0xa4, 0x64, 0x37, 0xf0, 0x65, 0xe8, 0x0e, 0xb3, 0x61, 0x38, 0x7d, 0x01,
+	0x91, 0x8b, 0x31, 0xa9, 0x36, 0x05, 0xbe, 0xce, 0x73, 0xcf, 0x97, 0xb4, 0xc4, 0x1f, 0xac, 0xe5,
+	0x53, 0x68, 0xa3, 0xa9, 0x3e, 0x0f, 0x47, 0x99, 0x48, 0x6e, 0xae, 0x87, 0xb2, 0x91, 0xdc, 0xf0,
+	0x55, 0x5f, 0xd8, 0xa5, 0x33, 0xc6, 0xc1, 0x70, 0x9c, 0xb5, 0x48, 0x72, 0xf0, 0xeb, 0xe5, 0x6e,
+	0xa9, 0xad, 0xbe, 0x4f, 0x65, 0x50, 0xd2, 0x77, 0x2c, 0x83, 0x92, 0xbe, 0x98, 0x05, 0x0f, 0xe8,
+	0xbc, 0x60, 0x67, 0x50, 0x7d, 0xb4, 0xca, 0x66, 0x50, 0xcd, 0x8e, 0xfb, 0x3e, 0x95, 0x41, 0xbe,
+	0xaf, 0x3e, 0x51, 0x65, 0xfb, 0x6a, 0x36, 0xaf, 0xb0, 0x9f, 0x52, 0x01, 0x88, 0x47, 0x03, 0x5c,
+	0xf0, 0x46, 0xc6, 0xe6, 0xfa, 0xa8, 0xd1, 0xbb, 0x99, 0x35, 0xb8, 0x6e, 0xb0, 0x35, 0xe0, 0xb2,
+	0x8b, 0x34, 0xe0, 0xa2, 0x0b, 0x35, 0xe0, 0xf1, 0xa4, 0x72, 0xd4, 0x52, 0xff, 0x31, 0xfd, 0xe4,
+	0x5f, 0x03, 0x00, 0xaa, 0x62, 0x3a, 0x04, 0x74, 0x2a, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2831,14 +4740,37 @@ type GreeterClient interface {
 	GetTxsByAddr(ctx context.Context, in *ReqTx, opts ...grpc.CallOption) (*ResposeTxs, error)
 	GetTxByHash(ctx context.Context, in *ReqTxByHash, opts ...grpc.CallOption) (*RespTxByHash, error)
 	GetMaxBlockNumber(ctx context.Context, in *ReqMaxBlockNumber, opts ...grpc.CallOption) (*RespMaxBlockNumber, error)
+	//获取链ID，签名交易时需要
+	GetChainID(ctx context.Context, in *ReqChainId, opts ...grpc.CallOption) (*RespChainId, error)
+	GetEmissionSchedule(ctx context.Context, in *ReqEmissionSchedule, opts ...grpc.CallOption) (*RespEmissionSchedule, error)
+	//发行总量、锁仓总量、资金池总量和流通量
+	GetSupply(ctx context.Context, in *ReqSupply, opts ...grpc.CallOption) (*RespSupply, error)
 	GetBlockByNum(ctx context.Context, in *ReqBlockByNumber, opts ...grpc.CallOption) (*RespBlock, error)
 	GetBlockByHash(ctx context.Context, in *ReqBlockByHash, opts ...grpc.CallOption) (*RespBlock, error)
+	GetBlockHeaderByNum(ctx context.Context, in *ReqBlockByNumber, opts ...grpc.CallOption) (*BlockHeader, error)
+	GetHeaders(ctx context.Context, in *ReqHeaders, opts ...grpc.CallOption) (*RespHeaders, error)
+	//批量获取序列化的块和提交证书，供落后的节点同步
+	GetBlocks(ctx context.Context, in *ReqBlocks, opts ...grpc.CallOption) (*RespBlocks, error)
+	//获取块的提交证书
+	GetBlockCommit(ctx context.Context, in *ReqBlockCommit, opts ...grpc.CallOption) (*RespBlockCommit, error)
+	GetTxProof(ctx context.Context, in *ReqTxProof, opts ...grpc.CallOption) (*RespTxProof, error)
+	//获取账户在最新状态树中的证明，可用块头的状态根验证
+	GetAccountProof(ctx context.Context, in *ReqAccountProof, opts ...grpc.CallOption) (*RespAccountProof, error)
 	SendTransaction(ctx context.Context, in *ReqTransaction, opts ...grpc.CallOption) (*ResTransaction, error)
 	SendTransactions(ctx context.Context, in *ReqTransactions, opts ...grpc.CallOption) (*RespTransactions, error)
 	SendSignedTransaction(ctx context.Context, in *ReqSignedTransaction, opts ...grpc.CallOption) (*RespSignedTransaction, error)
 	SendSignedTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error)
 	SendFreezeTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error)
 	SendUnfreezeTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error)
+	//添加或删除验证者，上链后共识层按链上的验证者集合重新配置
+	SendValidatorTransaction(ctx context.Context, in *ReqValidatorTransaction, opts ...grpc.CallOption) (*RespSignedTransaction, error)
+	GetValidators(ctx context.Context, in *ReqValidators, opts ...grpc.CallOption) (*RespValidators, error)
+	//从下一个块开始的计划出块者
+	GetProposerSchedule(ctx context.Context, in *ReqProposerSchedule, opts ...grpc.CallOption) (*RespProposerSchedule, error)
+	//用手续费更高的0金额自转账替换交易池中nonce相同的交易
+	CancelTransaction(ctx context.Context, in *ReqCancelTransaction, opts ...grpc.CallOption) (*RespCancelTransaction, error)
+	//订阅交易池的加入、替换和取消事件
+	SubscribePoolEvents(ctx context.Context, in *ReqPoolEvents, opts ...grpc.CallOption) (Greeter_SubscribePoolEventsClient, error)
 	SendToken(ctx context.Context, in *ReqTokenTransaction, opts ...grpc.CallOption) (*RespTokenTransaction, error)
 	SendSignedToken(ctx context.Context, in *ReqTokenTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error)
 	CreateAddr(ctx context.Context, in *ReqCreateAddr, opts ...grpc.CallOption) (*RespCreateAddr, error)
@@ -2948,6 +4880,33 @@ func (c *greeterClient) GetMaxBlockNumber(ctx context.Context, in *ReqMaxBlockNu
 	return out, nil
 }
 
+func (c *greeterClient) GetChainID(ctx context.Context, in *ReqChainId, opts ...grpc.CallOption) (*RespChainId, error) {
+	out := new(RespChainId)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetChainID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetEmissionSchedule(ctx context.Context, in *ReqEmissionSchedule, opts ...grpc.CallOption) (*RespEmissionSchedule, error) {
+	out := new(RespEmissionSchedule)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetEmissionSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetSupply(ctx context.Context, in *ReqSupply, opts ...grpc.CallOption) (*RespSupply, error) {
+	out := new(RespSupply)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetSupply", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *greeterClient) GetBlockByNum(ctx context.Context, in *ReqBlockByNumber, opts ...grpc.CallOption) (*RespBlock, error) {
 	out := new(RespBlock)
 	err := c.cc.Invoke(ctx, "/message.Greeter/GetBlockByNum", in, out, opts...)
@@ -2966,58 +4925,180 @@ func (c *greeterClient) GetBlockByHash(ctx context.Context, in *ReqBlockByHash,
 	return out, nil
 }
 
-func (c *greeterClient) SendTransaction(ctx context.Context, in *ReqTransaction, opts ...grpc.CallOption) (*ResTransaction, error) {
-	out := new(ResTransaction)
-	err := c.cc.Invoke(ctx, "/message.Greeter/SendTransaction", in, out, opts...)
+func (c *greeterClient) GetBlockHeaderByNum(ctx context.Context, in *ReqBlockByNumber, opts ...grpc.CallOption) (*BlockHeader, error) {
+	out := new(BlockHeader)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetBlockHeaderByNum", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetHeaders(ctx context.Context, in *ReqHeaders, opts ...grpc.CallOption) (*RespHeaders, error) {
+	out := new(RespHeaders)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetHeaders", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetBlocks(ctx context.Context, in *ReqBlocks, opts ...grpc.CallOption) (*RespBlocks, error) {
+	out := new(RespBlocks)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetBlockCommit(ctx context.Context, in *ReqBlockCommit, opts ...grpc.CallOption) (*RespBlockCommit, error) {
+	out := new(RespBlockCommit)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetBlockCommit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetTxProof(ctx context.Context, in *ReqTxProof, opts ...grpc.CallOption) (*RespTxProof, error) {
+	out := new(RespTxProof)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetTxProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) GetAccountProof(ctx context.Context, in *ReqAccountProof, opts ...grpc.CallOption) (*RespAccountProof, error) {
+	out := new(RespAccountProof)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetAccountProof", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SendTransaction(ctx context.Context, in *ReqTransaction, opts ...grpc.CallOption) (*ResTransaction, error) {
+	out := new(ResTransaction)
+	err := c.cc.Invoke(ctx, "/message.Greeter/SendTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SendTransactions(ctx context.Context, in *ReqTransactions, opts ...grpc.CallOption) (*RespTransactions, error) {
+	out := new(RespTransactions)
+	err := c.cc.Invoke(ctx, "/message.Greeter/SendTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SendSignedTransaction(ctx context.Context, in *ReqSignedTransaction, opts ...grpc.CallOption) (*RespSignedTransaction, error) {
+	out := new(RespSignedTransaction)
+	err := c.cc.Invoke(ctx, "/message.Greeter/SendSignedTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SendSignedTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error) {
+	out := new(RespSignedTransactions)
+	err := c.cc.Invoke(ctx, "/message.Greeter/SendSignedTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SendFreezeTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error) {
+	out := new(RespSignedTransactions)
+	err := c.cc.Invoke(ctx, "/message.Greeter/SendFreezeTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SendUnfreezeTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error) {
+	out := new(RespSignedTransactions)
+	err := c.cc.Invoke(ctx, "/message.Greeter/SendUnfreezeTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *greeterClient) SendValidatorTransaction(ctx context.Context, in *ReqValidatorTransaction, opts ...grpc.CallOption) (*RespSignedTransaction, error) {
+	out := new(RespSignedTransaction)
+	err := c.cc.Invoke(ctx, "/message.Greeter/SendValidatorTransaction", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *greeterClient) SendTransactions(ctx context.Context, in *ReqTransactions, opts ...grpc.CallOption) (*RespTransactions, error) {
-	out := new(RespTransactions)
-	err := c.cc.Invoke(ctx, "/message.Greeter/SendTransactions", in, out, opts...)
+func (c *greeterClient) GetValidators(ctx context.Context, in *ReqValidators, opts ...grpc.CallOption) (*RespValidators, error) {
+	out := new(RespValidators)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetValidators", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *greeterClient) SendSignedTransaction(ctx context.Context, in *ReqSignedTransaction, opts ...grpc.CallOption) (*RespSignedTransaction, error) {
-	out := new(RespSignedTransaction)
-	err := c.cc.Invoke(ctx, "/message.Greeter/SendSignedTransaction", in, out, opts...)
+func (c *greeterClient) GetProposerSchedule(ctx context.Context, in *ReqProposerSchedule, opts ...grpc.CallOption) (*RespProposerSchedule, error) {
+	out := new(RespProposerSchedule)
+	err := c.cc.Invoke(ctx, "/message.Greeter/GetProposerSchedule", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *greeterClient) SendSignedTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error) {
-	out := new(RespSignedTransactions)
-	err := c.cc.Invoke(ctx, "/message.Greeter/SendSignedTransactions", in, out, opts...)
+func (c *greeterClient) CancelTransaction(ctx context.Context, in *ReqCancelTransaction, opts ...grpc.CallOption) (*RespCancelTransaction, error) {
+	out := new(RespCancelTransaction)
+	err := c.cc.Invoke(ctx, "/message.Greeter/CancelTransaction", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *greeterClient) SendFreezeTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error) {
-	out := new(RespSignedTransactions)
-	err := c.cc.Invoke(ctx, "/message.Greeter/SendFreezeTransactions", in, out, opts...)
+func (c *greeterClient) SubscribePoolEvents(ctx context.Context, in *ReqPoolEvents, opts ...grpc.CallOption) (Greeter_SubscribePoolEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Greeter_serviceDesc.Streams[0], "/message.Greeter/SubscribePoolEvents", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &greeterSubscribePoolEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *greeterClient) SendUnfreezeTransactions(ctx context.Context, in *ReqSignedTransactions, opts ...grpc.CallOption) (*RespSignedTransactions, error) {
-	out := new(RespSignedTransactions)
-	err := c.cc.Invoke(ctx, "/message.Greeter/SendUnfreezeTransactions", in, out, opts...)
-	if err != nil {
+type Greeter_SubscribePoolEventsClient interface {
+	Recv() (*PoolEvent, error)
+	grpc.ClientStream
+}
+
+type greeterSubscribePoolEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *greeterSubscribePoolEventsClient) Recv() (*PoolEvent, error) {
+	m := new(PoolEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
 func (c *greeterClient) SendToken(ctx context.Context, in *ReqTokenTransaction, opts ...grpc.CallOption) (*RespTokenTransaction, error) {
@@ -3139,14 +5220,37 @@ type GreeterServer interface {
 	GetTxsByAddr(context.Context, *ReqTx) (*ResposeTxs, error)
 	GetTxByHash(context.Context, *ReqTxByHash) (*RespTxByHash, error)
 	GetMaxBlockNumber(context.Context, *ReqMaxBlockNumber) (*RespMaxBlockNumber, error)
+	//获取链ID，签名交易时需要
+	GetChainID(context.Context, *ReqChainId) (*RespChainId, error)
+	GetEmissionSchedule(context.Context, *ReqEmissionSchedule) (*RespEmissionSchedule, error)
+	//发行总量、锁仓总量、资金池总量和流通量
+	GetSupply(context.Context, *ReqSupply) (*RespSupply, error)
 	GetBlockByNum(context.Context, *ReqBlockByNumber) (*RespBlock, error)
 	GetBlockByHash(context.Context, *ReqBlockByHash) (*RespBlock, error)
+	GetBlockHeaderByNum(context.Context, *ReqBlockByNumber) (*BlockHeader, error)
+	GetHeaders(context.Context, *ReqHeaders) (*RespHeaders, error)
+	//批量获取序列化的块和提交证书，供落后的节点同步
+	GetBlocks(context.Context, *ReqBlocks) (*RespBlocks, error)
+	//获取块的提交证书
+	GetBlockCommit(context.Context, *ReqBlockCommit) (*RespBlockCommit, error)
+	GetTxProof(context.Context, *ReqTxProof) (*RespTxProof, error)
+	//获取账户在最新状态树中的证明，可用块头的状态根验证
+	GetAccountProof(context.Context, *ReqAccountProof) (*RespAccountProof, error)
 	SendTransaction(context.Context, *ReqTransaction) (*ResTransaction, error)
 	SendTransactions(context.Context, *ReqTransactions) (*RespTransactions, error)
 	SendSignedTransaction(context.Context, *ReqSignedTransaction) (*RespSignedTransaction, error)
 	SendSignedTransactions(context.Context, *ReqSignedTransactions) (*RespSignedTransactions, error)
 	SendFreezeTransactions(context.Context, *ReqSignedTransactions) (*RespSignedTransactions, error)
 	SendUnfreezeTransactions(context.Context, *ReqSignedTransactions) (*RespSignedTransactions, error)
+	//添加或删除验证者，上链后共识层按链上的验证者集合重新配置
+	SendValidatorTransaction(context.Context, *ReqValidatorTransaction) (*RespSignedTransaction, error)
+	GetValidators(context.Context, *ReqValidators) (*RespValidators, error)
+	//从下一个块开始的计划出块者
+	GetProposerSchedule(context.Context, *ReqProposerSchedule) (*RespProposerSchedule, error)
+	//用手续费更高的0金额自转账替换交易池中nonce相同的交易
+	CancelTransaction(context.Context, *ReqCancelTransaction) (*RespCancelTransaction, error)
+	//订阅交易池的加入、替换和取消事件
+	SubscribePoolEvents(*ReqPoolEvents, Greeter_SubscribePoolEventsServer) error
 	SendToken(context.Context, *ReqTokenTransaction) (*RespTokenTransaction, error)
 	SendSignedToken(context.Context, *ReqTokenTransactions) (*RespSignedTransactions, error)
 	CreateAddr(context.Context, *ReqCreateAddr) (*RespCreateAddr, error)
@@ -3198,12 +5302,39 @@ func (*UnimplementedGreeterServer) GetTxByHash(ctx context.Context, req *ReqTxBy
 func (*UnimplementedGreeterServer) GetMaxBlockNumber(ctx context.Context, req *ReqMaxBlockNumber) (*RespMaxBlockNumber, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMaxBlockNumber not implemented")
 }
+func (*UnimplementedGreeterServer) GetChainID(ctx context.Context, req *ReqChainId) (*RespChainId, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChainID not implemented")
+}
+func (*UnimplementedGreeterServer) GetEmissionSchedule(ctx context.Context, req *ReqEmissionSchedule) (*RespEmissionSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmissionSchedule not implemented")
+}
+func (*UnimplementedGreeterServer) GetSupply(ctx context.Context, req *ReqSupply) (*RespSupply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupply not implemented")
+}
 func (*UnimplementedGreeterServer) GetBlockByNum(ctx context.Context, req *ReqBlockByNumber) (*RespBlock, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByNum not implemented")
 }
 func (*UnimplementedGreeterServer) GetBlockByHash(ctx context.Context, req *ReqBlockByHash) (*RespBlock, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlockByHash not implemented")
 }
+func (*UnimplementedGreeterServer) GetBlockHeaderByNum(ctx context.Context, req *ReqBlockByNumber) (*BlockHeader, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockHeaderByNum not implemented")
+}
+func (*UnimplementedGreeterServer) GetHeaders(ctx context.Context, req *ReqHeaders) (*RespHeaders, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetHeaders not implemented")
+}
+func (*UnimplementedGreeterServer) GetBlocks(ctx context.Context, req *ReqBlocks) (*RespBlocks, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlocks not implemented")
+}
+func (*UnimplementedGreeterServer) GetBlockCommit(ctx context.Context, req *ReqBlockCommit) (*RespBlockCommit, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockCommit not implemented")
+}
+func (*UnimplementedGreeterServer) GetTxProof(ctx context.Context, req *ReqTxProof) (*RespTxProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxProof not implemented")
+}
+func (*UnimplementedGreeterServer) GetAccountProof(ctx context.Context, req *ReqAccountProof) (*RespAccountProof, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountProof not implemented")
+}
 func (*UnimplementedGreeterServer) SendTransaction(ctx context.Context, req *ReqTransaction) (*ResTransaction, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
 }
@@ -3222,6 +5353,21 @@ func (*UnimplementedGreeterServer) SendFreezeTransactions(ctx context.Context, r
 func (*UnimplementedGreeterServer) SendUnfreezeTransactions(ctx context.Context, req *ReqSignedTransactions) (*RespSignedTransactions, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendUnfreezeTransactions not implemented")
 }
+func (*UnimplementedGreeterServer) SendValidatorTransaction(ctx context.Context, req *ReqValidatorTransaction) (*RespSignedTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendValidatorTransaction not implemented")
+}
+func (*UnimplementedGreeterServer) GetValidators(ctx context.Context, req *ReqValidators) (*RespValidators, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetValidators not implemented")
+}
+func (*UnimplementedGreeterServer) GetProposerSchedule(ctx context.Context, req *ReqProposerSchedule) (*RespProposerSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProposerSchedule not implemented")
+}
+func (*UnimplementedGreeterServer) CancelTransaction(ctx context.Context, req *ReqCancelTransaction) (*RespCancelTransaction, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelTransaction not implemented")
+}
+func (*UnimplementedGreeterServer) SubscribePoolEvents(req *ReqPoolEvents, srv Greeter_SubscribePoolEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribePoolEvents not implemented")
+}
 func (*UnimplementedGreeterServer) SendToken(ctx context.Context, req *ReqTokenTransaction) (*RespTokenTransaction, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendToken not implemented")
 }
@@ -3425,6 +5571,60 @@ func _Greeter_GetMaxBlockNumber_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_GetChainID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqChainId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetChainID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetChainID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetChainID(ctx, req.(*ReqChainId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetEmissionSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqEmissionSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetEmissionSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetEmissionSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetEmissionSchedule(ctx, req.(*ReqEmissionSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetSupply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqSupply)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetSupply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetSupply",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetSupply(ctx, req.(*ReqSupply))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Greeter_GetBlockByNum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReqBlockByNumber)
 	if err := dec(in); err != nil {
@@ -3461,6 +5661,114 @@ func _Greeter_GetBlockByHash_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_GetBlockHeaderByNum_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqBlockByNumber)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetBlockHeaderByNum(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetBlockHeaderByNum",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetBlockHeaderByNum(ctx, req.(*ReqBlockByNumber))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetHeaders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqHeaders)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetHeaders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetHeaders",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetHeaders(ctx, req.(*ReqHeaders))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqBlocks)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetBlocks(ctx, req.(*ReqBlocks))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetBlockCommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqBlockCommit)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetBlockCommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetBlockCommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetBlockCommit(ctx, req.(*ReqBlockCommit))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetTxProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqTxProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetTxProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetTxProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetTxProof(ctx, req.(*ReqTxProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetAccountProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqAccountProof)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetAccountProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetAccountProof",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetAccountProof(ctx, req.(*ReqAccountProof))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Greeter_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReqTransaction)
 	if err := dec(in); err != nil {
@@ -3569,6 +5877,99 @@ func _Greeter_SendUnfreezeTransactions_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Greeter_SendValidatorTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqValidatorTransaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).SendValidatorTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/SendValidatorTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).SendValidatorTransaction(ctx, req.(*ReqValidatorTransaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetValidators_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqValidators)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetValidators(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetValidators",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetValidators(ctx, req.(*ReqValidators))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_GetProposerSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqProposerSchedule)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).GetProposerSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/GetProposerSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).GetProposerSchedule(ctx, req.(*ReqProposerSchedule))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_CancelTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqCancelTransaction)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GreeterServer).CancelTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/message.Greeter/CancelTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GreeterServer).CancelTransaction(ctx, req.(*ReqCancelTransaction))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Greeter_SubscribePoolEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReqPoolEvents)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GreeterServer).SubscribePoolEvents(m, &greeterSubscribePoolEventsServer{stream})
+}
+
+type Greeter_SubscribePoolEventsServer interface {
+	Send(*PoolEvent) error
+	grpc.ServerStream
+}
+
+type greeterSubscribePoolEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *greeterSubscribePoolEventsServer) Send(m *PoolEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Greeter_SendToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReqTokenTransaction)
 	if err := dec(in); err != nil {
@@ -3825,6 +6226,18 @@ var _Greeter_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetMaxBlockNumber",
 			Handler:    _Greeter_GetMaxBlockNumber_Handler,
 		},
+		{
+			MethodName: "GetChainID",
+			Handler:    _Greeter_GetChainID_Handler,
+		},
+		{
+			MethodName: "GetEmissionSchedule",
+			Handler:    _Greeter_GetEmissionSchedule_Handler,
+		},
+		{
+			MethodName: "GetSupply",
+			Handler:    _Greeter_GetSupply_Handler,
+		},
 		{
 			MethodName: "GetBlockByNum",
 			Handler:    _Greeter_GetBlockByNum_Handler,
@@ -3833,6 +6246,30 @@ var _Greeter_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlockByHash",
 			Handler:    _Greeter_GetBlockByHash_Handler,
 		},
+		{
+			MethodName: "GetBlockHeaderByNum",
+			Handler:    _Greeter_GetBlockHeaderByNum_Handler,
+		},
+		{
+			MethodName: "GetHeaders",
+			Handler:    _Greeter_GetHeaders_Handler,
+		},
+		{
+			MethodName: "GetBlocks",
+			Handler:    _Greeter_GetBlocks_Handler,
+		},
+		{
+			MethodName: "GetBlockCommit",
+			Handler:    _Greeter_GetBlockCommit_Handler,
+		},
+		{
+			MethodName: "GetTxProof",
+			Handler:    _Greeter_GetTxProof_Handler,
+		},
+		{
+			MethodName: "GetAccountProof",
+			Handler:    _Greeter_GetAccountProof_Handler,
+		},
 		{
 			MethodName: "SendTransaction",
 			Handler:    _Greeter_SendTransaction_Handler,
@@ -3857,6 +6294,22 @@ var _Greeter_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SendUnfreezeTransactions",
 			Handler:    _Greeter_SendUnfreezeTransactions_Handler,
 		},
+		{
+			MethodName: "SendValidatorTransaction",
+			Handler:    _Greeter_SendValidatorTransaction_Handler,
+		},
+		{
+			MethodName: "GetValidators",
+			Handler:    _Greeter_GetValidators_Handler,
+		},
+		{
+			MethodName: "GetProposerSchedule",
+			Handler:    _Greeter_GetProposerSchedule_Handler,
+		},
+		{
+			MethodName: "CancelTransaction",
+			Handler:    _Greeter_CancelTransaction_Handler,
+		},
 		{
 			MethodName: "SendToken",
 			Handler:    _Greeter_SendToken_Handler,
@@ -3906,6 +6359,12 @@ var _Greeter_serviceDesc = grpc.ServiceDesc{
 			Handler:    _Greeter_GetTotalKto_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribePoolEvents",
+			Handler:       _Greeter_SubscribePoolEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "message.proto",
 }
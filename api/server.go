@@ -28,6 +28,7 @@ func (s *Server) Run() {
 	s.GET("/balance", s.GetBalanceHandler)
 	s.GET("/transaction", s.GetTransactionHandler)
 	s.GET("/token/demic", s.GetTokenDemicHandler)
+	s.GET("/supply", s.GetSupplyHandler)
 
 	if err := fasthttp.ListenAndServe(s.port, s.Handler); err != nil {
 		logger.Error("failed to listen port", zap.Error(err), zap.String("port", s.port))
@@ -323,3 +324,27 @@ func (s *Server) GetTokenDemicHandler(ctx *fasthttp.RequestCtx) {
 	result.Data = demic
 	return
 }
+
+// GetSupplyHandler 获取发行总量、锁仓总量、资金池总量和流通量
+func (s *Server) GetSupplyHandler(ctx *fasthttp.RequestCtx) {
+	ctx.Response.Header.Set("Access-Control-Allow-Origin", "*")
+	ctx.Response.Header.Set("Content-Type", "application/json")
+	var result resultInfo
+	defer func() {
+		jsbyte, _ := json.Marshal(result)
+		ctx.Write(jsbyte)
+	}()
+
+	supply, err := blockChian.GetSupply()
+	if err != nil {
+		logger.Error("GetSupply", zap.Error(err))
+		result.Code = failedCode
+		result.Message = "内部错误"
+		return
+	}
+
+	result.Code = successCode
+	result.Message = OK
+	result.Data = supply
+	return
+}
@@ -35,6 +35,7 @@ type Block struct {
 	Root          string        `json:"root"`
 	Timestamp     int64         `json:"timestamp"`
 	Miner         string        `json:"miner"`
+	StateRoot     string        `json:"stateroot"`
 	Txs           []Transaction `json:"txs"`
 }
 
@@ -86,6 +87,7 @@ func changeBlock(b *block.Block) (result Block) {
 	result.Timestamp = b.Timestamp
 	result.Version = b.Version
 	result.Miner = b.Miner.String()
+	result.StateRoot = hex.EncodeToString(b.StateRoot)
 
 	for _, tx := range b.Transactions {
 		result.Txs = append(result.Txs, changeTransaction(tx))
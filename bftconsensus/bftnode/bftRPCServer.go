@@ -1,16 +1,27 @@
 package bftnode
 
 import (
+	"crypto/ed25519"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"kortho/logger"
+	"kortho/transaction"
+	"kortho/types"
+	"kortho/util/miscellaneous"
 	"net"
 	"net/http"
 	"net/rpc"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
+	"golang.org/x/crypto/sha3"
 )
 
+//a signed peer request is only accepted within this many seconds of its time.
+const peerRequestLifetime = 60
+
 /*
 	// client, err := rpc.DialHTTP("tcp", leaderaddr)
 	// if err != nil {
@@ -31,7 +42,7 @@ import (
 
 //RunRPCServer Register a rpc for follows to request to recover blocks data.
 func RunRPCServer(n *bftnode) error {
-	rm := RequestManage{bn: n}
+	rm := RequestManage{bn: n, used: make(map[string]int64)}
 	err := rpc.Register(&rm)
 	if err != nil {
 		return fmt.Errorf("Register rpc error:%v", err)
@@ -51,20 +62,76 @@ func RunRPCServer(n *bftnode) error {
 	return nil
 }
 
-//HandleAddPeer leader add node
+//PeerRequestHash is the hash of a peer request signed by admin.
+func PeerRequestHash(method, addr string, time int64, nonce uint64) []byte {
+	h := sha3.New256()
+	h.Write([]byte(method))
+	h.Write([]byte(addr))
+	h.Write(miscellaneous.E64func(uint64(time)))
+	h.Write(miscellaneous.E64func(nonce))
+	return h.Sum(nil)
+}
+
+//check the peer request is signed by admin recently and not accepted before.
+func (rm *RequestManage) checkPeerRequest(method string, req ReqBlockrpc) error {
+	if len(req.Addr) == 0 {
+		return fmt.Errorf("%s error:req.addr = %v", method, req.Addr)
+	}
+	now := time.Now().Unix()
+	if req.Time < now-peerRequestLifetime || req.Time > now+peerRequestLifetime {
+		return fmt.Errorf("%s error:request time %v expired", method, req.Time)
+	}
+	admin, err := types.StringToAddress(transaction.AdminAddr)
+	if err != nil || len(transaction.AdminAddr) != types.AddressSize || !admin.Verify() {
+		return fmt.Errorf("%s error:no admin address", method)
+	}
+	hash := PeerRequestHash(method, req.Addr, req.Time, req.Nonce)
+	if !ed25519.Verify(admin.ToPublicKey(), hash, req.Sig) {
+		return fmt.Errorf("%s error:bad signature", method)
+	}
+
+	//an expired request is rejected by its time,so only the requests within the lifetime are remembered.
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	for k, t := range rm.used {
+		if t < now-peerRequestLifetime {
+			delete(rm.used, k)
+		}
+	}
+	if _, ok := rm.used[string(hash)]; ok {
+		return fmt.Errorf("%s error:request replayed", method)
+	}
+	rm.used[string(hash)] = req.Time
+	return nil
+}
+
+//HandleAddPeer leader adds back a validator which was removed by HandleRemovePeer,the request must be signed by admin.
 func (rm *RequestManage) HandleAddPeer(req ReqBlockrpc, res *ReSBlockrpc) error {
-	if len(req.Addr) > 0 {
+	if err := rm.checkPeerRequest("RequestManage.HandleAddPeer", req); err != nil {
+		return err
+	}
+	//only validators in the committed validator set can join the cluster.
+	vals, err := rm.bn.bc.GetValidators()
+	if err != nil {
+		return fmt.Errorf("HandleAddPeer error:%v", err)
+	}
+	if len(vals) == 0 {
 		return rm.bn.Add(req.Addr)
 	}
-	return fmt.Errorf("HandleAddPeer error:req.addr = %v", req.Addr)
+	for _, v := range vals {
+		if v[strings.LastIndex(v, "@")+1:] == req.Addr {
+			return rm.bn.Add(req.Addr)
+		}
+	}
+	return errors.New("HandleAddPeer error:" + req.Addr + " is not a validator")
 }
 
-//HandleRemovePeer leader remove node
+//HandleRemovePeer leader removes a node temporarily,the request must be signed by admin.
 func (rm *RequestManage) HandleRemovePeer(req ReqBlockrpc, res *ReSBlockrpc) error {
-	if len(req.Addr) > 0 {
-		return rm.bn.Remove(req.Addr)
+	if err := rm.checkPeerRequest("RequestManage.HandleRemovePeer", req); err != nil {
+		return err
 	}
-	return fmt.Errorf("HandleRemovePeert error:req.addr = %v", req.Addr)
+	return rm.bn.Remove(req.Addr)
 }
 
 //HandleGetLeader Get Leader
@@ -86,6 +153,13 @@ func (rm *RequestManage) HandleGetBlockSection(req ReqBlockrpc, res *ReSBlockrpc
 		return fmt.Errorf("HandleGetBlockSection json Marshal failed:%v", err)
 	}
 	res.Data = d
+	for _, b := range blocks {
+		var commit []byte
+		if c, err := rm.bn.bc.GetBlockCommit(b.Height); err == nil {
+			commit = c.Serialize()
+		}
+		res.Commits = append(res.Commits, commit)
+	}
 	return nil
 }
 
@@ -0,0 +1,43 @@
+package bftnode
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"kortho/transaction"
+	"kortho/types"
+	"testing"
+	"time"
+)
+
+//a signed peer request is accepted once,the same request sent again within its lifetime is rejected.
+func TestPeerRequestReplay(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	transaction.InitAdmin(types.PublicKeyToAddress(pub))
+	defer transaction.InitAdmin("")
+
+	const method = "RequestManage.HandleRemovePeer"
+	sign := func(nonce uint64) ReqBlockrpc {
+		req := ReqBlockrpc{Addr: "127.0.0.1:9500", Time: time.Now().Unix(), Nonce: nonce}
+		req.Sig = ed25519.Sign(priv, PeerRequestHash(method, req.Addr, req.Time, req.Nonce))
+		return req
+	}
+	rm := &RequestManage{used: make(map[string]int64)}
+	req := sign(1)
+	if err := rm.checkPeerRequest(method, req); err != nil {
+		t.Fatal(err)
+	}
+	if err := rm.checkPeerRequest(method, req); err == nil {
+		t.Fatal("replayed request accepted")
+	}
+	if err := rm.checkPeerRequest(method, sign(2)); err != nil {
+		t.Fatal(err)
+	}
+
+	//the requests out of the lifetime are forgotten.
+	for k := range rm.used {
+		rm.used[k] = time.Now().Unix() - peerRequestLifetime - 1
+	}
+	if err := rm.checkPeerRequest(method, sign(3)); err != nil || len(rm.used) != 1 {
+		t.Fatalf("%v,%d requests remembered", err, len(rm.used))
+	}
+}
@@ -2,10 +2,12 @@
 package bftnode
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"kortho/bftconsensus/blocksync"
 	"kortho/bftconsensus/node"
+	"kortho/bftconsensus/pbft"
+	"kortho/bftconsensus/protocol"
 	"kortho/block"
 	"kortho/blockchain"
 	"kortho/config"
@@ -14,6 +16,7 @@ import (
 	"kortho/txpool"
 	addrtypes "kortho/types"
 	"os"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -22,7 +25,22 @@ import (
 //NewBftNode new a bft node.
 func NewBftNode(cfg *config.BftConfig, bc blockchain.Blockchains, pn p2pnode.Node, pool *txpool.TxPool) (Node, error) {
 	var bn bftnode
+	params, err := bc.GetChainParams()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkConfig(cfg, params); err != nil {
+		return nil, err
+	}
+	vals, err := bc.GetValidators()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkMiner(cfg, params, vals); err != nil {
+		return nil, err
+	}
 	nC := &node.Config{
+		Engine:            cfg.Engine,
 		Join:              cfg.Join,
 		Address:           cfg.NodeAddr,
 		SnapshotThreshold: cfg.SnapshotCount,
@@ -34,8 +52,14 @@ func NewBftNode(cfg *config.BftConfig, bc blockchain.Blockchains, pn p2pnode.Nod
 		NodeNum:           cfg.NodeNum,
 		RPCPort:           cfg.RpcPort,
 		MRpcAddr:          cfg.MRpcAddr,
+		PrivKey:           cfg.PrivKey,
+		Validators:        cfg.Validators,
+		ViewTimeout:       time.Duration(cfg.ViewTimeout) * time.Second,
+		ProposerTerm:      params.ProposerTerm,
+		SyncPeers:         cfg.SyncPeers,
+		SyncBatch:         cfg.SyncBatch,
 	}
-	n, err := node.New(nC, &bn, commit, delive, bc, pool)
+	n, err := node.New(nC, &bn, commit, delive, checkSynced, restore, bc, pool)
 	if err != nil {
 		return nil, err
 	}
@@ -45,6 +69,7 @@ func NewBftNode(cfg *config.BftConfig, bc blockchain.Blockchains, pn p2pnode.Nod
 	bn.cfg = cfg
 	bn.bc = bc
 	bn.pool = pool
+	bn.params = params
 
 	lh, err := bn.bc.GetHeight()
 	if err != nil {
@@ -56,24 +81,65 @@ func NewBftNode(cfg *config.BftConfig, bc blockchain.Blockchains, pn p2pnode.Nod
 	return &bn, nil
 }
 
-//run a bft node.This function packages a new block per second.
+//check the empty block config against the block interval in genesis and set the defaults.
+func checkConfig(cfg *config.BftConfig, params *blockchain.ChainParams) error {
+	if cfg.HeartbeatInterval < 0 {
+		return errors.New("negative 'heartbeatinterval' in config file")
+	}
+	if cfg.EmptyBlockPolicy == "" {
+		cfg.EmptyBlockPolicy = EmptyBlockAlways
+	}
+
+	//the longest time between two blocks when the chain is idle.
+	idle := params.BlockInterval
+	switch cfg.EmptyBlockPolicy {
+	case EmptyBlockAlways:
+	case EmptyBlockSkip:
+		idle = 0
+	case EmptyBlockHeartbeat:
+		if cfg.HeartbeatInterval < params.BlockInterval {
+			return errors.New("'heartbeatinterval' in config file is less than the block interval in genesis")
+		}
+		idle = cfg.HeartbeatInterval
+	default:
+		return fmt.Errorf("unknown 'emptyblockpolicy' %q in config file", cfg.EmptyBlockPolicy)
+	}
+
+	//pbft validators change view when no block is committed within the view timeout.
+	if cfg.Engine == protocol.EnginePBFT {
+		//synced blocks are only checked against commit certificates from 'certheight' on.
+		if params.CertHeight == 0 {
+			return errors.New("pbft needs 'certheight' in genesis params")
+		}
+		timeout := time.Duration(cfg.ViewTimeout) * time.Second
+		if timeout <= 0 {
+			timeout = pbft.DefaultViewTimeout
+		}
+		if idle == 0 || time.Duration(idle)*time.Second >= timeout {
+			return errors.New("pbft needs a block within 'viewtimeout',use 'always' or 'heartbeat' with a shorter interval")
+		}
+	}
+	return nil
+}
+
+//run a bft node.This function packages a new block per block interval.
 func (n *bftnode) Run() {
 	logger.Info("Run bftnode...")
-	//Only leader can do this when first time to start a cluster.
-	if n.cfg.Join {
+	//The raft leader makes the cluster match the committed validator set,
+	//pbft validators change the set while committing the block.
+	if n.cfg.Engine != protocol.EnginePBFT {
 		go func() {
+			var leading bool
 			for {
-				if leader := n.Bn.GetLeader(); leader == n.cfg.NodeAddr {
-					for _, peer := range n.cfg.Peers {
-						logger.Info("leader add node", zap.String("node addr", peer))
-						if err := n.Add(peer); err != nil {
-							logger.Error("Add bft nodes failed! Please check 'peers' in config file,then restart.", zap.String("peer", peer))
-							os.Exit(1)
-						}
-					}
-					break
-				}
 				time.Sleep(time.Millisecond * 10)
+				if leader := n.Bn.GetLeader(); leader != n.cfg.NodeAddr {
+					leading = false
+					continue
+				}
+				if !leading {
+					leading = true
+					n.syncValidators()
+				}
 			}
 		}()
 	}
@@ -87,10 +153,26 @@ func (n *bftnode) Run() {
 
 	time.Sleep(time.Second * 2)
 
+	interval := time.Duration(n.params.BlockInterval) * time.Second
 	for {
-		time.Sleep(time.Second)
+		time.Sleep(interval)
 		if leader := n.Bn.GetLeader(); leader == n.cfg.NodeAddr {
-			txs := n.pool.Pending(n.bc)
+			if n.transfer() {
+				continue
+			}
+			parent, err := n.parentTime(n.lastHeight + 1)
+			if err != nil {
+				logger.Error("Leader: get parent block failed,do it again.", zap.Uint64("height", n.lastHeight), zap.Error(err))
+				continue
+			}
+			//the timestamp of the new block must be one block interval after the parent.
+			if wait := time.Until(time.Unix(parent+n.params.BlockInterval, 0)); wait > 0 {
+				time.Sleep(wait)
+			}
+			txs := n.pool.PendingN(n.bc, int(n.params.MaxBlockTxs))
+			if len(txs) == 0 && !n.emptyBlock(parent) {
+				continue
+			}
 			minerAddr, _ := addrtypes.BytesToAddress([]byte(n.cfg.CountAddr))
 			dsAddr, _ := addrtypes.BytesToAddress([]byte(n.cfg.Ds))
 			cmAddr, _ := addrtypes.BytesToAddress([]byte(n.cfg.Cm))
@@ -113,16 +195,7 @@ func (n *bftnode) Run() {
 
 			fmt.Println("pack info:", "Height", b.Height, "res hash", resultHash)
 
-			blockData := DataStu{
-				Block:      b,
-				ResultHash: resultHash,
-			}
-
-			pb, err := json.Marshal(blockData)
-			if err != nil {
-				logger.Error("Marshal packaged block error!", zap.Error(err))
-				continue
-			}
+			pb := node.EncodeBlockData(b, resultHash)
 			logger.Info("Prepare new block :", zap.Uint64("height", b.Height))
 			if err := n.Bn.Prepare(pb); err != nil {
 				logger.Error("error: Leader Prepare new block failed!", zap.Uint64("height", b.Height), zap.Error(err))
@@ -132,11 +205,95 @@ func (n *bftnode) Run() {
 	}
 }
 
-//DataStu add ResultHash
-type DataStu struct {
-	//TODO：处理数据为nil时，引起panic的bug
-	Block      *block.Block `json:"block"`
-	ResultHash []byte       `json:"resulthash"`
+//The raft leader hands over the leadership to the scheduled proposer of the next block in the committed validator set,
+//it does not propose the block itself since the block of another miner is rejected by checkBlock.
+//It returns false when this node is the scheduled proposer or the proposers do not rotate.
+//The pbft primary rotates in the engine.
+func (n *bftnode) transfer() bool {
+	if n.cfg.Engine == protocol.EnginePBFT {
+		return false
+	}
+	height := n.lastHeight + 1
+	proposer, err := n.bc.GetProposer(height)
+	if err != nil {
+		logger.Error("GetProposer error", zap.Uint64("height", height), zap.Error(err))
+		return true
+	}
+	addr := proposer[strings.LastIndex(proposer, "@")+1:]
+	if proposer == "" || addr == n.cfg.NodeAddr {
+		return false
+	}
+	logger.Info("leader transfer to the scheduled proposer", zap.Uint64("height", height), zap.String("proposer", proposer))
+	if err := n.Bn.LeaderShipTransferTo(addr); err != nil {
+		logger.Error("LeaderShipTransferTo error,try again", zap.String("proposer", proposer), zap.Error(err))
+	}
+	return true
+}
+
+//with proposer rotation the miner of a block must be the scheduled validator,
+//so this node must mine to its validator address.
+func checkMiner(cfg *config.BftConfig, params *blockchain.ChainParams, vals []string) error {
+	if params.ProposerTerm == 0 {
+		return nil
+	}
+	for _, v := range vals {
+		if i := strings.LastIndex(v, "@"); v[i+1:] == cfg.NodeAddr && v[:i] != cfg.CountAddr {
+			return fmt.Errorf("'countaddr' in config file must be the validator address %s when proposers rotate", v[:i])
+		}
+	}
+	return nil
+}
+
+//timestamp of the parent of the block at height,
+//0 for the first block of a chain without genesis block.
+func (n *bftnode) parentTime(height uint64) (int64, error) {
+	h, err := n.bc.GetHeaderByHeight(height - 1)
+	if err != nil {
+		if height == 1 {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return h.Timestamp, nil
+}
+
+//propose an empty block or not when there is no transaction.
+func (n *bftnode) emptyBlock(parent int64) bool {
+	switch n.cfg.EmptyBlockPolicy {
+	case EmptyBlockSkip:
+		return false
+	case EmptyBlockHeartbeat:
+		return time.Now().Unix()-parent >= n.cfg.HeartbeatInterval
+	}
+	return true
+}
+
+//reconfigure the cluster with the committed validator set.
+func (n *bftnode) syncValidators() {
+	vals, err := n.bc.GetValidators()
+	if err != nil {
+		logger.Error("GetValidators error", zap.Error(err))
+		return
+	}
+	if len(vals) != 0 {
+		logger.Info("leader sync validators", zap.Strings("validators", vals))
+		if err := n.Bn.SetValidators(vals); err != nil {
+			logger.Error("SetValidators error", zap.Error(err))
+		}
+		return
+	}
+
+	//Only the bootstrap leader adds 'peers' in config file when the chain has no validator set.
+	if !n.cfg.Join {
+		return
+	}
+	for _, peer := range n.cfg.Peers {
+		logger.Info("leader add node", zap.String("node addr", peer))
+		if err := n.Add(peer); err != nil {
+			logger.Error("Add bft nodes failed! Please check 'peers' in config file,then restart.", zap.String("peer", peer))
+			os.Exit(1)
+		}
+	}
 }
 
 //add other nodes into cluster.
@@ -191,17 +348,11 @@ func delive(u interface{}, data []byte) error {
 func checkBlockData(u interface{}, data []byte) ([]byte, uint64, error) {
 	bn := u.(*bftnode)
 
-	var blockData DataStu
-
-	if err := json.Unmarshal(data, &blockData); err != nil {
-		logger.Error("checkBlockData Unmarshal error", zap.Error(err))
+	b, resultHash, err := node.DecodeBlockData(data)
+	if err != nil {
+		logger.Error("checkBlockData DecodeBlockData error", zap.Error(err))
 		return nil, 0, err
 	}
-	b := blockData.Block
-	if b == nil {
-		logger.Error("block is nil")
-		return nil, 0, errors.New("block data is nil")
-	}
 
 	//not an expect block data,so return error here.
 	if b.Height != 1+bn.lastHeight {
@@ -209,13 +360,10 @@ func checkBlockData(u interface{}, data []byte) ([]byte, uint64, error) {
 		return b.Hash, b.Height, errors.New("checkBlockData block error: b.Height != 1+bn.lastHeight")
 	}
 
-	p := bn.pool
-	p.Filter(*b)
-
-	//logger.Info("checkBlockData info", zap.Uint64("Height", b.Height), zap.ByteString("res hash", blockData.ResultHash))
-	fmt.Println("checkBlockData info:", "Height", b.Height, "res hash", blockData.ResultHash)
+	//logger.Info("checkBlockData info", zap.Uint64("Height", b.Height), zap.ByteString("res hash", resultHash))
+	fmt.Println("checkBlockData info:", "Height", b.Height, "res hash", resultHash)
 
-	if !bn.checkBlock(b, blockData.ResultHash) {
+	if !bn.checkBlock(b, resultHash) {
 		logger.Error("Follow checkBlock error!", zap.Uint64("hegiht:", b.Height))
 		return b.Hash, b.Height, errors.New("CheckBlock ERROR")
 	}
@@ -228,31 +376,64 @@ func checkBlockData(u interface{}, data []byte) ([]byte, uint64, error) {
 	return b.Hash, b.Height, nil
 }
 
+//Check a block fetched by the syncer before commit.
+//It runs the checks of checkBlockData,the commit certificate of the validators stands in for the result hash of the leader.
+func checkSynced(u interface{}, b *block.Block, c *block.Commit) error {
+	bn := u.(*bftnode)
+
+	if b.Height != 1+bn.lastHeight {
+		return fmt.Errorf("synced block %d is not next to %d", b.Height, bn.lastHeight)
+	}
+	if err := blocksync.CheckBlock(bn.bc, b, c, []byte(bn.cfg.Ds), []byte(bn.cfg.Cm), []byte(bn.cfg.QTJ)); err != nil {
+		logger.Error("checkSynced block error", zap.Uint64("height", b.Height), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
 //commit the correct block data.
-func commit(u interface{}, data []byte) error {
-	var blockData DataStu
-	err := json.Unmarshal(data, &blockData)
+func commit(u interface{}, data []byte, c *block.Commit) error {
+	b, _, err := node.DecodeBlockData(data)
 	if err != nil {
-		logger.Error("commit Unmarshal error", zap.Error(err))
+		logger.Error("commit DecodeBlockData error", zap.Error(err))
 		return fmt.Errorf("Commit block failed:%v", err)
 	}
-	b := blockData.Block
-	if b == nil {
-		logger.Error("failed to unmarsal block")
-		return errors.New("Commit block failed")
-	}
 
-	err = u.(*bftnode).bc.AddBlock(b, []byte(u.(*bftnode).cfg.CountAddr))
+	err = u.(*bftnode).bc.AddBlock(b, c)
 	if err != nil {
 		logger.Error("Fatal error: commit block failed", zap.Uint64("height", b.Height), zap.Error(err))
 		return fmt.Errorf("Commit block failed:%v", err)
 	}
 	//update last blockHeight
 	u.(*bftnode).lastHeight = b.Height
+	//drop the pool transactions packed by the block only after it is committed,a checked proposal may never be.
+	u.(*bftnode).pool.Filter(*b)
+
+	//the validator set changed by this block takes effect from the next block.
+	for _, tx := range b.Transactions {
+		if tx.IsValidatorTransaction() {
+			vals, err := u.(*bftnode).bc.GetValidators()
+			if err == nil {
+				err = u.(*bftnode).Bn.SetValidators(vals)
+			}
+			if err != nil {
+				logger.Error("failed to change validators", zap.Uint64("height", b.Height), zap.Error(err))
+			}
+			break
+		}
+	}
+
 	logger.Info("Finished commit block", zap.Uint64("height", u.(*bftnode).lastHeight), zap.Int("data lenght", len(data)), zap.Int("tx lenght", len(b.Transactions)))
 	return nil
 }
 
+//This is a callback function after the node rebuilt its state from a raft snapshot.
+func restore(u interface{}, height uint64) error {
+	u.(*bftnode).lastHeight = height
+	logger.Info("Finished restore snapshot", zap.Uint64("height", height))
+	return nil
+}
+
 //check block data.
 func (n *bftnode) checkBlock(b *block.Block, resultHash []byte) bool {
 	if !n.bc.CheckResults(b, resultHash, []byte(n.cfg.Ds), []byte(n.cfg.Cm), []byte(n.cfg.QTJ)) {
@@ -7,11 +7,19 @@ import (
 	"kortho/config"
 	p2pnode "kortho/p2p/node"
 	"kortho/txpool"
+	"sync"
+)
+
+//empty block policies
+const (
+	EmptyBlockAlways    = "always"    //propose a block every block interval
+	EmptyBlockSkip      = "skip"      //propose a block only when there are transactions
+	EmptyBlockHeartbeat = "heartbeat" //propose an empty block after 'heartbeatinterval' seconds without blocks
 )
 
 //Node interface
 type Node interface {
-	//run a bft node and package a new block per second.
+	//run a bft node and package a new block per block interval.
 	Run()
 	//add node into cluster.
 	Add(string) error
@@ -19,17 +27,20 @@ type Node interface {
 }
 
 type bftnode struct {
-	Bn         node.Node              //bft node
-	lastHeight uint64                 //The latest height.
-	cfg        *config.BftConfig      //bft config
-	pn         p2pnode.Node           //p2p node
-	bc         blockchain.Blockchains //blockchain
-	pool       *txpool.TxPool         //txpool
+	Bn         node.Node               //bft node
+	lastHeight uint64                  //The latest height.
+	cfg        *config.BftConfig       //bft config
+	pn         p2pnode.Node            //p2p node
+	bc         blockchain.Blockchains  //blockchain
+	pool       *txpool.TxPool          //txpool
+	params     *blockchain.ChainParams //block production rules in genesis
 }
 
 //RequestManage struct
 type RequestManage struct {
-	bn *bftnode //bft node
+	bn   *bftnode         //bft node
+	mu   sync.Mutex       //guards used
+	used map[string]int64 //hashes of the accepted peer requests and their time,kept within peerRequestLifetime
 }
 
 //ReqNodeOption request
@@ -46,11 +57,15 @@ type ReqBlockrpc struct {
 	ReqBlocks    bool   //request leader blocks from height 'LowH' to 'HeiH'
 	LowH         uint64 //form LowH
 	HeiH         uint64 //to HeiH
+	Time         int64  //peer request time
+	Nonce        uint64 //random number of a peer request,a signed request is accepted only once
+	Sig          []byte //admin signature of PeerRequestHash
 }
 
 //ReSBlockrpc result info
 type ReSBlockrpc struct {
-	Data       []byte //blocks data
+	Data       []byte   //blocks data
+	Commits    [][]byte //serialized commit certificates of the blocks,nil for a block without one
 	LeaderAddr string
 	MaxHieght  uint64 //leader max block height
 }
@@ -0,0 +1,243 @@
+//Package blocksync catches up with other nodes by fetching batched block ranges outside of consensus.
+package blocksync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"kortho/block"
+	"kortho/blockchain"
+	"kortho/logger"
+	"kortho/txpool"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+//DefaultBatch is the number of blocks requested at a time.
+const DefaultBatch = 500
+
+//Peer provides blocks to sync from.
+type Peer interface {
+	//peer name for logs
+	String() string
+	//latest height of the peer
+	Height() (uint64, error)
+	//blocks and their commit certificates from height 'from' to 'to',a commit is nil if the peer has none.
+	//The peer may return less blocks than requested.
+	Blocks(from, to uint64) ([]*block.Block, []*block.Commit, error)
+}
+
+//Chain is the local blockchain.
+type Chain interface {
+	GetHeight() (uint64, error)
+	GetHash(uint64) ([]byte, error)
+}
+
+//CommitFunc commits a verified block on top of the local chain.
+type CommitFunc func(*block.Block, *block.Commit) error
+
+//Progress of the running or the last sync.
+type Progress struct {
+	Syncing bool   //a sync is running
+	Start   uint64 //local height when the sync started
+	Current uint64 //local height
+	Target  uint64 //highest height of the peers
+	Peer    string //peer syncing from
+}
+
+//Syncer fetches blocks from peers until the local chain reaches the highest peer.
+type Syncer struct {
+	chain   Chain
+	peers   func() []Peer
+	commit  CommitFunc
+	batch   uint64
+	trigger chan struct{}
+
+	mu       sync.RWMutex
+	progress Progress
+}
+
+//New new a syncer,peers returns the peers to sync from,batch is the number of blocks per request.
+func New(chain Chain, peers func() []Peer, commit CommitFunc, batch uint64) *Syncer {
+	if batch == 0 {
+		batch = DefaultBatch
+	}
+	return &Syncer{
+		chain:   chain,
+		peers:   peers,
+		commit:  commit,
+		batch:   batch,
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+//Run syncs whenever it is triggered until stop is closed.
+func (s *Syncer) Run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-s.trigger:
+			if err := s.Sync(); err != nil {
+				logger.Error("block sync error", zap.Error(err))
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+//Trigger asks Run to sync,it never blocks.
+func (s *Syncer) Trigger() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+//Progress of the running or the last sync.
+func (s *Syncer) Progress() Progress {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.progress
+}
+
+func (s *Syncer) setProgress(f func(*Progress)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f(&s.progress)
+}
+
+//Sync fetches blocks from the highest peers in turn until the local chain reaches the highest height of the peers.
+func (s *Syncer) Sync() error {
+	type peerHeight struct {
+		p Peer
+		h uint64
+	}
+	var peers []peerHeight
+	var target uint64
+	for _, p := range s.peers() {
+		h, err := p.Height()
+		if err != nil {
+			logger.Info("block sync peer height error", zap.String("peer", p.String()), zap.Error(err))
+			continue
+		}
+		peers = append(peers, peerHeight{p, h})
+		if h > target {
+			target = h
+		}
+	}
+
+	start, err := s.chain.GetHeight()
+	if err != nil {
+		return err
+	}
+	if start >= target {
+		return nil
+	}
+	logger.Info("block sync start", zap.Uint64("height", start), zap.Uint64("target", target), zap.Int("peers", len(peers)))
+	s.setProgress(func(p *Progress) { *p = Progress{Syncing: true, Start: start, Current: start, Target: target} })
+	defer s.setProgress(func(p *Progress) { p.Syncing = false })
+
+	begin := time.Now()
+	var errs []string
+	for len(peers) > 0 {
+		//sync from the highest peer first.
+		best := 0
+		for i := range peers {
+			if peers[i].h > peers[best].h {
+				best = i
+			}
+		}
+		p := peers[best]
+		peers = append(peers[:best], peers[best+1:]...)
+
+		if err := s.syncFrom(p.p, p.h); err != nil {
+			logger.Error("block sync from peer error", zap.String("peer", p.p.String()), zap.Error(err))
+			errs = append(errs, fmt.Sprintf("%s: %v", p.p, err))
+		}
+		if s.Progress().Current >= target {
+			logger.Info("block sync end", zap.Uint64("height", target), zap.Uint64("blocks", target-start), zap.Duration("elapsed", time.Since(begin)))
+			return nil
+		}
+	}
+	return fmt.Errorf("synced to %d of %d: %v", s.Progress().Current, target, errs)
+}
+
+//fetch and commit blocks from a peer up to height 'to'.
+func (s *Syncer) syncFrom(p Peer, to uint64) error {
+	s.setProgress(func(pr *Progress) { pr.Peer = p.String() })
+	for {
+		height, err := s.chain.GetHeight()
+		if err != nil {
+			return err
+		}
+		s.setProgress(func(pr *Progress) { pr.Current = height })
+		if height >= to {
+			return nil
+		}
+		//the first block of a chain without genesis block has nothing to link to.
+		prev, err := s.chain.GetHash(height)
+		if err != nil && height != 0 {
+			return err
+		}
+
+		hi := height + s.batch
+		if hi > to {
+			hi = to
+		}
+		bs, cs, err := p.Blocks(height+1, hi)
+		if err != nil {
+			return err
+		}
+		if len(bs) == 0 || len(bs) != len(cs) {
+			return errors.New("peer returned no blocks")
+		}
+		if err := Verify(bs, height+1, prev); err != nil {
+			return err
+		}
+		for i, b := range bs {
+			c := cs[i]
+			if c != nil && (c.Height != b.Height || !bytes.Equal(c.Hash, b.Hash)) {
+				c = nil
+			}
+			if err := s.commit(b, c); err != nil {
+				return fmt.Errorf("commit block %d: %v", b.Height, err)
+			}
+		}
+		logger.Info("block sync progress", zap.String("peer", p.String()), zap.Uint64("height", bs[len(bs)-1].Height), zap.Uint64("target", to))
+	}
+}
+
+//Verify checks blocks are consecutive from height 'from',each hash matches its data
+//and links to the previous hash,which is not checked for the first block when prev is nil.
+func Verify(bs []*block.Block, from uint64, prev []byte) error {
+	for i, b := range bs {
+		if b.Height != from+uint64(i) {
+			return fmt.Errorf("block %d at %d,want %d", b.Height, i, from+uint64(i))
+		}
+		if prev != nil && !bytes.Equal(b.PrevHash, prev) {
+			return fmt.Errorf("block %d prev hash %x,want %x", b.Height, b.PrevHash, prev)
+		}
+		if err := b.CheckHash(); err != nil {
+			return err
+		}
+		prev = b.Hash
+	}
+	return nil
+}
+
+//CheckBlock checks a fetched block like a proposal before commit: the commit certificate against the committed validator set,
+//the block rules and the state root of the chain,and the transactions.ds,cm and qtj are the addresses paid by the coinbase transactions.
+func CheckBlock(bc blockchain.Blockchains, b *block.Block, c *block.Commit, ds, cm, qtj []byte) error {
+	if err := bc.VerifyCommit(b, c); err != nil {
+		return err
+	}
+	if err := bc.CheckBlock(b, ds, cm, qtj); err != nil {
+		return err
+	}
+	if !txpool.VerifyBlock(*b, bc) {
+		return fmt.Errorf("block %d has invalid transactions", b.Height)
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package blocksync
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"kortho/block"
+	"kortho/blockchain"
+	"kortho/logger"
+	"kortho/types"
+	"os"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var testAddrs = []string{
+	"KtoC5gP1TLyUWbHRkp1gfpMrbdBawnqxQi3NdYtB31dgtJE",
+	"Kto3PYebE3gTorcqYf59uHc2PcCdoANzgvgmZXa21r559rR",
+	"KtoD6ELKyafRZU9SDMKfDpRZdyjHugsdmTvDXTH1ED2SmBt",
+	"Kto2YGvFKXQtSazWp9hPZyBrA9JPkxgNE6GW56o7jcdQXTq",
+}
+
+type testChain struct {
+	*blockchain.Blockchain
+	dir string
+}
+
+func newTestChain(t *testing.T) *testChain {
+	dir, err := ioutil.TempDir("", "kortho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testChain{blockchain.Open(dir), dir}
+}
+
+func (c *testChain) close() {
+	c.Close()
+	os.RemoveAll(c.dir)
+}
+
+//testPeer serves blocks of a local chain like the grpc api.
+type testPeer struct {
+	name   string
+	c      *testChain
+	max    uint64 //blocks returned at most per request
+	fault  uint64 //height of the block whose data is changed
+	noCert bool   //serve the blocks without commit certificates
+}
+
+func (p *testPeer) String() string { return p.name }
+
+func (p *testPeer) Height() (uint64, error) { return p.c.GetHeight() }
+
+func (p *testPeer) Blocks(from, to uint64) ([]*block.Block, []*block.Commit, error) {
+	if p.max > 0 && to-from+1 > p.max {
+		to = from + p.max - 1
+	}
+	bs, err := p.c.GetBlockSection(from, to)
+	if err != nil {
+		return nil, nil, err
+	}
+	cs := make([]*block.Commit, len(bs))
+	for i, b := range bs {
+		if b.Height == p.fault {
+			b.Transactions[0].Amount++
+		}
+		//blocks without a certificate are served with a nil one like the grpc api.
+		if c, err := p.c.GetBlockCommit(b.Height); err == nil && !p.noCert {
+			cs[i] = c
+		}
+	}
+	return bs, cs, nil
+}
+
+//a stale follower catches up 10k blocks from peers through the checks of a proposal,
+//one peer is down,one sends a changed block and one sends no commit certificates,the follower moves on to the next peer.
+func TestCatchUp(t *testing.T) {
+	if testing.Short() {
+		t.Skip("10k blocks")
+	}
+	logger.Logger = zap.NewNop()
+
+	const n, stale = 10000, 100
+	var addrs []types.Address
+	for _, s := range testAddrs {
+		addr, _ := types.StringToAddress(s)
+		addrs = append(addrs, *addr)
+	}
+	ds, cm, qtj := addrs[1].Bytes(), addrs[2].Bytes(), addrs[3].Bytes()
+
+	//4 validators,3 of them sign each block.
+	var vals []string
+	var keys []ed25519.PrivateKey
+	for i := 0; i < 4; i++ {
+		pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+		vals = append(vals, fmt.Sprintf("%s@127.0.0.1:%d", types.PublicKeyToAddress(pub), 9600+i))
+		keys = append(keys, priv)
+	}
+	params := blockchain.DefaultChainParams()
+	params.CertHeight = 1
+	g := &blockchain.Genesis{Timestamp: time.Now().Unix() - n - 100, Validators: vals, Params: params}
+
+	leader, follower := newTestChain(t), newTestChain(t)
+	defer leader.close()
+	defer follower.close()
+	for _, c := range []*testChain{leader, follower} {
+		if _, err := c.InitGenesis(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for h := uint64(1); h <= n; h++ {
+		b, err := leader.NewBlock(nil, addrs[0], addrs[1], addrs[2], addrs[3])
+		if err != nil {
+			t.Fatal(err)
+		}
+		//one block per second in the past.
+		b.Timestamp = g.Timestamp + int64(h)
+		b.SetHash()
+		c := &block.Commit{Height: b.Height, Hash: b.Hash}
+		for _, key := range keys[1:] {
+			c.Signatures = append(c.Signatures, &block.CommitSig{
+				Validator: types.PublicKeyToAddress(key.Public().(ed25519.PublicKey)),
+				Signature: ed25519.Sign(key, block.CommitSignBytes(b.Height, b.Hash)),
+			})
+		}
+		if err := leader.AddBlock(b, c); err != nil {
+			t.Fatal(err)
+		}
+		if h <= stale {
+			if err := follower.AddBlock(b, c); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	peers := func() []Peer {
+		return []Peer{
+			downPeer{},
+			&testPeer{name: "faulty", c: leader, fault: n / 2},
+			&testPeer{name: "nocert", c: leader, noCert: true},
+			&testPeer{name: "good", c: leader, max: 300},
+		}
+	}
+	s := New(follower, peers, func(b *block.Block, c *block.Commit) error {
+		if err := CheckBlock(follower, b, c, ds, cm, qtj); err != nil {
+			return err
+		}
+		return follower.AddBlock(b, c)
+	}, 0)
+	if err := s.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	if h, _ := follower.GetHeight(); h != n {
+		t.Fatalf("follower height %d", h)
+	}
+	want, _ := leader.GetHash(n)
+	if got, _ := follower.GetHash(n); !bytes.Equal(got, want) {
+		t.Fatalf("hash %x,want %x", got, want)
+	}
+	if c, err := follower.GetBlockCommit(n); err != nil || c == nil {
+		t.Fatalf("commit %v,%v", c, err)
+	}
+	if p := s.Progress(); p.Syncing || p.Start != stale || p.Current != n || p.Target != n || p.Peer != "good" {
+		t.Fatalf("progress %+v", p)
+	}
+}
+
+//raft blocks carry no commit certificates,a follower of a chain with validators still catches up.
+func TestCatchUpRaft(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	const n, stale = 300, 10
+	var addrs []types.Address
+	for _, s := range testAddrs {
+		addr, _ := types.StringToAddress(s)
+		addrs = append(addrs, *addr)
+	}
+	ds, cm, qtj := addrs[1].Bytes(), addrs[2].Bytes(), addrs[3].Bytes()
+
+	var vals []string
+	for i := 0; i < 4; i++ {
+		pub, _, _ := ed25519.GenerateKey(rand.Reader)
+		vals = append(vals, fmt.Sprintf("%s@127.0.0.1:%d", types.PublicKeyToAddress(pub), 9600+i))
+	}
+	g := &blockchain.Genesis{Timestamp: time.Now().Unix() - n - 100, Validators: vals}
+
+	leader, follower := newTestChain(t), newTestChain(t)
+	defer leader.close()
+	defer follower.close()
+	for _, c := range []*testChain{leader, follower} {
+		if _, err := c.InitGenesis(g); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for h := uint64(1); h <= n; h++ {
+		b, err := leader.NewBlock(nil, addrs[0], addrs[1], addrs[2], addrs[3])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Timestamp = g.Timestamp + int64(h)
+		b.SetHash()
+		if err := leader.AddBlock(b, nil); err != nil {
+			t.Fatal(err)
+		}
+		if h <= stale {
+			if err := follower.AddBlock(b, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	peers := func() []Peer {
+		return []Peer{&testPeer{name: "leader", c: leader}}
+	}
+	s := New(follower, peers, func(b *block.Block, c *block.Commit) error {
+		if err := CheckBlock(follower, b, c, ds, cm, qtj); err != nil {
+			return err
+		}
+		return follower.AddBlock(b, c)
+	}, 0)
+	if err := s.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	want, _ := leader.GetHash(n)
+	if got, _ := follower.GetHash(n); !bytes.Equal(got, want) {
+		t.Fatalf("hash %x,want %x", got, want)
+	}
+}
+
+type downPeer struct{}
+
+func (downPeer) String() string { return "down" }
+
+func (downPeer) Height() (uint64, error) { return 0, errors.New("connection refused") }
+
+func (downPeer) Blocks(uint64, uint64) ([]*block.Block, []*block.Commit, error) {
+	return nil, nil, errors.New("connection refused")
+}
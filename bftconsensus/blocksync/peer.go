@@ -0,0 +1,79 @@
+package blocksync
+
+import (
+	"context"
+	pb "kortho/api/message"
+	"kortho/block"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+const requestTimeout = 30 * time.Second
+
+//grpcPeer requests blocks by the grpc api of a node.
+type grpcPeer struct {
+	addr string
+}
+
+//NewGRPCPeer new a peer with the grpc api address of a node.
+func NewGRPCPeer(addr string) Peer {
+	return &grpcPeer{addr: addr}
+}
+
+func (p *grpcPeer) String() string {
+	return p.addr
+}
+
+func (p *grpcPeer) call(f func(context.Context, pb.GreeterClient) error) error {
+	conn, err := grpc.Dial(p.addr, grpc.WithInsecure())
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	return f(ctx, pb.NewGreeterClient(conn))
+}
+
+func (p *grpcPeer) Height() (uint64, error) {
+	var h uint64
+	err := p.call(func(ctx context.Context, cc pb.GreeterClient) error {
+		res, err := cc.GetMaxBlockNumber(ctx, &pb.ReqMaxBlockNumber{})
+		if err == nil {
+			h = res.MaxNumber
+		}
+		return err
+	})
+	return h, err
+}
+
+func (p *grpcPeer) Blocks(from, to uint64) ([]*block.Block, []*block.Commit, error) {
+	var res *pb.RespBlocks
+	err := p.call(func(ctx context.Context, cc pb.GreeterClient) (err error) {
+		res, err = cc.GetBlocks(ctx, &pb.ReqBlocks{From: from, To: to})
+		return
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var bs []*block.Block
+	var cs []*block.Commit
+	for i, data := range res.Blocks {
+		b, err := block.Deserialize(data)
+		if err != nil {
+			return nil, nil, err
+		}
+		var c *block.Commit
+		if i < len(res.Commits) && len(res.Commits[i]) > 0 {
+			if c, err = block.DeserializeCommit(res.Commits[i]); err != nil {
+				c = nil
+			}
+		}
+		bs = append(bs, b)
+		cs = append(cs, c)
+	}
+	return bs, cs, nil
+}
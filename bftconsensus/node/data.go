@@ -0,0 +1,53 @@
+package node
+
+import (
+	"encoding/json"
+	"errors"
+	"kortho/block"
+	"kortho/transaction"
+	"kortho/util/codec"
+)
+
+//version of the binary encoding of log entries.
+const dataVersion byte = 1
+
+//EncodeBlockData encodes a block and its result hash into a log entry.
+func EncodeBlockData(b *block.Block, resultHash []byte) []byte {
+	e := codec.NewEncoder()
+	e.Byte(dataVersion)
+	e.Bytes(b.Serialize())
+	e.Bytes(resultHash)
+	return e.Result()
+}
+
+//DecodeBlockData decodes a log entry,entries written before the binary encoding are json.
+func DecodeBlockData(data []byte) (*block.Block, []byte, error) {
+	if transaction.IsLegacy(data) {
+		blockData := struct {
+			Block      *block.Block `json:"block"`
+			ResultHash []byte       `json:"resulthash"`
+		}{}
+		if err := json.Unmarshal(data, &blockData); err != nil {
+			return nil, nil, err
+		}
+		if blockData.Block == nil {
+			return nil, nil, errors.New("block data is nil")
+		}
+		return blockData.Block, blockData.ResultHash, nil
+	}
+
+	d := codec.NewDecoder(data)
+	if v := d.Byte(); d.Err() == nil && v != dataVersion {
+		return nil, nil, errors.New("unknown block data version")
+	}
+	bd := d.Bytes()
+	resultHash := d.Bytes()
+	if err := d.Finish(); err != nil {
+		return nil, nil, err
+	}
+	b, err := block.Deserialize(bd)
+	if err != nil {
+		return nil, nil, err
+	}
+	return b, resultHash, nil
+}
@@ -2,33 +2,49 @@
 package node
 
 import (
+	"bufio"
 	"bytes"
-	"context"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
-	"kortho/api"
-	pb "kortho/api/message"
+	"kortho/bftconsensus/blocksync"
+	"kortho/bftconsensus/pbft"
 	"kortho/bftconsensus/protocol"
 	"kortho/block"
 	"kortho/blockchain"
 	"kortho/logger"
-	"kortho/transaction"
 	"kortho/txpool"
-	"kortho/types"
 	"strings"
-	"time"
 
 	"github.com/hashicorp/raft"
 	"go.uber.org/zap"
-	"google.golang.org/grpc"
 )
 
 //New node
-func New(cfg *Config, u interface{}, cf CommitFunc, df DeliveFunc, bc blockchain.Blockchains, pool *txpool.TxPool) (Node, error) {
+func New(cfg *Config, u interface{}, cf CommitFunc, df DeliveFunc, kf CheckFunc, rf RestoreFunc, bc blockchain.Blockchains, pool *txpool.TxPool) (Node, error) {
 	var n node
+	n.u = u
+	n.commitF = cf
+	n.deliveF = df
+	n.checkF = kf
+	n.restoreF = rf
+	n.pool = pool
+	n.boot = cfg.Join
+	n.nodeN = cfg.NodeNum
+	n.bc = bc
+	n.rpcPort = cfg.RPCPort
+	n.nodeAddr = cfg.Address
+	chi, err := n.bc.GetHeight()
+	if err != nil {
+		return nil, err
+	}
+	n.currentHeight = chi
+	n.syncAddrs = cfg.SyncPeers
+	n.syncer = blocksync.New(bc, n.syncPeers, n.commitSynced, cfg.SyncBatch)
+
+	//raft restores the latest snapshot inside protocol.New,so the node must be ready before it.
 	pC := &protocol.Config{
+		Engine:            cfg.Engine,
 		Join:              cfg.Join,
 		Address:           cfg.Address,
 		SnapshotInterval:  cfg.SnapshotInterval,
@@ -37,6 +53,11 @@ func New(cfg *Config, u interface{}, cf CommitFunc, df DeliveFunc, bc blockchain
 		SnapDir:           cfg.SnapDir,
 		LogsDir:           cfg.LogsDir,
 		StableDir:         cfg.StableDir,
+		PrivKey:           cfg.PrivKey,
+		Validators:        cfg.Validators,
+		ViewTimeout:       cfg.ViewTimeout,
+		Height:            chi,
+		Term:              cfg.ProposerTerm,
 	}
 	cp, err := protocol.New(pC, &n)
 	if err != nil {
@@ -45,22 +66,11 @@ func New(cfg *Config, u interface{}, cf CommitFunc, df DeliveFunc, bc blockchain
 	}
 
 	logger.Info("Init node...")
-
-	n.u = u
 	n.cp = cp
-	n.commitF = cf
-	n.deliveF = df
-	n.pool = pool
-	n.boot = cfg.Join
-	n.nodeN = cfg.NodeNum
-	n.bc = bc
-	n.rpcPort = cfg.RPCPort
-	n.nodeAddr = cfg.Address
-	chi, err := n.bc.GetHeight()
-	if err != nil {
-		return nil, err
-	}
-	n.currentHeight = chi
+
+	//catch up with the peers after restart.
+	go n.syncer.Run(nil)
+	n.syncer.Trigger()
 
 	return &n, nil
 }
@@ -70,15 +80,15 @@ func (n *node) Apply(e *raft.Log) interface{} {
 	//s := n.GetStats()
 	logger.Info("Apply Start:", zap.Uint64("e.Index", e.Index), zap.Uint64("e.term", e.Term), zap.String("num_peers", n.GetStats()["num_peers"]))
 
-	blockData := struct {
-		Block *block.Block `json:"block"`
-	}{}
-
-	if err := json.Unmarshal(e.Data, &blockData); err != nil {
-		logger.Error("Apply json.Unmarshal error", zap.Error(err))
+	b, _, err := DecodeBlockData(e.Data)
+	if err != nil {
+		logger.Error("Apply DecodeBlockData error", zap.Error(err))
 		return err
 	}
-	b := blockData.Block
+
+	//the syncer commits blocks at the same time.
+	n.mu.Lock()
+	defer n.mu.Unlock()
 
 	//already commited.
 	if b.Height < 1+n.currentHeight {
@@ -99,7 +109,7 @@ func (n *node) Apply(e *raft.Log) interface{} {
 		}
 
 		//commit block
-		err = n.commitF(n.u, e.Data)
+		err = n.commitF(n.u, e.Data, blockCommit(b, e.Extensions))
 		if err != nil {
 			logger.Error("commit block error", zap.Error(err))
 			return false
@@ -110,55 +120,70 @@ func (n *node) Apply(e *raft.Log) interface{} {
 		return nil
 	}
 
-	//b.Height > 1+n.currentHeight,need to recover block data
-	var leaderLastHeight uint64
-	var CConn *grpc.ClientConn
+	//b.Height > 1+n.currentHeight,the syncer fetches the missing blocks from peers outside of apply.
+	logger.Info("Apply End: height gap,start block sync", zap.Uint64("b.Height", b.Height), zap.Uint64("current height", n.currentHeight))
+	n.syncer.Trigger()
+	return nil
+}
 
-	leader := n.cp.GetLeader()
-	if leader == "" {
-		logger.Error("Error: the cluster no leader now,return apply")
-		return nil
+//check a proposal before voting for it,the syncer commits blocks at the same time.
+func (n *node) Verify(data []byte) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.deliveF(n.u, data)
+}
+
+//the latest committed height.
+func (n *node) Height() uint64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.currentHeight
+}
+
+//the committed validator set,pbft reloads it after the syncer committed blocks.
+func (n *node) Validators() ([]string, error) {
+	return n.bc.GetValidators()
+}
+
+//pbft committed a block after a gap,fetch the missing blocks.
+func (n *node) TriggerSync() {
+	n.syncer.Trigger()
+}
+
+//peers to sync blocks from,the grpc api of the leader at 'rpcport' when 'syncpeers' is not configured.
+func (n *node) syncPeers() []blocksync.Peer {
+	var peers []blocksync.Peer
+	for _, addr := range n.syncAddrs {
+		peers = append(peers, blocksync.NewGRPCPeer(addr))
 	}
-	if leader != n.nodeAddr {
-		//only follows need to build connection with leader
-		CConn = n.getRPCClient()
-		if CConn == nil {
-			logger.Error("getRPCClient failed!")
-			return nil
-		}
-		defer CConn.Close()
-
-		//only follows need to get the max Block Height From Leader
-		breakC := time.After(2 * time.Second)
-		for {
-			select {
-			case <-breakC:
-				logger.Error("getMaxBlockHeightFromLeader timeout,return...")
-				return nil
-			case <-time.After(time.Millisecond * 10):
-				h, err := n.getMaxBlockHeightFromLeader(CConn)
-				if err != nil {
-					logger.Error("getMaxBlockHeightFromLeader failed,continue to get...", zap.Error(err))
-					continue
-				}
-				leaderLastHeight = h
-			}
-			break
-		}
+	if leader := n.cp.GetLeader(); len(peers) == 0 && leader != "" && leader != n.nodeAddr {
+		peers = append(peers, blocksync.NewGRPCPeer(strings.Split(leader, ":")[0]+n.rpcPort))
+	}
+	return peers
+}
 
-		//commit without check to catch up with leader.
-		if b.Height <= leaderLastHeight {
-			mh, err := n.bc.GetMaxBlockHeight()
-			if err == nil {
-				n.currentHeight = mh
-			}
-			er := n.recoverBackwardBlocks(CConn, n.currentHeight+1, leaderLastHeight)
-			if er != nil {
-				logger.Error("recoverBackwardBlocks error", zap.Error(er))
-				return err
-			}
-		}
+//check and commit a block fetched by the syncer.
+func (n *node) commitSynced(b *block.Block, c *block.Commit) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	//consensus may have committed it meanwhile.
+	if b.Height <= n.currentHeight {
+		return nil
+	}
+	if b.Height != n.currentHeight+1 {
+		return fmt.Errorf("synced block %d is not next to %d", b.Height, n.currentHeight)
+	}
+	if err := n.checkF(n.u, b, c); err != nil {
+		return err
+	}
+	n.syncing = true
+	err := n.commitF(n.u, EncodeBlockData(b, nil), c)
+	n.syncing = false
+	if err != nil {
+		return err
 	}
+	n.currentHeight = b.Height
 	return nil
 }
 
@@ -181,7 +206,7 @@ func (n *node) handleBlockData(hei uint64, hs []byte, data []byte) bool {
 	//more than 2/3 nodes checked block ok to commit.
 	if trueCount >= n.nodeN*2/3 {
 		if n.currentHeight+1 == hei {
-			err := n.commitF(n.u, data)
+			err := n.commitF(n.u, data, nil)
 			if err != nil {
 				logger.Error("commit block error", zap.Error(err))
 				return false
@@ -216,161 +241,78 @@ func (n *node) deleteCommitedBlockInMap(hei uint64) {
 	logger.Info("finished delete commited height in map.", zap.Uint64("height", hei), zap.Int("map length", len(n.pool.Idhc)))
 }
 
-func (n *node) getRPCClient() *grpc.ClientConn {
-	breakC := time.After(time.Minute)
-	for {
-		select {
-		case <-breakC:
-			//restart when timeout
-			logger.Error("getRPCClient error timeout,return......")
-			return nil
-		case <-time.After(time.Millisecond):
-			addr := n.cp.GetLeader()
-			ad := strings.Split(addr, ":")
-			leaderaddr := ad[0] + n.rpcPort
-			conn, err := grpc.Dial(leaderaddr, grpc.WithInsecure())
-			if err != nil {
-				logger.Error("grpc Dial error", zap.Error(err))
-				continue
-			}
-			return conn
-		}
-	}
-}
-
-//Get the latest height from leader.
-func (n *node) getMaxBlockHeightFromLeader(CConn *grpc.ClientConn) (uint64, error) {
-
-	cc := pb.NewGreeterClient(CConn)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	res, err := cc.GetMaxBlockNumber(ctx, &pb.ReqMaxBlockNumber{})
-	if err != nil {
-		logger.Error("Call GetMaxBlockNumber error", zap.Error(err))
-		return 0, err
+//build the commit certificate of a block from the pbft log extensions.
+func blockCommit(b *block.Block, ext []byte) *block.Commit {
+	if len(ext) == 0 {
+		return nil
 	}
-
-	return res.MaxNumber, nil
-}
-
-//recover backward blocks data from height 'lo' to 'hi'.
-func (n *node) recoverBackwardBlocks(CConn *grpc.ClientConn, lo uint64, hi uint64) error {
-	logger.Info("Into recoverBackwardBlocks", zap.Uint64("low height", lo), zap.Uint64("high height", hi))
-
-	cc := pb.NewGreeterClient(CConn)
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	if lo > hi {
-		return fmt.Errorf("Wrong block interval：lo[%v] > hi[%v]", lo, hi)
+	var cert pbft.CommitCert
+	if err := json.Unmarshal(ext, &cert); err != nil {
+		logger.Error("Unmarshal commit certificate error", zap.Error(err))
+		return nil
 	}
-
-	for i := lo; i <= hi; i++ {
-		logger.Info("Start to recover Backward Blocks", zap.Uint64("Height", i))
-		res, err := cc.GetBlockByNum(ctx, &pb.ReqBlockByNumber{Height: i})
-		if err != nil {
-			logger.Error("recoverBackwardBlocks GetBlockByNum error", zap.Error(err))
-			return err
-		}
-		if res == nil {
-			continue
-		}
-
-		bc, err := n.blockConversion(res)
-		if err != nil {
-			return err
-		}
-		if bc.Height <= n.currentHeight {
-			continue
-		}
-
-		if n.currentHeight+1 == bc.Height {
-			bData := struct {
-				Block *block.Block
-			}{
-				Block: bc,
-			}
-			data, err := json.Marshal(bData)
-			if err != nil {
-				logger.Error("json Marshal error", zap.Error(err))
-				return err
-			}
-			err = n.commitF(n.u, data)
-			if err != nil {
-				logger.Error("recoverBackwardBlocks commit block error", zap.Error(err))
-				return err
-			}
-			n.currentHeight = bc.Height
-		}
-
+	c := &block.Commit{Height: b.Height, Hash: b.Hash, Round: cert.View}
+	for _, s := range cert.Signatures {
+		c.Signatures = append(c.Signatures, &block.CommitSig{Validator: s.Validator, Signature: s.Signature})
 	}
-
-	logger.Info("End recoverBackwardBlocks", zap.Uint64("current height", n.currentHeight))
-	return nil
+	return c
 }
 
-func (n *node) blockConversion(res *pb.RespBlock) (*block.Block, error) {
-	var Tx []*transaction.Transaction
-
-	if len(res.Txs) > 0 {
-		for _, msTx := range res.Txs {
-			if msTx != nil {
-				t, err := api.MsgTxToTx(msTx)
-				if err != nil {
-					return nil, err
-				}
-				Tx = append(Tx, t)
-			}
-		}
-	}
-
-	PrHs, err := hex.DecodeString(res.PrevBlockHash)
+//validators sign the height and hash of the block when committing it.
+func (n *node) CommitPayload(data []byte) ([]byte, error) {
+	b, _, err := DecodeBlockData(data)
 	if err != nil {
 		return nil, err
 	}
+	return block.CommitSignBytes(b.Height, b.Hash), nil
+}
 
-	Hs, err := hex.DecodeString(res.Hash)
+//generate a snapshot of the committed blockchain state.
+func (n *node) Snapshot() (raft.FSMSnapshot, error) {
+	s, err := n.bc.Snapshot()
 	if err != nil {
+		logger.Error("blockchain Snapshot error", zap.Error(err))
 		return nil, err
 	}
+	logger.Info("Snapshot", zap.Uint64("snapshot height", s.Height), zap.Uint64("current height", n.currentHeight))
+	return &snapshot{s: s}, nil
+}
 
-	Rt, err := hex.DecodeString(res.Root)
-	if err != nil {
-		return nil, err
-	}
+//rebuild the blockchain state from a snapshot.
+func (n *node) Restore(rc io.ReadCloser) error {
+	defer rc.Close()
 
-	ad, err := types.StringToAddress(res.Miner)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	h, err := n.bc.Restore(bufio.NewReader(rc))
 	if err != nil {
-		return nil, err
+		logger.Error("blockchain Restore error", zap.Error(err))
+		return err
 	}
-
-	b := &block.Block{
-		Height:       res.Height,
-		PrevHash:     PrHs,
-		Hash:         Hs,
-		Transactions: Tx,
-		Root:         Rt,
-		Version:      res.Version,
-		Timestamp:    res.Timestamp,
-		Miner:        *ad,
+	n.currentHeight = h
+	if err := n.restoreF(n.u, h); err != nil {
+		logger.Error("restore callback error", zap.Error(err))
+		return err
 	}
-	return b, nil
-}
-
-//generate a snapshot struct
-func (n *node) Snapshot() (raft.FSMSnapshot, error) {
-	return &snapshot{}, nil
-}
-
-//recover data
-func (n *node) Restore(_ io.ReadCloser) error {
+	logger.Info("Restore End:", zap.Uint64("current height", n.currentHeight))
 	return nil
 }
 
 //save the FSM snapshot out to the given sink
-func (s *snapshot) Persist(_ raft.SnapshotSink) error {
-	return nil
+func (s *snapshot) Persist(sink raft.SnapshotSink) error {
+	w := bufio.NewWriter(sink)
+	err := s.s.Dump(w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		logger.Error("Persist snapshot error", zap.Error(err), zap.Uint64("height", s.s.Height))
+		sink.Cancel()
+		return err
+	}
+	return sink.Close()
 }
 
-func (s *snapshot) Release() {}
+func (s *snapshot) Release() {
+	s.s.Release()
+}
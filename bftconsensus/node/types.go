@@ -2,14 +2,19 @@
 package node
 
 import (
+	"kortho/bftconsensus/blocksync"
 	"kortho/bftconsensus/protocol"
+	"kortho/block"
 	"kortho/blockchain"
 	"kortho/txpool"
+	"strconv"
 	"sync"
+	"time"
 )
 
 //Config for node
 type Config struct {
+	Engine            string //consensus engine
 	Join              bool   //use for distinguishing leader does some actions.
 	Address           string //node address
 	SnapshotThreshold uint64 //Snapshot threshold
@@ -21,15 +26,23 @@ type Config struct {
 	NodeNum           uint64 //node number
 	RPCPort           string //request max block height rpc
 	MRpcAddr          string
-	MRpcPort          string //request backward blocks data rpc
+	MRpcPort          string        //request backward blocks data rpc
+	PrivKey           string        //pbft validator private key
+	Validators        []string      //pbft validator set
+	ViewTimeout       time.Duration //pbft view change timeout
+	ProposerTerm      uint64        //number of blocks every proposer proposes in turn
+	SyncPeers         []string      //grpc api addresses of the nodes to sync blocks from
+	SyncBatch         uint64        //number of blocks requested at a time when syncing
 }
 
 //Node interface
 type Node interface {
-	IsMiner() bool        //leader or not
-	DelPeer(string) error //delete a node
-	AddPeer(string) error //add a node
-	Prepare([]byte) error //Prepare a block data
+	IsMiner() bool                     //leader or not
+	DelPeer(string) error              //delete a node
+	AddPeer(string) error              //add a node
+	SetValidators([]string) error      //reconfigure with the committed validator set
+	Prepare([]byte) error              //Prepare a block data
+	LeaderShipTransferTo(string) error //transfer the leadership to a node
 	GetLeader() string
 }
 
@@ -37,6 +50,8 @@ type node struct {
 	u             interface{}
 	commitF       CommitFunc             //callback function for commit block data
 	deliveF       DeliveFunc             //callback function for delive block data
+	checkF        CheckFunc              //callback function for checking synced blocks
+	restoreF      RestoreFunc            //callback function after restoring a snapshot
 	cp            protocol.Consensus     //bft  Consensus
 	bc            blockchain.Blockchains //blockchain
 	pool          *txpool.TxPool         //TxPool
@@ -45,8 +60,11 @@ type node struct {
 	currentHeight uint64                 //current height
 	rpcPort       string                 //port for get max block height from leader
 	recPort       string                 //port for recover blocks data
-	mu            sync.RWMutex           //
+	mu            sync.RWMutex           //guards currentHeight and committing blocks
 	nodeAddr      string                 //node address
+	syncer        *blocksync.Syncer      //fetches missing blocks from peers
+	syncAddrs     []string               //grpc api addresses of the peers to sync from
+	syncing       bool                   //the syncer is committing a block
 }
 
 //spread a block data to other nodes by p2p
@@ -69,6 +87,15 @@ func (n *node) AddPeer(id string) error {
 	return n.cp.AddPeer(id, id)
 }
 
+//reconfigure the consensus with the committed validator set
+//The syncer commits blocks outside of consensus,pbft reloads the set from Validators then.
+func (n *node) SetValidators(vals []string) error {
+	if n.syncing {
+		return nil
+	}
+	return n.cp.SetValidators(vals)
+}
+
 func (n *node) GetLeader() string {
 	return n.cp.GetLeader()
 }
@@ -77,15 +104,36 @@ func (n *node) LeaderShipTransferToF() error {
 	return n.cp.LeaderShipTransferToF()
 }
 
+//transfer the leadership to the node with address
+func (n *node) LeaderShipTransferTo(addr string) error {
+	return n.cp.LeaderShipTransferTo(addr)
+}
+
 func (n *node) GetStats() map[string]string {
-	return n.cp.GetStats()
+	st := n.cp.GetStats()
+	p := n.syncer.Progress()
+	st["sync_state"] = "Idle"
+	if p.Syncing {
+		st["sync_state"] = "Syncing"
+	}
+	st["sync_height"] = strconv.FormatUint(p.Current, 10)
+	st["sync_target"] = strconv.FormatUint(p.Target, 10)
+	st["sync_peer"] = p.Peer
+	return st
 }
 
-//CommitFunc commits the blocks
-type CommitFunc (func(interface{}, []byte) error)
+//CommitFunc commits the blocks with the commit certificate,which is nil if the engine does not provide one
+type CommitFunc (func(interface{}, []byte, *block.Commit) error)
 
 //DeliveFunc delive the blocks
 type DeliveFunc (func(interface{}, []byte) error)
 
+//CheckFunc checks a block fetched by the syncer with its commit certificate before commit
+type CheckFunc (func(interface{}, *block.Block, *block.Commit) error)
+
+//RestoreFunc is called with the new height after a snapshot is restored
+type RestoreFunc (func(interface{}, uint64) error)
+
 type snapshot struct {
+	s *blockchain.Snapshot
 }
@@ -0,0 +1,750 @@
+//Package pbft implements a practical byzantine fault tolerant consensus engine.
+package pbft
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kortho/logger"
+	"kortho/util"
+	"kortho/util/miscellaneous"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	DefaultViewTimeout = 10 * time.Second
+	prepareTimeout     = 10 * time.Second
+	maxBackoff         = 6
+	//sequences above the committed one kept in the log,
+	//so a faulty validator can not fill the memory with instances of far sequences.
+	logWindow = 64
+)
+
+//New new a pbft node
+func New(cfg *Config, fsm raft.FSM) (*pbft, error) {
+	logger.Info("Init pbft...")
+	if len(cfg.PrivKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("pbft: invalid private key")
+	}
+	if cfg.ViewTimeout <= 0 {
+		cfg.ViewTimeout = DefaultViewTimeout
+	}
+
+	p := &pbft{
+		cfg:       cfg,
+		fsm:       fsm,
+		vals:      cfg.Validators,
+		byID:      make(map[string]*Validator),
+		seq:       cfg.Height,
+		log:       make(map[uint64]*instance),
+		vcs:       make(map[uint64]map[string]*Message),
+		ahead:     make(map[uint64]map[string]bool),
+		waiters:   make(map[uint64]chan error),
+		progress:  time.Now(),
+		msgC:      make(chan *Message, 1024),
+		propC:     make(chan *proposal),
+		transferC: make(chan struct{}, 1),
+		clients:   make(map[string]*client),
+	}
+	for _, v := range p.vals {
+		p.byID[v.ID] = v
+	}
+	if len(p.byID) != len(p.vals) || len(p.vals) == 0 {
+		return nil, errors.New("pbft: invalid validator set")
+	}
+	id := util.PubtoAddr(cfg.PrivKey.Public().(ed25519.PublicKey))
+	if p.self = p.byID[id]; p.self == nil {
+		return nil, fmt.Errorf("pbft: %s is not in the validator set", id)
+	}
+	p.f = (len(p.vals) - 1) / 3
+	p.quorum = 2*p.f + 1
+	p.updateStatus()
+
+	if err := p.listen(); err != nil {
+		logger.Error("pbft listen error", zap.Error(err), zap.String("node addr", cfg.Address))
+		return nil, err
+	}
+	go p.run()
+
+	logger.Info("pbft started", zap.String("id", id), zap.Int("validators", len(p.vals)), zap.Int("quorum", p.quorum), zap.Uint64("seq", p.seq))
+	return p, nil
+}
+
+//ParseValidator parses a validator in format "address@host:port".
+func ParseValidator(s string) (*Validator, error) {
+	i := strings.Index(s, "@")
+	if i < 0 {
+		return nil, fmt.Errorf("pbft: invalid validator %q", s)
+	}
+	id, addr := s[:i], s[i+1:]
+	if len(id) <= 3 || !strings.HasPrefix(id, "Kto") {
+		return nil, fmt.Errorf("pbft: invalid validator address %q", id)
+	}
+	pub := util.Decode(id[3:])
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("pbft: invalid validator address %q", id)
+	}
+	return &Validator{ID: id, Addr: addr, PubKey: ed25519.PublicKey(pub)}, nil
+}
+
+//IsMiner true is primary,false not.
+func (p *pbft) IsMiner() bool {
+	st := p.st.Load().(*status)
+	return !st.changing && st.primary == p.self.Addr
+}
+
+//Prepare proposes block data and waits until it is committed.
+func (p *pbft) Prepare(data []byte) error {
+	if !p.IsMiner() {
+		return errNotPrimary
+	}
+	pr := &proposal{data: data, done: make(chan error, 1)}
+	p.propC <- pr
+	select {
+	case err := <-pr.done:
+		return err
+	case <-time.After(prepareTimeout):
+		return errTimeout
+	}
+}
+
+//DelPeer the validator set is only changed by SetValidators.
+func (p *pbft) DelPeer(string) error {
+	return errStaticPeers
+}
+
+//AddPeer the validator set is only changed by SetValidators.
+func (p *pbft) AddPeer(string, string) error {
+	return errStaticPeers
+}
+
+//SetValidators replaces the validator set from the next sequence.
+//It must be called by fsm while applying a proposal,so every validator changes the set after the same sequence.
+func (p *pbft) SetValidators(vals []string) error {
+	var vs []*Validator
+	byID := make(map[string]*Validator)
+	for _, s := range vals {
+		v, err := ParseValidator(s)
+		if err != nil {
+			return err
+		}
+		if _, ok := byID[v.ID]; ok {
+			return fmt.Errorf("pbft: duplicate validator %s", v.ID)
+		}
+		vs = append(vs, v)
+		byID[v.ID] = v
+	}
+	if len(vs) == 0 {
+		return errors.New("pbft: invalid validator set")
+	}
+
+	p.vals = vs
+	p.byID = byID
+	p.f = (len(vs) - 1) / 3
+	p.quorum = 2*p.f + 1
+	//votes of removed validators do not count any more.
+	for _, in := range p.log {
+		for id := range in.prepares {
+			if _, ok := byID[id]; !ok {
+				delete(in.prepares, id)
+			}
+		}
+		for id := range in.commits {
+			if _, ok := byID[id]; !ok {
+				delete(in.commits, id)
+			}
+		}
+	}
+	p.updateStatus()
+	_, member := byID[p.self.ID]
+	logger.Info("pbft validators changed", zap.Int("validators", len(vs)), zap.Int("quorum", p.quorum), zap.Bool("member", member))
+	return nil
+}
+
+//GetLeader get primary address,empty in view change.
+func (p *pbft) GetLeader() string {
+	st := p.st.Load().(*status)
+	if st.changing {
+		return ""
+	}
+	return st.primary
+}
+
+//LeaderShipTransferToF starts a view change.
+func (p *pbft) LeaderShipTransferToF() error {
+	if !p.IsMiner() {
+		return errNotPrimary
+	}
+	select {
+	case p.transferC <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+//LeaderShipTransferTo the primary rotates by the committed sequence only.
+func (p *pbft) LeaderShipTransferTo(string) error {
+	return errRotation
+}
+
+func (p *pbft) GetStats() map[string]string {
+	st := p.st.Load().(*status)
+	state := "Backup"
+	if st.changing {
+		state = "ViewChange"
+	} else if st.primary == p.self.Addr {
+		state = "Primary"
+	}
+	return map[string]string{
+		"engine":       "pbft",
+		"state":        state,
+		"view":         strconv.FormatUint(st.view, 10),
+		"commit_index": strconv.FormatUint(st.seq, 10),
+		"primary":      st.primary,
+		"num_peers":    strconv.Itoa(st.validators - 1),
+		"quorum":       strconv.Itoa(st.quorum),
+	}
+}
+
+//event loop,all consensus state is only accessed here.
+func (p *pbft) run() {
+	ticker := time.NewTicker(p.cfg.ViewTimeout / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case m := <-p.msgC:
+			p.handle(m)
+		case pr := <-p.propC:
+			p.propose(pr)
+		case <-p.transferC:
+			if !p.changing {
+				p.startViewChange(p.view + 1)
+			}
+		case <-ticker.C:
+			p.tick()
+		}
+		//handle own messages
+		for len(p.queue) > 0 {
+			m := p.queue[0]
+			p.queue = p.queue[1:]
+			p.handle(m)
+		}
+		p.updateStatus()
+	}
+}
+
+func (p *pbft) updateStatus() {
+	p.st.Store(&status{
+		view:       p.view,
+		seq:        p.seq,
+		primary:    p.primary(p.view).Addr,
+		changing:   p.changing,
+		validators: len(p.vals),
+		quorum:     p.quorum,
+	})
+}
+
+func (p *pbft) primary(view uint64) *Validator {
+	return p.vals[view%uint64(len(p.vals))]
+}
+
+func (p *pbft) isPrimary() bool {
+	return !p.changing && p.primary(p.view).ID == p.self.ID
+}
+
+func (p *pbft) instance(seq uint64) *instance {
+	in, ok := p.log[seq]
+	if !ok {
+		in = &instance{
+			prepares: make(map[string]*Message),
+			commits:  make(map[string]*Message),
+		}
+		p.log[seq] = in
+	}
+	return in
+}
+
+//sign a message,send it to the others and handle it by self.
+func (p *pbft) emit(m *Message) {
+	m.From = p.self.ID
+	m.Sig = ed25519.Sign(p.cfg.PrivKey, m.hash())
+	p.queue = append(p.queue, m)
+	p.broadcast([]*Message{m})
+}
+
+func (p *pbft) verify(m *Message) bool {
+	v, ok := p.byID[m.From]
+	return ok && ed25519.Verify(v.PubKey, m.hash(), m.Sig)
+}
+
+func (p *pbft) propose(pr *proposal) {
+	if !p.isPrimary() {
+		pr.done <- errNotPrimary
+		return
+	}
+	seq := p.seq + 1
+	if in, ok := p.log[seq]; ok && in.prePrepare != nil {
+		pr.done <- errBusy
+		return
+	}
+	p.waiters[seq] = pr.done
+	p.emit(&Message{
+		Type:   PrePrepareMsg,
+		View:   p.view,
+		Seq:    seq,
+		Digest: digest(pr.data),
+		Data:   pr.data,
+	})
+}
+
+func (p *pbft) handle(m *Message) {
+	if m == nil || !p.verify(m) {
+		logger.Debug("pbft drop message", zap.Error(errBadSignature))
+		return
+	}
+	switch m.Type {
+	case PrePrepareMsg, PrepareMsg, CommitMsg:
+		if m.View > p.view {
+			p.catchUpView(m)
+			return
+		}
+		if m.View < p.view || p.changing || m.Seq <= p.seq {
+			return
+		}
+		//the others are that far only when this node is behind,fetch the blocks instead.
+		if m.Seq > p.seq+logWindow {
+			if s, ok := p.fsm.(Syncer); ok {
+				s.TriggerSync()
+			}
+			return
+		}
+		switch m.Type {
+		case PrePrepareMsg:
+			p.handlePrePrepare(m)
+		case PrepareMsg:
+			p.instance(m.Seq).prepares[m.From] = m
+		case CommitMsg:
+			p.instance(m.Seq).commits[m.From] = m
+		}
+		p.check(m.Seq)
+	case ViewChangeMsg:
+		p.handleViewChange(m)
+	case NewViewMsg:
+		p.handleNewView(m)
+	}
+}
+
+func (p *pbft) handlePrePrepare(m *Message) {
+	if m.From != p.primary(m.View).ID || !bytes.Equal(m.Digest, digest(m.Data)) {
+		return
+	}
+	//the new primary must propose again the proposal which may have been committed in the old view.
+	if p.expected != nil && p.expected.Seq == m.Seq && !bytes.Equal(p.expected.Digest, m.Digest) {
+		logger.Error("pbft pre-prepare conflicts with prepared proposal", zap.Uint64("seq", m.Seq), zap.Uint64("view", m.View))
+		return
+	}
+	in := p.instance(m.Seq)
+	if in.prePrepare != nil {
+		if !bytes.Equal(in.prePrepare.Digest, m.Digest) {
+			logger.Error("pbft conflicting pre-prepare", zap.Uint64("seq", m.Seq), zap.Uint64("view", m.View))
+		}
+		return
+	}
+	in.prePrepare = m
+	p.accept(m.Seq)
+}
+
+//check the proposal of the next sequence and prepare it,
+//a proposal after a gap can not be checked until the previous ones are committed.
+func (p *pbft) accept(seq uint64) {
+	in, ok := p.log[seq]
+	if !ok || in.prePrepare == nil || in.verified || seq != p.seq+1 {
+		return
+	}
+	pp := in.prePrepare
+	if v, ok := p.fsm.(Verifier); ok {
+		if err := v.Verify(pp.Data); err != nil {
+			logger.Error("pbft verify proposal error", zap.Uint64("seq", seq), zap.Error(err))
+			return
+		}
+	}
+	in.verified = true
+	p.emit(&Message{Type: PrepareMsg, View: pp.View, Seq: pp.Seq, Digest: pp.Digest})
+}
+
+//move to the next phase when a quorum is reached.
+func (p *pbft) check(seq uint64) {
+	in := p.log[seq]
+	if in == nil || in.prePrepare == nil {
+		return
+	}
+	pp := in.prePrepare
+	if !in.prepared && votes(in.prepares, pp) >= p.quorum {
+		in.prepared = true
+		p.prepared = &Certificate{PrePrepare: pp, Votes: matching(in.prepares, pp)}
+	}
+	//never vote to commit a proposal which is not checked by self.
+	if in.prepared && in.verified && !in.sentCommit {
+		in.sentCommit = true
+		m := &Message{Type: CommitMsg, View: pp.View, Seq: pp.Seq, Digest: pp.Digest}
+		if c, ok := p.fsm.(Certifier); ok {
+			payload, err := c.CommitPayload(pp.Data)
+			if err != nil {
+				logger.Error("pbft commit payload error", zap.Uint64("seq", pp.Seq), zap.Error(err))
+			} else {
+				in.payload = payload
+				m.CertSig = ed25519.Sign(p.cfg.PrivKey, payload)
+			}
+		}
+		p.emit(m)
+	}
+	if !in.committed && votes(in.commits, pp) >= p.quorum {
+		in.committed = true
+		p.commit()
+	}
+}
+
+//apply committed proposals in order of sequence.
+func (p *pbft) commit() {
+	var seqs []uint64
+	for seq, in := range p.log {
+		if in.committed && seq > p.seq {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+
+	var rotate bool
+	for _, seq := range seqs {
+		if seq <= p.seq {
+			continue
+		}
+		//stop at a gap,the missing proposals are fetched outside of consensus.
+		if seq != p.seq+1 {
+			if s, ok := p.fsm.(Syncer); ok {
+				s.TriggerSync()
+			}
+			break
+		}
+		in := p.log[seq]
+		pp := in.prePrepare
+		r := p.fsm.Apply(&raft.Log{Index: pp.Seq, Term: pp.View, Type: raft.LogCommand, Data: pp.Data, Extensions: p.certificate(in)})
+		p.seq = seq
+		p.syncHeight()
+		p.progress = time.Now()
+		if p.cfg.Term > 0 && seq%p.cfg.Term == 0 {
+			rotate = true
+		}
+
+		if w, ok := p.waiters[seq]; ok {
+			err, _ := r.(error)
+			if applied, isBool := r.(bool); isBool && !applied {
+				err = errors.New("pbft: apply proposal failed")
+			}
+			w <- err
+			delete(p.waiters, seq)
+		}
+	}
+	p.truncate()
+	if p.prepared != nil && p.prepared.PrePrepare.Seq <= p.seq {
+		p.prepared = nil
+	}
+	if p.expected != nil && p.expected.Seq <= p.seq {
+		p.expected = nil
+	}
+	//every validator rotates after committing the last sequence of a term.
+	if rotate && !p.changing {
+		p.enterView(p.rotation(p.seq))
+		return
+	}
+	p.accept(p.seq + 1)
+}
+
+//rotation returns the lowest view above the current one whose primary is the scheduled proposer of seq+1.
+func (p *pbft) rotation(seq uint64) uint64 {
+	n := uint64(len(p.vals))
+	next := seq / p.cfg.Term % n
+	view := p.view + 1
+	return view + (next+n-view%n)%n
+}
+
+//collect the valid commit payload signatures of a committed proposal.
+func (p *pbft) certificate(in *instance) []byte {
+	pp := in.prePrepare
+	if in.payload == nil {
+		c, ok := p.fsm.(Certifier)
+		if !ok {
+			return nil
+		}
+		payload, err := c.CommitPayload(pp.Data)
+		if err != nil {
+			return nil
+		}
+		in.payload = payload
+	}
+
+	cert := &CommitCert{View: pp.View, Seq: pp.Seq}
+	for _, m := range matching(in.commits, pp) {
+		if v, ok := p.byID[m.From]; ok && ed25519.Verify(v.PubKey, in.payload, m.CertSig) {
+			cert.Signatures = append(cert.Signatures, &CommitSig{Validator: m.From, Signature: m.CertSig})
+		}
+	}
+	sort.Slice(cert.Signatures, func(i, j int) bool { return cert.Signatures[i].Validator < cert.Signatures[j].Validator })
+	data, err := json.Marshal(cert)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+//the fsm may recover blocks by itself.
+//follow the height of fsm,return true if fsm committed blocks outside of consensus.
+func (p *pbft) syncHeight() bool {
+	if h, ok := p.fsm.(Heighter); ok {
+		if hi := h.Height(); hi > p.seq {
+			p.seq = hi
+			p.truncate()
+			p.reloadValidators()
+			return true
+		}
+	}
+	return false
+}
+
+//drop the instances of committed sequences.
+func (p *pbft) truncate() {
+	for seq := range p.log {
+		if seq <= p.seq {
+			delete(p.log, seq)
+		}
+	}
+}
+
+//the blocks recovered by fsm may change the validator set.
+func (p *pbft) reloadValidators() {
+	vs, ok := p.fsm.(ValidatorSet)
+	if !ok {
+		return
+	}
+	vals, err := vs.Validators()
+	if err != nil || len(vals) == 0 {
+		return
+	}
+	if err := p.SetValidators(vals); err != nil {
+		logger.Error("pbft reload validators error", zap.Error(err))
+	}
+}
+
+func (p *pbft) tick() {
+	//apply the proposals committed after the gap filled by fsm.
+	if p.syncHeight() {
+		p.commit()
+	}
+	timeout := p.cfg.ViewTimeout
+	if p.changing {
+		//back off when view changes keep failing.
+		n := p.target - p.view
+		if n > maxBackoff {
+			n = maxBackoff
+		}
+		timeout <<= n
+	}
+	if time.Since(p.progress) < timeout {
+		return
+	}
+	if p.changing {
+		p.startViewChange(p.target + 1)
+	} else {
+		p.startViewChange(p.view + 1)
+	}
+}
+
+func (p *pbft) startViewChange(view uint64) {
+	logger.Info("pbft start view change", zap.Uint64("view", p.view), zap.Uint64("new view", view), zap.Uint64("seq", p.seq))
+	p.changing = true
+	p.target = view
+	p.progress = time.Now()
+	for seq, w := range p.waiters {
+		w <- errViewChanged
+		delete(p.waiters, seq)
+	}
+
+	m := &Message{Type: ViewChangeMsg, View: view, Seq: p.seq}
+	if p.prepared != nil && p.prepared.PrePrepare.Seq > p.seq {
+		m.Prepared = p.prepared
+		m.Digest = p.prepared.PrePrepare.Digest
+	}
+	p.emit(m)
+}
+
+func (p *pbft) handleViewChange(m *Message) {
+	if m.View <= p.view || !p.validViewChange(m, m.View) {
+		return
+	}
+	vcs, ok := p.vcs[m.View]
+	if !ok {
+		vcs = make(map[string]*Message)
+		p.vcs[m.View] = vcs
+	}
+	vcs[m.From] = m
+
+	//f+1 validators want to change view,so at least one correct validator does.
+	if len(vcs) > p.f && (!p.changing || p.target < m.View) {
+		p.startViewChange(m.View)
+	}
+	if len(vcs) >= p.quorum && p.changing && p.target == m.View && p.primary(m.View).ID == p.self.ID {
+		nv := &Message{Type: NewViewMsg, View: m.View}
+		for _, vc := range vcs {
+			nv.ViewChanges = append(nv.ViewChanges, vc)
+		}
+		sort.Slice(nv.ViewChanges, func(i, j int) bool { return nv.ViewChanges[i].From < nv.ViewChanges[j].From })
+		nv.Digest = vcDigest(nv.ViewChanges)
+		p.emit(nv)
+	}
+}
+
+func (p *pbft) handleNewView(m *Message) {
+	if m.View <= p.view || m.From != p.primary(m.View).ID || !bytes.Equal(m.Digest, vcDigest(m.ViewChanges)) {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, vc := range m.ViewChanges {
+		if vc.Type != ViewChangeMsg || !p.verify(vc) || !p.validViewChange(vc, m.View) {
+			return
+		}
+		seen[vc.From] = true
+	}
+	if len(seen) < p.quorum {
+		return
+	}
+
+	//the prepared proposal with the highest view must be proposed again.
+	var cert *Certificate
+	for _, vc := range m.ViewChanges {
+		if c := vc.Prepared; c != nil && c.PrePrepare.Seq > p.seq {
+			if cert == nil || c.PrePrepare.View > cert.PrePrepare.View {
+				cert = c
+			}
+		}
+	}
+
+	p.enterView(m.View)
+	if cert != nil {
+		p.expected = cert.PrePrepare
+		if p.isPrimary() && cert.PrePrepare.Seq == p.seq+1 {
+			p.emit(&Message{
+				Type:   PrePrepareMsg,
+				View:   p.view,
+				Seq:    cert.PrePrepare.Seq,
+				Digest: cert.PrePrepare.Digest,
+				Data:   cert.PrePrepare.Data,
+			})
+		}
+	}
+}
+
+//join a higher view which f+1 validators are working in.
+func (p *pbft) catchUpView(m *Message) {
+	ids, ok := p.ahead[m.View]
+	if !ok {
+		ids = make(map[string]bool)
+		p.ahead[m.View] = ids
+	}
+	ids[m.From] = true
+	if len(ids) > p.f {
+		p.enterView(m.View)
+		p.queue = append(p.queue, m)
+	}
+}
+
+func (p *pbft) enterView(view uint64) {
+	logger.Info("pbft enter new view", zap.Uint64("view", view), zap.String("primary", p.primary(view).Addr))
+	p.view = view
+	p.target = view
+	p.changing = false
+	p.progress = time.Now()
+	for seq := range p.log {
+		delete(p.log, seq)
+	}
+	for v := range p.vcs {
+		if v <= view {
+			delete(p.vcs, v)
+		}
+	}
+	for v := range p.ahead {
+		if v <= view {
+			delete(p.ahead, v)
+		}
+	}
+}
+
+func (p *pbft) validViewChange(m *Message, view uint64) bool {
+	if m.View != view {
+		return false
+	}
+	if m.Prepared == nil {
+		return len(m.Digest) == 0
+	}
+	return bytes.Equal(m.Digest, m.Prepared.PrePrepare.Digest) && p.validCert(m.Prepared, PrepareMsg)
+}
+
+//check a certificate contains a pre-prepare and a quorum of votes.
+func (p *pbft) validCert(c *Certificate, t MsgType) bool {
+	pp := c.PrePrepare
+	if pp == nil || pp.Type != PrePrepareMsg || pp.From != p.primary(pp.View).ID || !p.verify(pp) || !bytes.Equal(pp.Digest, digest(pp.Data)) {
+		return false
+	}
+	seen := make(map[string]bool)
+	for _, v := range c.Votes {
+		if v.Type == t && v.View == pp.View && v.Seq == pp.Seq && bytes.Equal(v.Digest, pp.Digest) && p.verify(v) {
+			seen[v.From] = true
+		}
+	}
+	return len(seen) >= p.quorum
+}
+
+func (m *Message) hash() []byte {
+	h := sha3.New256()
+	h.Write([]byte{byte(m.Type)})
+	h.Write(miscellaneous.E64func(m.View))
+	h.Write(miscellaneous.E64func(m.Seq))
+	h.Write(m.Digest)
+	h.Write([]byte(m.From))
+	return h.Sum(nil)
+}
+
+func digest(data []byte) []byte {
+	h := sha3.Sum256(data)
+	return h[:]
+}
+
+func vcDigest(vcs []*Message) []byte {
+	h := sha3.New256()
+	for _, vc := range vcs {
+		h.Write(vc.Sig)
+	}
+	return h.Sum(nil)
+}
+
+func votes(ms map[string]*Message, pp *Message) int {
+	return len(matching(ms, pp))
+}
+
+func matching(ms map[string]*Message, pp *Message) []*Message {
+	var r []*Message
+	for _, m := range ms {
+		if m.View == pp.View && bytes.Equal(m.Digest, pp.Digest) {
+			r = append(r, m)
+		}
+	}
+	return r
+}
@@ -0,0 +1,316 @@
+package pbft
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"kortho/logger"
+	"kortho/util"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"go.uber.org/zap"
+)
+
+type testFSM struct {
+	mu    sync.Mutex
+	seqs  []uint64
+	views []uint64
+	datas [][]byte
+	certs []*CommitCert
+}
+
+func (f *testFSM) Apply(l *raft.Log) interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.seqs = append(f.seqs, l.Index)
+	f.views = append(f.views, l.Term)
+	f.datas = append(f.datas, l.Data)
+	var cert CommitCert
+	if err := json.Unmarshal(l.Extensions, &cert); err != nil {
+		return err
+	}
+	f.certs = append(f.certs, &cert)
+	return nil
+}
+
+func (f *testFSM) CommitPayload(data []byte) ([]byte, error) { return digest(data), nil }
+
+func (f *testFSM) Snapshot() (raft.FSMSnapshot, error) { return nil, nil }
+
+func (f *testFSM) Restore(io.ReadCloser) error { return nil }
+
+func (f *testFSM) applied() []uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]uint64{}, f.seqs...)
+}
+
+func testValidators(t *testing.T, n, port int) ([]*Validator, []ed25519.PrivateKey) {
+	var vals []*Validator
+	var keys []ed25519.PrivateKey
+	for i := 0; i < n; i++ {
+		pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+		v, err := ParseValidator(fmt.Sprintf("%s@127.0.0.1:%d", util.PubtoAddr(pub), port+i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		vals = append(vals, v)
+		keys = append(keys, priv)
+	}
+	return vals, keys
+}
+
+//the primary of view 0 is down,the others change view and keep committing.
+func TestViewChange(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	vals, keys := testValidators(t, 4, 19800)
+
+	var ps []*pbft
+	var fs []*testFSM
+	for i := 1; i < len(vals); i++ {
+		f := &testFSM{}
+		p, err := New(&Config{Address: vals[i].Addr, PrivKey: keys[i], Validators: vals, ViewTimeout: time.Second}, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ps = append(ps, p)
+		fs = append(fs, f)
+	}
+
+	const n = 5
+	deadline := time.Now().Add(20 * time.Second)
+	for len(fs[0].applied()) < n && time.Now().Before(deadline) {
+		for _, p := range ps {
+			if p.IsMiner() {
+				p.Prepare([]byte(time.Now().String()))
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	for i, f := range fs {
+		seqs := f.applied()
+		if len(seqs) < n {
+			t.Fatalf("node %d applied %v", i+1, seqs)
+		}
+		for j, seq := range seqs {
+			if seq != uint64(j+1) {
+				t.Fatalf("node %d applied %v", i+1, seqs)
+			}
+		}
+	}
+	//every commit certificate has a quorum of signatures over the payload
+	byID := make(map[string]*Validator)
+	for _, v := range vals {
+		byID[v.ID] = v
+	}
+	for i, cert := range fs[0].certs {
+		if len(cert.Signatures) < 3 {
+			t.Fatalf("seq %d has %d signatures", cert.Seq, len(cert.Signatures))
+		}
+		for _, sig := range cert.Signatures {
+			if !ed25519.Verify(byID[sig.Validator].PubKey, digest(fs[0].datas[i]), sig.Signature) {
+				t.Fatalf("bad signature of %s at seq %d", sig.Validator, cert.Seq)
+			}
+		}
+	}
+
+	if st := ps[0].GetStats(); st["view"] == "0" {
+		t.Fatalf("view not changed: %v", st)
+	}
+}
+
+//every validator proposes Term sequences in turn.
+func TestRotation(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	vals, keys := testValidators(t, 4, 19810)
+	var ps []*pbft
+	var fs []*testFSM
+	for i := range vals {
+		f := &testFSM{}
+		p, err := New(&Config{Address: vals[i].Addr, PrivKey: keys[i], Validators: vals, ViewTimeout: 5 * time.Second, Term: 2}, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ps = append(ps, p)
+		fs = append(fs, f)
+	}
+
+	const n = 10
+	deadline := time.Now().Add(20 * time.Second)
+	for len(fs[0].applied()) < n && time.Now().Before(deadline) {
+		for _, p := range ps {
+			if p.IsMiner() {
+				p.Prepare([]byte(time.Now().String()))
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	f := fs[0]
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.seqs) < n {
+		t.Fatalf("applied %v", f.seqs)
+	}
+	for i, seq := range f.seqs {
+		if want := vals[(seq-1)/2%4]; vals[f.views[i]%4] != want {
+			t.Fatalf("seq %d proposed in view %d, want primary %s", seq, f.views[i], want.Addr)
+		}
+	}
+}
+
+//checkFSM rejects the proposal 'bad' and records sync requests.
+type checkFSM struct {
+	testFSM
+	syncs int
+}
+
+func (f *checkFSM) Verify(data []byte) error {
+	if string(data) == "bad" {
+		return fmt.Errorf("bad proposal")
+	}
+	return nil
+}
+
+func (f *checkFSM) TriggerSync() { f.syncs++ }
+
+//a validator without network,messages are handled by the test.
+func testNode(vals []*Validator, key ed25519.PrivateKey, fsm raft.FSM) *pbft {
+	p := &pbft{
+		cfg:     &Config{PrivKey: key, Validators: vals, ViewTimeout: time.Second},
+		fsm:     fsm,
+		vals:    vals,
+		byID:    make(map[string]*Validator),
+		log:     make(map[uint64]*instance),
+		vcs:     make(map[uint64]map[string]*Message),
+		ahead:   make(map[uint64]map[string]bool),
+		waiters: make(map[uint64]chan error),
+		clients: make(map[string]*client),
+	}
+	for _, v := range vals {
+		p.byID[v.ID] = v
+	}
+	p.self = p.byID[util.PubtoAddr(key.Public().(ed25519.PublicKey))]
+	p.f = (len(vals) - 1) / 3
+	p.quorum = 2*p.f + 1
+	return p
+}
+
+//handle a message signed by validator i,return the messages sent by the node.
+func (p *pbft) testHandle(m *Message, vals []*Validator, keys []ed25519.PrivateKey, i int) []*Message {
+	m.From = vals[i].ID
+	m.Sig = ed25519.Sign(keys[i], m.hash())
+	p.handle(m)
+	var sent []*Message
+	for len(p.queue) > 0 {
+		m := p.queue[0]
+		p.queue = p.queue[1:]
+		sent = append(sent, m)
+		p.handle(m)
+	}
+	return sent
+}
+
+//proposals are applied without gaps and a validator only votes for the proposals it checked.
+func TestCommitChecked(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	vals, keys := testValidators(t, 4, 19820)
+	f := &checkFSM{}
+	p := testNode(vals, keys[1], f)
+	others := []int{0, 2, 3}
+
+	//quorum of others commits seq 2 before seq 1 arrives.
+	data := []byte("2")
+	pp := &Message{Type: PrePrepareMsg, Seq: 2, Digest: digest(data), Data: data}
+	if sent := p.testHandle(pp, vals, keys, 0); len(sent) != 0 {
+		t.Fatalf("sent %v for a proposal after a gap", sent[0].Type)
+	}
+	for _, i := range others {
+		for _, typ := range []MsgType{PrepareMsg, CommitMsg} {
+			if sent := p.testHandle(&Message{Type: typ, Seq: 2, Digest: pp.Digest}, vals, keys, i); len(sent) != 0 {
+				t.Fatalf("sent %v for an unchecked proposal", sent[0].Type)
+			}
+		}
+	}
+	if seqs := f.applied(); len(seqs) != 0 || p.seq != 0 || f.syncs == 0 {
+		t.Fatalf("applied %v at seq %d,syncs %d", seqs, p.seq, f.syncs)
+	}
+
+	//seq 1 fills the gap,the committed seq 2 is applied after it.
+	commit := func(seq uint64, data string) []*Message {
+		pp := &Message{Type: PrePrepareMsg, Seq: seq, Digest: digest([]byte(data)), Data: []byte(data)}
+		sent := p.testHandle(pp, vals, keys, 0)
+		for _, i := range others {
+			for _, typ := range []MsgType{PrepareMsg, CommitMsg} {
+				sent = append(sent, p.testHandle(&Message{Type: typ, Seq: seq, Digest: pp.Digest}, vals, keys, i)...)
+			}
+		}
+		return sent
+	}
+	commit(1, "1")
+	if seqs := f.applied(); len(seqs) != 2 || seqs[0] != 1 || seqs[1] != 2 {
+		t.Fatalf("applied %v", seqs)
+	}
+
+	//seq 4 arrives before seq 3,it is checked and prepared after seq 3 is committed.
+	data = []byte("4")
+	if sent := p.testHandle(&Message{Type: PrePrepareMsg, Seq: 4, Digest: digest(data), Data: data}, vals, keys, 0); len(sent) != 0 {
+		t.Fatalf("sent %v for a proposal after a gap", sent[0].Type)
+	}
+	var prepared bool
+	for _, m := range commit(3, "3") {
+		if m.Type == PrepareMsg && m.Seq == 4 {
+			prepared = true
+		}
+	}
+	if !prepared {
+		t.Fatal("seq 4 not prepared after seq 3 committed")
+	}
+
+	//a rejected proposal gets no vote even when the others prepared it.
+	data = []byte("bad")
+	pp5 := &Message{Type: PrePrepareMsg, Seq: 5, Digest: digest(data), Data: data}
+	for _, i := range others {
+		p.testHandle(&Message{Type: CommitMsg, Seq: 4, Digest: digest([]byte("4"))}, vals, keys, i)
+	}
+	if p.seq != 4 {
+		t.Fatalf("seq %d", p.seq)
+	}
+	sent := p.testHandle(pp5, vals, keys, 0)
+	for _, i := range others {
+		sent = append(sent, p.testHandle(&Message{Type: PrepareMsg, Seq: 5, Digest: pp5.Digest}, vals, keys, i)...)
+	}
+	if len(sent) != 0 {
+		t.Fatalf("sent %v for a rejected proposal", sent[0].Type)
+	}
+}
+
+//messages of sequences beyond the log window are dropped and the node fetches the blocks instead.
+func TestLogWindow(t *testing.T) {
+	logger.Logger = zap.NewNop()
+
+	vals, keys := testValidators(t, 4, 19830)
+	f := &checkFSM{}
+	p := testNode(vals, keys[1], f)
+
+	data := []byte("far")
+	for _, seq := range []uint64{logWindow, logWindow + 1, 1 << 40} {
+		p.testHandle(&Message{Type: PrepareMsg, Seq: seq, Digest: digest(data)}, vals, keys, 2)
+	}
+	if _, ok := p.log[logWindow]; !ok || len(p.log) != 1 {
+		t.Fatalf("log has %d instances", len(p.log))
+	}
+	if f.syncs != 2 {
+		t.Fatalf("syncs %d", f.syncs)
+	}
+}
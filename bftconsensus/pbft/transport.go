@@ -0,0 +1,100 @@
+package pbft
+
+import (
+	"kortho/logger"
+	"net"
+	"net/rpc"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const dialTimeout = 3 * time.Second
+
+//Service receives pbft messages from other validators.
+type Service struct {
+	p *pbft
+}
+
+type client struct {
+	*rpc.Client
+}
+
+//Receive a message from other validator
+func (s *Service) Receive(m *Message, ok *bool) error {
+	*ok = true
+	s.p.msgC <- m
+	return nil
+}
+
+//listen on the node address
+func (p *pbft) listen() error {
+	srv := rpc.NewServer()
+	if err := srv.Register(&Service{p: p}); err != nil {
+		return err
+	}
+	lis, err := net.Listen("tcp", p.cfg.Address)
+	if err != nil {
+		return err
+	}
+	go srv.Accept(lis)
+	return nil
+}
+
+//send messages to all other validators
+func (p *pbft) broadcast(ms []*Message) {
+	for _, m := range ms {
+		for _, v := range p.vals {
+			if v.ID == p.self.ID {
+				continue
+			}
+			go p.send(v, m)
+		}
+	}
+}
+
+func (p *pbft) send(v *Validator, m *Message) {
+	c, err := p.getClient(v)
+	if err != nil {
+		logger.Debug("pbft dial error", zap.String("addr", v.Addr), zap.Error(err))
+		return
+	}
+	var ok bool
+	if err := c.Call("Service.Receive", m, &ok); err != nil {
+		logger.Debug("pbft send error", zap.String("addr", v.Addr), zap.Error(err))
+		p.dropClient(v, c)
+	}
+}
+
+func (p *pbft) getClient(v *Validator) (*client, error) {
+	p.mu.Lock()
+	c, ok := p.clients[v.ID]
+	p.mu.Unlock()
+	if ok {
+		return c, nil
+	}
+
+	conn, err := net.DialTimeout("tcp", v.Addr, dialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	c = &client{rpc.NewClient(conn)}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if old, ok := p.clients[v.ID]; ok {
+		c.Close()
+		return old, nil
+	}
+	p.clients[v.ID] = c
+	return c, nil
+}
+
+func (p *pbft) dropClient(v *Validator, c *client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.clients[v.ID] == c {
+		delete(p.clients, v.ID)
+		c.Close()
+	}
+}
@@ -0,0 +1,166 @@
+//Package pbft implements a practical byzantine fault tolerant consensus engine.
+package pbft
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/raft"
+)
+
+var (
+	errNotPrimary   = errors.New("pbft: not the primary")
+	errViewChanged  = errors.New("pbft: view changed before the proposal committed")
+	errTimeout      = errors.New("pbft: proposal timeout")
+	errBusy         = errors.New("pbft: a proposal is in progress")
+	errStaticPeers  = errors.New("pbft: validator set is changed by committed chain state only")
+	errRotation     = errors.New("pbft: the primary rotates by the committed sequence only")
+	errUnknownPeer  = errors.New("pbft: unknown validator")
+	errBadSignature = errors.New("pbft: bad signature")
+)
+
+//Validator is a member of the validator set.
+type Validator struct {
+	ID     string            //kto address of the validator key
+	Addr   string            //pbft listen address
+	PubKey ed25519.PublicKey //validator public key
+}
+
+//Config for pbft
+type Config struct {
+	Address     string             //node address
+	PrivKey     ed25519.PrivateKey //validator private key
+	Validators  []*Validator       //validator set,in the same order on every node
+	Height      uint64             //latest committed height
+	ViewTimeout time.Duration      //start a view change when no block is committed within this time
+	Term        uint64             //the primary rotates after every Term sequences,0 never rotates
+}
+
+//Verifier is implemented by fsm which checks a proposal before voting for it.
+type Verifier interface {
+	Verify([]byte) error
+}
+
+//Certifier is implemented by fsm which wants validators to sign its own payload when committing,
+//the signatures are passed to Apply in raft.Log.Extensions as a json encoded CommitCert.
+type Certifier interface {
+	CommitPayload([]byte) ([]byte, error)
+}
+
+//CommitCert contains the signatures of the validators committed a proposal.
+type CommitCert struct {
+	View       uint64
+	Seq        uint64
+	Signatures []*CommitSig
+}
+
+//CommitSig is a validator signature over the commit payload.
+type CommitSig struct {
+	Validator string
+	Signature []byte
+}
+
+//Heighter is implemented by fsm which may commit blocks outside of consensus.
+type Heighter interface {
+	Height() uint64
+}
+
+//ValidatorSet is implemented by fsm whose blocks committed outside of consensus may change the validator set.
+type ValidatorSet interface {
+	Validators() ([]string, error)
+}
+
+//Syncer is implemented by fsm which fetches the proposals it missed outside of consensus,
+//it is asked to sync when a proposal after a gap is committed.
+type Syncer interface {
+	TriggerSync()
+}
+
+//MsgType is the type of a pbft message
+type MsgType uint8
+
+const (
+	PrePrepareMsg MsgType = iota
+	PrepareMsg
+	CommitMsg
+	ViewChangeMsg
+	NewViewMsg
+)
+
+//Message is signed by its sender.
+type Message struct {
+	Type   MsgType
+	View   uint64
+	Seq    uint64
+	Digest []byte
+	Data   []byte //proposal,only for pre-prepare
+	From   string //validator id
+	Sig    []byte
+
+	CertSig     []byte       //commit: signature over the commit payload of fsm
+	Prepared    *Certificate //view change: the prepared proposal of Seq+1
+	ViewChanges []*Message   //new view: view change messages from a quorum
+}
+
+//Certificate proves a proposal was prepared or committed by a quorum.
+type Certificate struct {
+	PrePrepare *Message
+	Votes      []*Message
+}
+
+type instance struct {
+	prePrepare *Message
+	payload    []byte //commit payload of fsm
+	prepares   map[string]*Message
+	commits    map[string]*Message
+	verified   bool //the proposal is checked by fsm
+	prepared   bool
+	committed  bool
+	sentCommit bool
+}
+
+type proposal struct {
+	data []byte
+	done chan error
+}
+
+//status can be read without blocking the event loop,the fsm calls GetLeader while applying.
+type status struct {
+	view       uint64
+	seq        uint64
+	primary    string
+	changing   bool
+	validators int
+	quorum     int
+}
+
+type pbft struct {
+	cfg       *Config
+	fsm       raft.FSM
+	self      *Validator
+	vals      []*Validator
+	byID      map[string]*Validator
+	f         int //max faulty nodes
+	quorum    int //2f+1
+	view      uint64
+	seq       uint64 //latest committed sequence
+	changing  bool   //in view change
+	target    uint64 //target view of the view change
+	log       map[uint64]*instance
+	vcs       map[uint64]map[string]*Message //view change messages by view
+	ahead     map[uint64]map[string]bool     //validators seen working in a higher view
+	prepared  *Certificate                   //prepared but not committed proposal
+	expected  *Message                       //proposal must be proposed again in the new view
+	waiters   map[uint64]chan error
+	progress  time.Time
+	queue     []*Message
+	msgC      chan *Message
+	propC     chan *proposal
+	transferC chan struct{}
+	st        atomic.Value
+	mu        sync.Mutex
+	clients   map[string]*client
+}
@@ -2,7 +2,10 @@
 package protocol
 
 import (
+	"fmt"
+	"kortho/bftconsensus/pbft"
 	"kortho/logger"
+	"kortho/util"
 	"net"
 	"time"
 
@@ -12,8 +15,23 @@ import (
 	"go.uber.org/zap"
 )
 
-//New new a raft node
-func New(cfg *Config, fsm raft.FSM) (*node, error) {
+//New new a consensus node of the configured engine
+func New(cfg *Config, fsm raft.FSM) (Consensus, error) {
+	switch cfg.Engine {
+	case "", EngineRaft:
+		n, err := newRaft(cfg, fsm)
+		if err != nil {
+			return nil, err
+		}
+		return n, nil
+	case EnginePBFT:
+		return newPBFT(cfg, fsm)
+	}
+	return nil, fmt.Errorf("unknown consensus engine: %s", cfg.Engine)
+}
+
+//new a raft node
+func newRaft(cfg *Config, fsm raft.FSM) (*node, error) {
 	logger.Info("Init raft...")
 	trans, err := newRaftTransport(cfg.Address)
 	if err != nil {
@@ -65,6 +83,32 @@ func New(cfg *Config, fsm raft.FSM) (*node, error) {
 	return &node{fsm: fsm, Raft: r}, nil
 }
 
+//new a pbft node
+func newPBFT(cfg *Config, fsm raft.FSM) (Consensus, error) {
+	var vals []*pbft.Validator
+	for _, s := range cfg.Validators {
+		v, err := pbft.ParseValidator(s)
+		if err != nil {
+			logger.Error("ParseValidator error", zap.Error(err), zap.String("validator", s))
+			return nil, err
+		}
+		vals = append(vals, v)
+	}
+	p, err := pbft.New(&pbft.Config{
+		Address:     cfg.Address,
+		PrivKey:     util.Decode(cfg.PrivKey),
+		Validators:  vals,
+		Height:      cfg.Height,
+		ViewTimeout: cfg.ViewTimeout,
+		Term:        cfg.Term,
+	}, fsm)
+	if err != nil {
+		logger.Error("pbft New error", zap.Error(err))
+		return nil, err
+	}
+	return p, nil
+}
+
 //build raft transport
 func newRaftTransport(address string) (*raft.NetworkTransport, error) {
 	addr, err := net.ResolveTCPAddr("tcp", address)
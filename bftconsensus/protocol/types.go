@@ -2,9 +2,18 @@
 package protocol
 
 import (
+	"kortho/logger"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/raft"
+	"go.uber.org/zap"
+)
+
+//consensus engines
+const (
+	EngineRaft = "raft"
+	EnginePBFT = "pbft"
 )
 
 type Consensus interface {
@@ -16,13 +25,18 @@ type Consensus interface {
 	DelPeer(string) error
 	//add a node
 	AddPeer(string, string) error
+	//reconfigure the cluster with the committed validator set,"address@host:port"
+	SetValidators([]string) error
 	//get leader address
 	GetLeader() string
 	LeaderShipTransferToF() error
+	//transfer the leadership to the node with address
+	LeaderShipTransferTo(string) error
 	GetStats() map[string]string
 }
 
 type Config struct {
+	Engine            string //consensus engine,raft or pbft
 	Join              bool   //use for distinguishing leader does some actions.
 	Address           string //node address
 	SnapshotThreshold uint64 //Snapshot threshold
@@ -31,6 +45,12 @@ type Config struct {
 	SnapDir           string //Snap location
 	LogsDir           string //raft log location
 	StableDir         string //raft stable location
+
+	PrivKey     string        //pbft validator private key
+	Validators  []string      //pbft validator set,"address@host:port"
+	ViewTimeout time.Duration //pbft view change timeout
+	Height      uint64        //latest committed height
+	Term        uint64        //number of blocks every proposer proposes in turn,0 never rotates
 }
 
 type node struct {
@@ -77,6 +97,60 @@ func (a *node) LeaderShipTransferToF() error {
 	return nil
 }
 
+func (a *node) LeaderShipTransferTo(addr string) error {
+	if err := a.LeadershipTransferToServer(raft.ServerID(addr), raft.ServerAddress(addr)); err.Error() != nil {
+		return err.Error()
+	}
+	return nil
+}
+
 func (a *node) GetStats() map[string]string {
 	return a.Stats()
 }
+
+//SetValidators makes the raft configuration match the validator set,only the leader does it.
+//It does not wait for the configuration change,the fsm calls it while applying a log.
+func (a *node) SetValidators(vals []string) error {
+	if a.State() != raft.Leader {
+		return nil
+	}
+	var addrs []string
+	for _, v := range vals {
+		addrs = append(addrs, v[strings.LastIndex(v, "@")+1:])
+	}
+	go a.reconfigure(addrs)
+	return nil
+}
+
+//add the missing voters first,then remove the others.
+func (a *node) reconfigure(addrs []string) {
+	f := a.GetConfiguration()
+	if err := f.Error(); err != nil {
+		logger.Error("GetConfiguration error", zap.Error(err))
+		return
+	}
+	have := make(map[raft.ServerID]bool)
+	for _, s := range f.Configuration().Servers {
+		have[s.ID] = true
+	}
+	want := make(map[raft.ServerID]bool)
+	for _, addr := range addrs {
+		want[raft.ServerID(addr)] = true
+		if have[raft.ServerID(addr)] {
+			continue
+		}
+		logger.Info("raft add voter", zap.String("addr", addr))
+		if err := a.AddPeer(addr, addr); err != nil {
+			logger.Error("raft add voter error", zap.String("addr", addr), zap.Error(err))
+		}
+	}
+	for id := range have {
+		if want[id] {
+			continue
+		}
+		logger.Info("raft remove server", zap.String("id", string(id)))
+		if err := a.DelPeer(string(id)); err != nil {
+			logger.Error("raft remove server error", zap.String("id", string(id)), zap.Error(err))
+		}
+	}
+}
@@ -3,15 +3,30 @@ package block
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"kortho/transaction"
 	"kortho/types"
+	"kortho/util/codec"
+	"kortho/util/merkle"
 	"kortho/util/miscellaneous"
 
 	"golang.org/x/crypto/sha3"
 )
 
+const (
+	// CodecVersion 块二进制编码的版本号，写在编码的第一个字节
+	CodecVersion byte = 1
+	// BinaryVersion 从这个块版本开始，默克尔根使用二进制编码的交易，块hash是块头的摘要
+	BinaryVersion uint64 = 2
+)
+
+// ErrCodecVersion 不支持的编码版本
+var ErrCodecVersion = errors.New("block: unknown codec version")
+
 // Block 块数据结构
 type Block struct {
 	Height       uint64                     `json:"height"`    //当前块号
@@ -22,6 +37,7 @@ type Block struct {
 	Version      uint64                     `json:"version"`   //版本号
 	Timestamp    int64                      `json:"timestamp"` //时间戳
 	Miner        types.Address              `json:"miner"`     //矿工地址
+	StateRoot    []byte                     `json:"stateRoot"` //上链后账户状态树的根
 }
 
 func newBlock(height uint64, prevHash []byte, transactions []*transaction.Transaction) *Block {
@@ -33,30 +49,115 @@ func newBlock(height uint64, prevHash []byte, transactions []*transaction.Transa
 	return block
 }
 
-// Serialize 使用json格式进行序列化
+// Serialize 使用二进制格式进行序列化
 func (b *Block) Serialize() []byte {
-	data, err := json.Marshal(b)
-	if err != nil {
-		return nil
+	e := codec.NewEncoder()
+	e.Byte(CodecVersion)
+	e.Uint64(b.Height)
+	e.Bytes(b.PrevHash)
+	e.Bytes(b.Hash)
+	e.Bytes(b.Root)
+	e.Uint64(b.Version)
+	e.Int64(b.Timestamp)
+	e.Fixed(b.Miner[:])
+	e.Bytes(b.StateRoot)
+	e.Uint32(uint32(len(b.Transactions)))
+	for _, tx := range b.Transactions {
+		tx.Encode(e)
 	}
-	return data
+	return e.Result()
 }
 
-// Deserialize 对json格式的块数据反序列化
+// Deserialize 对块数据反序列化，兼容升级前的json格式
 func Deserialize(data []byte) (*Block, error) {
+	if transaction.IsLegacy(data) {
+		var block Block
+		if err := json.Unmarshal(data, &block); err != nil {
+			return nil, err
+		}
+		return &block, nil
+	}
+
+	d := codec.NewDecoder(data)
+	if v := d.Byte(); d.Err() == nil && v != CodecVersion {
+		return nil, ErrCodecVersion
+	}
 	var block Block
-	if err := json.Unmarshal(data, &block); err != nil {
+	block.Height = d.Uint64()
+	block.PrevHash = d.Bytes()
+	block.Hash = d.Bytes()
+	block.Root = d.Bytes()
+	block.Version = d.Uint64()
+	block.Timestamp = d.Int64()
+	copy(block.Miner[:], d.Fixed(types.AddressSize))
+	block.StateRoot = d.Bytes()
+	n := d.Uint32()
+	if d.Err() == nil && n > 0 {
+		block.Transactions = make([]*transaction.Transaction, 0, n)
+	}
+	for i := uint32(0); i < n && d.Err() == nil; i++ {
+		tx, err := transaction.Decode(d)
+		if err != nil {
+			return nil, err
+		}
+		block.Transactions = append(block.Transactions, tx)
+	}
+	if err := d.Finish(); err != nil {
 		return nil, err
 	}
 	return &block, nil
 }
 
+// TxsBytes 默克尔树叶子节点的数据，BinaryVersion之前的块使用json格式
+func (b *Block) TxsBytes() [][]byte {
+	txsBytes := make([][]byte, 0, len(b.Transactions))
+	for _, tx := range b.Transactions {
+		if b.Version < BinaryVersion {
+			data, _ := json.Marshal(tx)
+			txsBytes = append(txsBytes, data)
+		} else {
+			txsBytes = append(txsBytes, tx.Serialize())
+		}
+	}
+	return txsBytes
+}
+
 //SetHash 对块数据摘要出hash
 func (b *Block) SetHash() {
+	b.Hash = b.computeHash()
+}
+
+// computeHash BinaryVersion开始的块hash是块头的摘要，之前的块对交易数据摘要
+func (b *Block) computeHash() []byte {
+	if b.Version >= BinaryVersion {
+		return b.Header().ComputeHash()
+	}
 	heightBytes := miscellaneous.E64func(b.Height)
 	txsBytes, _ := json.Marshal(b.Transactions)
 	timeBytes := miscellaneous.E64func(uint64(b.Timestamp))
 	blockBytes := bytes.Join([][]byte{heightBytes, b.PrevHash, txsBytes, timeBytes}, []byte{})
+	//没有状态根的老块hash保持不变
+	if len(b.StateRoot) > 0 {
+		blockBytes = append(blockBytes, b.StateRoot...)
+	}
 	hash := sha3.Sum256(blockBytes)
-	b.Hash = hash[:]
+	return hash[:]
+}
+
+// TxRoot 交易的默克尔根，没有交易时为nil
+func (b *Block) TxRoot() []byte {
+	return merkle.New(sha256.New(), b.TxsBytes()).GetMtHash()
+}
+
+// CheckHash 检查块hash与块数据一致，BinaryVersion开始的块同时检查默克尔根
+func (b *Block) CheckHash() error {
+	if b.Version >= BinaryVersion {
+		if root := b.TxRoot(); !bytes.Equal(root, b.Root) {
+			return fmt.Errorf("block %d root %x,want %x", b.Height, b.Root, root)
+		}
+	}
+	if hash := b.computeHash(); !bytes.Equal(hash, b.Hash) {
+		return fmt.Errorf("block %d hash %x,want %x", b.Height, b.Hash, hash)
+	}
+	return nil
 }
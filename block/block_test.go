@@ -0,0 +1,97 @@
+package block
+
+import (
+	"bytes"
+	"encoding/json"
+	"kortho/transaction"
+	"kortho/types"
+	"reflect"
+	"testing"
+)
+
+func testBlock(version uint64) *Block {
+	var from, to types.Address
+	copy(from[:], "Kto9sFhbjDdjEHvcdH6n9dtQws1m4ptsAWAy7DhqGdrUFai")
+	copy(to[:], "Kto2YR7g9Jk2DhgzdxAfvNNZ9n6RGgTLkLoVLpHrD1Rj3ZW")
+	tx := transaction.ZNewTransaction(1, 100, from, to)
+	order := transaction.ZNewTransaction(2, 200, from, to, transaction.WithOrder(&transaction.Order{
+		ID:        []byte("order"),
+		Address:   from,
+		Price:     10,
+		Hash:      []byte{1, 2, 3},
+		Signature: []byte{4, 5, 6},
+		Tradename: "name",
+	}))
+	order.Signature = []byte{7, 8, 9}
+	b := &Block{
+		Height:       10,
+		PrevHash:     []byte{1, 2, 3},
+		Transactions: []*transaction.Transaction{tx, order, transaction.NewCoinBaseTransaction(to, 50)},
+		Root:         []byte{4, 5, 6},
+		Version:      version,
+		Timestamp:    1600000000,
+		Miner:        to,
+		StateRoot:    []byte{7, 8, 9},
+	}
+	b.SetHash()
+	return b
+}
+
+func TestSerialize(t *testing.T) {
+	b := testBlock(BinaryVersion)
+	data := b.Serialize()
+	nb, err := Deserialize(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(b, nb) {
+		t.Fatalf("block changed:\n%+v\n%+v", b, nb)
+	}
+	if !bytes.Equal(nb.Serialize(), data) {
+		t.Fatal("encoding is not deterministic")
+	}
+	nb.SetHash()
+	if !bytes.Equal(nb.Hash, b.Hash) {
+		t.Fatal("hash changed")
+	}
+
+	if _, err := Deserialize(data[:len(data)-1]); err == nil {
+		t.Fatal("decoded truncated data")
+	}
+	if _, err := Deserialize(append(data, 0)); err == nil {
+		t.Fatal("decoded trailing data")
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	b := testBlock(1)
+	data, _ := json.Marshal(b)
+	nb, err := Deserialize(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(b, nb) {
+		t.Fatalf("block changed:\n%+v\n%+v", b, nb)
+	}
+	//old blocks keep the json hash
+	nb, _ = Deserialize(nb.Serialize())
+	nb.SetHash()
+	if !bytes.Equal(nb.Hash, b.Hash) {
+		t.Fatal("hash of legacy block changed")
+	}
+}
+
+func TestHeader(t *testing.T) {
+	b := testBlock(BinaryVersion)
+	h := b.Header()
+	nh, err := DeserializeHeader(h.Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(h, nh) {
+		t.Fatalf("header changed:\n%+v\n%+v", h, nh)
+	}
+	if nh.TxCount != 3 {
+		t.Fatalf("tx count = %d", nh.TxCount)
+	}
+}
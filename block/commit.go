@@ -0,0 +1,95 @@
+package block
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"kortho/types"
+	"kortho/util/miscellaneous"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// Commit 块的提交证书，由同意该块的验证者对块高和块hash的签名组成
+type Commit struct {
+	Height     uint64       `json:"height"`     //块号
+	Hash       []byte       `json:"hash"`       //块hash
+	Round      uint64       `json:"round"`      //提交时的共识轮次(pbft的view)
+	Signatures []*CommitSig `json:"signatures"` //验证者签名
+}
+
+// CommitSig 单个验证者的签名
+type CommitSig struct {
+	Validator string `json:"validator"` //验证者地址
+	Signature []byte `json:"signature"` //对CommitSignBytes的签名
+}
+
+// CommitSignBytes 验证者签名的内容：sha3(height || hash)
+func CommitSignBytes(height uint64, hash []byte) []byte {
+	h := sha3.Sum256(bytes.Join([][]byte{miscellaneous.E64func(height), hash}, []byte{}))
+	return h[:]
+}
+
+// Serialize 使用json格式进行序列化
+func (c *Commit) Serialize() []byte {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// DeserializeCommit 对json格式的提交证书反序列化
+func DeserializeCommit(data []byte) (*Commit, error) {
+	var c Commit
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Verify 检查证书属于块b，并且validators中至少quorum个不同的验证者签名有效
+func (c *Commit) Verify(b *Block, validators []string, quorum int) error {
+	return c.verify(b.Height, b.Hash, validators, quorum)
+}
+
+// VerifyHeader 检查证书属于块头h，块头的hash与内容一致，并且validators中至少quorum个不同的验证者签名有效，
+// 通过后可以用块头的默克尔根和状态根验证交易和账户证明
+func (c *Commit) VerifyHeader(h *BlockHeader, validators []string, quorum int) error {
+	if !bytes.Equal(h.ComputeHash(), h.Hash) {
+		return errors.New("header hash does not match header")
+	}
+	return c.verify(h.Height, h.Hash, validators, quorum)
+}
+
+func (c *Commit) verify(height uint64, hash []byte, validators []string, quorum int) error {
+	if c.Height != height || !bytes.Equal(c.Hash, hash) {
+		return errors.New("commit does not match block")
+	}
+
+	msg := CommitSignBytes(c.Height, c.Hash)
+	allowed := make(map[string]bool, len(validators))
+	for _, v := range validators {
+		allowed[v] = true
+	}
+	signed := make(map[string]bool)
+	for _, sig := range c.Signatures {
+		if !allowed[sig.Validator] || signed[sig.Validator] {
+			continue
+		}
+		addr, err := types.StringToAddress(sig.Validator)
+		if err != nil || !addr.Verify() {
+			continue
+		}
+		if ed25519.Verify(addr.ToPublicKey(), msg, sig.Signature) {
+			signed[sig.Validator] = true
+		}
+	}
+	if len(signed) < quorum {
+		return fmt.Errorf("not enough signatures: %d < %d", len(signed), quorum)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package block
+
+import (
+	"kortho/types"
+	"kortho/util/codec"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// BlockHeader 块头，不含交易数据，和块体分开存储
+type BlockHeader struct {
+	Height    uint64        `json:"height"`    //当前块号
+	PrevHash  []byte        `json:"prevHash"`  //上一块的hash
+	Hash      []byte        `json:"hash"`      //当前块hash
+	Root      []byte        `json:"root"`      //交易的默克根
+	StateRoot []byte        `json:"stateRoot"` //上链后账户状态树的根
+	Version   uint64        `json:"version"`   //版本号
+	Timestamp int64         `json:"timestamp"` //时间戳
+	Miner     types.Address `json:"miner"`     //矿工地址
+	TxCount   uint32        `json:"txCount"`   //交易数量
+}
+
+// Header 获取块的块头
+func (b *Block) Header() *BlockHeader {
+	return &BlockHeader{
+		Height:    b.Height,
+		PrevHash:  b.PrevHash,
+		Hash:      b.Hash,
+		Root:      b.Root,
+		StateRoot: b.StateRoot,
+		Version:   b.Version,
+		Timestamp: b.Timestamp,
+		Miner:     b.Miner,
+		TxCount:   uint32(len(b.Transactions)),
+	}
+}
+
+// ComputeHash 对块头中除hash以外的字段摘要，交易由默克尔根确定，只有BinaryVersion开始的块头可以计算，之前的块返回nil
+func (h *BlockHeader) ComputeHash() []byte {
+	if h.Version < BinaryVersion {
+		return nil
+	}
+	e := codec.NewEncoder()
+	e.Uint64(h.Height)
+	e.Bytes(h.PrevHash)
+	e.Bytes(h.Root)
+	e.Bytes(h.StateRoot)
+	e.Uint64(h.Version)
+	e.Int64(h.Timestamp)
+	e.Fixed(h.Miner[:])
+	e.Uint32(h.TxCount)
+	hash := sha3.Sum256(e.Result())
+	return hash[:]
+}
+
+// Serialize 使用二进制格式序列化块头
+func (h *BlockHeader) Serialize() []byte {
+	e := codec.NewEncoder()
+	e.Byte(CodecVersion)
+	e.Uint64(h.Height)
+	e.Bytes(h.PrevHash)
+	e.Bytes(h.Hash)
+	e.Bytes(h.Root)
+	e.Bytes(h.StateRoot)
+	e.Uint64(h.Version)
+	e.Int64(h.Timestamp)
+	e.Fixed(h.Miner[:])
+	e.Uint32(h.TxCount)
+	return e.Result()
+}
+
+// DeserializeHeader 对块头数据反序列化
+func DeserializeHeader(data []byte) (*BlockHeader, error) {
+	d := codec.NewDecoder(data)
+	if v := d.Byte(); d.Err() == nil && v != CodecVersion {
+		return nil, ErrCodecVersion
+	}
+	var h BlockHeader
+	h.Height = d.Uint64()
+	h.PrevHash = d.Bytes()
+	h.Hash = d.Bytes()
+	h.Root = d.Bytes()
+	h.StateRoot = d.Bytes()
+	h.Version = d.Uint64()
+	h.Timestamp = d.Int64()
+	copy(h.Miner[:], d.Fixed(types.AddressSize))
+	h.TxCount = d.Uint32()
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
@@ -3,6 +3,7 @@ package blockchain
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -17,6 +18,7 @@ import (
 	"kortho/util/miscellaneous"
 	"kortho/util/store"
 	"kortho/util/store/bg"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
@@ -60,6 +62,10 @@ var (
 	HeightPrefix = []byte("blockheight")
 	// TxListName 交易列表的名字
 	TxListName = []byte("txlist")
+	// CommitPrefix 块提交证书key的前缀
+	CommitPrefix = []byte("blockcommit")
+	// HeaderPrefix 块头key的前缀，key为前缀+块hash
+	HeaderPrefix = []byte("blockheader")
 )
 
 // Blockchain 区块链数据结构
@@ -67,6 +73,14 @@ type Blockchain struct {
 	mu  sync.RWMutex
 	db  store.DB
 	cdb store.DB
+
+	crashAt func(stage string) error //测试时在提交的各个阶段注入崩溃，为nil时不注入
+}
+
+// reader 数据库和事务共有的读操作，检查块时可以读取已提交的数据，也可以读取事务中回滚后的数据
+type reader interface {
+	Get([]byte) ([]byte, error)
+	Mget([]byte, []byte) ([]byte, error)
 }
 
 type TXindex struct {
@@ -80,12 +94,42 @@ func New() *Blockchain {
 	bgc := bg.New("contract.db")
 	bc := &Blockchain{db: bgs, cdb: bgc}
 
+	if err := bc.recoverContract(); err != nil {
+		logger.Error("failed to recover contract db", zap.Error(err))
+	}
+	return bc
+}
+
+// Open 打开目录dir中的区块数据库和合约数据库
+func Open(dir string) *Blockchain {
+	bc := &Blockchain{
+		db:  bg.New(filepath.Join(dir, BlockchainDBName)),
+		cdb: bg.New(filepath.Join(dir, ContractDBName)),
+	}
+	if err := bc.recoverContract(); err != nil {
+		logger.Error("failed to recover contract db", zap.Error(err))
+	}
 	return bc
 }
 
+// Close 关闭区块数据库和合约数据库
+func (bc *Blockchain) Close() error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if err := bc.db.Close(); err != nil {
+		return err
+	}
+	return bc.cdb.Close()
+}
+
 // GetBlockchain 获取blockchain对象
 func GetBlockchain() *Blockchain {
-	return &Blockchain{db: bg.New(BlockchainDBName), cdb: bg.New(ContractDBName)}
+	bc := &Blockchain{db: bg.New(BlockchainDBName), cdb: bg.New(ContractDBName)}
+	if err := bc.recoverContract(); err != nil {
+		logger.Error("failed to recover contract db", zap.Error(err))
+	}
+	return bc
 }
 
 // NewBlock 通过输入的交易，新建block，minaddr,Ds,Cm,QTJ分别是矿工，社区，技术和趣淘鲸的地址
@@ -106,12 +150,18 @@ func (bc *Blockchain) NewBlock(txs []*transaction.Transaction, minaddr, Ds, Cm,
 			logger.Error("failed to get hash", zap.Error(err), zap.Uint64("previous height", prevHeight))
 			return nil, err
 		}
-	} else {
-		prevHash = []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+	} else if prevHash, err = firstPrevHash(bc.db.Get(GenesisKey)); err != nil {
+		logger.Error("failed to get genesis hash", zap.Error(err))
+		return nil, err
 	}
 
 	//出币分配
-	txs = Distr(txs, minaddr, Ds, Cm, QTJ, height)
+	emission, err := getEmission(bc.db.Get(EmissionKey))
+	if err != nil {
+		logger.Error("failed to get emission schedule", zap.Error(err))
+		return nil, err
+	}
+	txs = emission.Distr(txs, minaddr, Ds, Cm, QTJ, height)
 
 	/* 	//锁仓收益分红规则，社区分配收益到链上执行，每天执行一次
 	   	//分配锁仓收益
@@ -121,41 +171,85 @@ func (bc *Blockchain) NewBlock(txs []*transaction.Transaction, minaddr, Ds, Cm,
 	   			logger.Error("failed from shareoutbouns to do :", zap.Error(err))
 	   		}
 	   	} */
-	//生成默克尔根,如果没有交易的话，调用GetMtHash会painc
-	txBytesList := make([][]byte, 0, len(txs))
 	for _, tx := range txs {
 		tx.BlockNumber = height
-		txBytesList = append(txBytesList, tx.Serialize())
 	}
-	tree := merkle.New(sha256.New(), txBytesList)
-	root := tree.GetMtHash()
+
+	//本地时钟落后时使用允许的最小时间戳，保证时间戳严格递增
+	timestamp := time.Now().Unix()
+	if min, err := minTimestamp(bc.db, height); err != nil {
+		logger.Error("failed to get min timestamp", zap.Error(err))
+		return nil, err
+	} else if timestamp < min {
+		timestamp = min
+	}
 
 	block := &block.Block{
 		Height:       height,
 		PrevHash:     prevHash,
 		Transactions: txs,
-		Root:         root,
-		Version:      1,
-		Timestamp:    time.Now().Unix(),
+		Version:      block.BinaryVersion,
+		Timestamp:    timestamp,
 		Miner:        minaddr,
 	}
+
+	//生成默克尔根,如果没有交易的话，调用GetMtHash会painc
+	tree := merkle.New(sha256.New(), block.TxsBytes())
+	block.Root = tree.GetMtHash()
+
+	//状态根
+	if block.StateRoot, err = bc.StateRoot(block); err != nil {
+		logger.Error("failed to calculate state root", zap.Error(err))
+		return nil, err
+	}
 	block.SetHash()
 	logger.Info("end to new block")
 	return block, nil
 }
 
-// AddBlock 向数据库添加新的block数据，minaddr矿工地址
-func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
+// AddBlock 向数据库添加新的block数据，手续费记入block.Miner。
+// c不为nil时用块之前的验证者集合检查提交证书，并和块在同一个事务中保存，证书无效或保存失败时块不上链
+func (bc *Blockchain) AddBlock(block *block.Block, c *block.Commit) error {
 	logger.Info("Start to commit block...")
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 
 	DBTransaction := bc.db.NewTransaction()
 	defer DBTransaction.Cancel()
+	CDBTransaction := bc.cdb.NewTransaction()
+	defer CDBTransaction.Cancel()
+
+	if c != nil {
+		if err := verifyCommit(DBTransaction, block, c); err == errNoValidators {
+			//没有验证者集合的链无法检查证书，只提交块
+			logger.Info("skip commit certificate", zap.Uint64("height", block.Height), zap.Error(err))
+			c = nil
+		} else if err != nil {
+			logger.Error("failed to verify commit certificate", zap.Uint64("height", block.Height), zap.Error(err))
+			return err
+		}
+	}
+
+	if err := bc.addBlock(DBTransaction, CDBTransaction, block); err != nil {
+		return err
+	}
+	if c != nil {
+		if err := DBTransaction.Set(append(CommitPrefix, miscellaneous.E64func(block.Height)...), c.Serialize()); err != nil {
+			logger.Error("failed to set commit certificate", zap.Uint64("height", block.Height), zap.Error(err))
+			return err
+		}
+	}
+
+	logger.Info("end to commit block")
+	return bc.commit(DBTransaction, CDBTransaction)
+}
+
+// addBlock 在DBTransaction和CDBTransaction中添加block，同时写入撤销记录
+func (bc *Blockchain) addBlock(DBTransaction, CDBTransaction store.Transaction, block *block.Block) error {
 	var err error
 	var height, prevHeight uint64
 	//拿出块高
-	prevHeight, err = bc.getHeight()
+	prevHeight, err = getUint64(DBTransaction.Get(HeightKey))
 	if err != nil {
 		logger.Error("failed to get height", zap.Error(err))
 		return err
@@ -165,6 +259,18 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 	if block.Height != height {
 		return fmt.Errorf("height error:current height=%d,commit height=%d", prevHeight, block.Height)
 	}
+	if height == 1 {
+		prevHash, err := firstPrevHash(DBTransaction.Get(GenesisKey))
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(block.PrevHash, prevHash) {
+			return errors.New("genesis block belongs to another chain")
+		}
+	}
+
+	//记录块修改的所有key的原始数据
+	startJournal(DBTransaction, CDBTransaction)
 
 	//高度->哈希
 	hash := block.Hash
@@ -179,10 +285,43 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 		return err
 	}
 
+	//哈希-> 块头
+	if err = DBTransaction.Set(append(HeaderPrefix, hash...), block.Header().Serialize()); err != nil {
+		logger.Error("Failed to set block header", zap.Error(err))
+		return err
+	}
+
 	//重置块高
 	DBTransaction.Del(HeightKey)
 	DBTransaction.Set(HeightKey, miscellaneous.E64func(height))
 
+	if err = bc.applyTransactions(DBTransaction, CDBTransaction, block); err != nil {
+		return err
+	}
+
+	//状态树
+	root, err := bc.commitState(DBTransaction, block)
+	if err != nil {
+		logger.Error("failed to commit state", zap.Error(err))
+		return err
+	}
+	//没有状态根的旧块不校验
+	if len(block.StateRoot) > 0 && !bytes.Equal(root, block.StateRoot) {
+		logger.Error("state root not equal", zap.Uint64("height", height), zap.String("block state root", hex.EncodeToString(block.StateRoot)),
+			zap.String("state root", hex.EncodeToString(root)))
+		return fmt.Errorf("state root error:block %d state root=%x,state root=%x", height, block.StateRoot, root)
+	}
+
+	//撤销记录
+	if err = writeUndo(DBTransaction, CDBTransaction, height); err != nil {
+		logger.Error("Failed to set undo record", zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// applyTransactions 把block中的交易写入DBTransaction，合约写入CDBTransaction，CDBTransaction为nil时不执行合约
+func (bc *Blockchain) applyTransactions(DBTransaction, CDBTransaction store.Transaction, block *block.Block) error {
 	// 获取pck和dkto的总数
 	pckTotal, err := getPckTotal(DBTransaction)
 	if err != nil {
@@ -196,6 +335,17 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 		return err
 	}
 
+	// 获取并更新发行总量和锁仓总量
+	minted, frozen, err := getSupplyTotals(DBTransaction, block.Height-1)
+	if err != nil {
+		logger.Error("failed to get supply", zap.Error(err))
+		return err
+	}
+	if minted, frozen, err = addSupply(block, minted, frozen); err != nil {
+		logger.Error("failed to add supply", zap.Error(err), zap.Uint64("height", block.Height))
+		return err
+	}
+
 	for index, tx := range block.Transactions {
 		if tx.IsCoinBaseTransaction() {
 			if err = setTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(index)); err != nil {
@@ -229,7 +379,7 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 			}
 
 			var frozenBalBytes []byte
-			frozenBal, _ := bc.getFreezeBalance(tx.To.Bytes())
+			frozenBal, _ := getUint64(DBTransaction.Mget(FreezeKey, tx.To.Bytes()))
 			if tx.IsFreezeTransaction() {
 				frozenBalBytes = miscellaneous.E64func(tx.Amount + frozenBal)
 				/* 			//投票记录处理
@@ -243,6 +393,26 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 					zap.Uint64("amount", tx.Amount))
 				return err
 			}
+		} else if tx.IsValidatorTransaction() {
+			if err := setTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(index)); err != nil {
+				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("validator", tx.Script))
+				return err
+			}
+
+			nonce := tx.Nonce + 1
+			if err := setNonce(DBTransaction, tx.From.Bytes(), miscellaneous.E64func(nonce)); err != nil {
+				logger.Error("Failed to set nonce", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("validator", tx.Script))
+				return err
+			}
+
+			//修改验证者集合，共识层提交该块后按新的集合重新配置
+			if err := applyValidatorTransaction(DBTransaction, tx); err != nil {
+				logger.Error("Failed to change validators", zap.Error(err), zap.Int32("tag", tx.Tag),
+					zap.String("validator", tx.Script))
+				return err
+			}
 		} else if tx.IsConvertPckTransaction() || tx.IsConvertKtoTransaction() {
 			if err := setTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(index)); err != nil {
 				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
@@ -286,9 +456,9 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 
 			}
 		} else {
-			if tx.IsTokenTransaction() {
+			if tx.IsTokenTransaction() && CDBTransaction != nil {
 				sc := parser.Parser([]byte(tx.Script))
-				e, err := exec.New(bc.cdb, sc, tx.From.String())
+				e, err := exec.NewWithTx(CDBTransaction, sc, tx.From.String())
 				if err != nil {
 					logger.Error("Failed to new exec", zap.String("script", tx.Script),
 						zap.String("from address", tx.From.String()))
@@ -300,8 +470,11 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 						zap.String("from address", tx.From.String()))
 					return err
 				}
+			}
 
-				if err = setMinerFee(DBTransaction, minaddr, tx.Fee); err != nil {
+			//手续费记入出块矿工
+			if tx.Fee > 0 {
+				if err = setMinerFee(DBTransaction, block.Miner.Bytes(), tx.Fee); err != nil {
 					logger.Error("Failed to set fee", zap.Error(err), zap.String("script", tx.Script),
 						zap.String("from address", tx.From.String()), zap.Uint64("fee", tx.Fee))
 					return err
@@ -352,9 +525,12 @@ func (bc *Blockchain) AddBlock(block *block.Block, minaddr []byte) error {
 		logger.Error("failed to set total", zap.Error(err))
 		return err
 	}
+	if err := setSupplyTotals(DBTransaction, minted, frozen); err != nil {
+		logger.Error("failed to set supply", zap.Error(err))
+		return err
+	}
 
-	logger.Info("end to commit block")
-	return DBTransaction.Commit()
+	return nil
 }
 
 // GetNonce 获取address的nonce
@@ -397,11 +573,11 @@ func (bc *Blockchain) GetBalance(address []byte) (uint64, error) {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
 
-	return bc.getBalance(address)
+	return getBalance(bc.db, address)
 }
 
-func (bc *Blockchain) getBalance(address []byte) (uint64, error) {
-	balanceBytes, err := bc.db.Get(address)
+func getBalance(r reader, address []byte) (uint64, error) {
+	balanceBytes, err := r.Get(address)
 	if err == store.NotExist {
 		return 0, nil
 	} else if err != nil {
@@ -475,6 +651,70 @@ func (bc *Blockchain) getBlockByheight(height uint64) (*block.Block, error) {
 	return block.Deserialize(blockData)
 }
 
+// GetHeaderByHeight 获取块高对应的块头
+func (bc *Blockchain) GetHeaderByHeight(height uint64) (*block.BlockHeader, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return getHeaderByHeight(bc.db, height)
+}
+
+// GetHeaders 获取从lowH到heiH的块头
+func (bc *Blockchain) GetHeaders(lowH, heiH uint64) ([]*block.BlockHeader, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if lowH > heiH {
+		return nil, errors.New("parameter error")
+	}
+	headers := make([]*block.BlockHeader, 0, heiH-lowH+1)
+	for h := lowH; h <= heiH; h++ {
+		header, err := getHeaderByHeight(bc.db, h)
+		if err != nil {
+			logger.Error("failed to get block header", zap.Error(err), zap.Uint64("height", h))
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+func getHeaderByHeight(r reader, height uint64) (*block.BlockHeader, error) {
+	//有创世文件时高度0为创世块
+	hash, err := r.Get(append(HeightPrefix, miscellaneous.E64func(height)...))
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := r.Get(append(HeaderPrefix, hash...))
+	if err == store.NotExist {
+		//升级前的块没有单独存储块头
+		blockData, err := r.Get(hash)
+		if err != nil {
+			return nil, err
+		}
+		b, err := block.Deserialize(blockData)
+		if err != nil {
+			return nil, err
+		}
+		return b.Header(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	return block.DeserializeHeader(data)
+}
+
+// GetBlockCommit 获取块高对应的提交证书
+func (bc *Blockchain) GetBlockCommit(height uint64) (*block.Commit, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	data, err := bc.db.Get(append(CommitPrefix, miscellaneous.E64func(height)...))
+	if err != nil {
+		return nil, err
+	}
+	return block.DeserializeCommit(data)
+}
+
 func (bc *Blockchain) GBbyHeight(height uint64) (*block.Block, error) {
 	if height < 1 {
 		return nil, errors.New("parameter error")
@@ -558,6 +798,39 @@ func (bc *Blockchain) GetTransactions(start, end int64) ([]*transaction.Transact
 	return transactions, err
 }
 
+// GetTxProof 获取交易所在的块、交易在块中的序号和交易在块默克尔树中的证明路径
+func (bc *Blockchain) GetTxProof(hash []byte) (*block.Block, uint64, []merkle.ProofStep, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	Hi, err := bc.db.Get(hash)
+	if err != nil {
+		logger.Error("failed to get hash", zap.Error(err))
+		return nil, 0, nil, err
+	}
+	var txindex TXindex
+	if err = json.Unmarshal(Hi, &txindex); err != nil {
+		logger.Error("Failed to unmarshal bytes", zap.Error(err))
+		return nil, 0, nil, err
+	}
+	b, err := bc.getBlockByheight(txindex.Height)
+	if err != nil {
+		logger.Error("failed to getblock height", zap.Error(err), zap.Uint64("height", txindex.Height))
+		return nil, 0, nil, err
+	}
+	if txindex.Index >= uint64(len(b.Transactions)) {
+		return nil, 0, nil, errors.New("transaction index out of range")
+	}
+
+	tree := merkle.New(sha256.New(), b.TxsBytes())
+	proof, err := tree.GenerateProof(int(txindex.Index))
+	if err != nil {
+		logger.Error("failed to generate proof", zap.Error(err), zap.Uint64("height", txindex.Height))
+		return nil, 0, nil, err
+	}
+	return b, txindex.Index, proof, nil
+}
+
 // GetTransactionByHash 获取交易哈希对应的交易
 func (bc *Blockchain) GetTransactionByHash(hash []byte) (*transaction.Transaction, error) {
 	bc.mu.RLock()
@@ -700,47 +973,6 @@ func setMinerFee(tx store.Transaction, to []byte, amount uint64) error {
 	return setBalance(tx, to, toBalanceBytes)
 }
 
-// Distr 出币分配
-func Distr(txs []*transaction.Transaction, minaddr, Ds, Cm, QTJ types.Address, height uint64) []*transaction.Transaction {
-	//TODO:避免魔数存在
-	var orderIndexList []int
-	var total uint64 = 49460000000
-	x := height / 31536000 //矿工奖励衰减周期
-
-	for i := 0; uint64(i) < x; i++ {
-		total = total * 8 / 10
-	}
-	each, mod := total/10, total%10
-
-	for i, tx := range txs {
-		if tx.IsOrderTransaction() && tx.Order.Vertify(QTJ) {
-			orderIndexList = append(orderIndexList, i)
-		}
-	}
-
-	if len(orderIndexList) != 0 {
-		fAmonut, fMod := each/uint64(len(orderIndexList)), each%uint64(len(orderIndexList)) //10% 订单用户
-		for _, orderIndex := range orderIndexList {
-			txs = append(txs, transaction.NewCoinBaseTransaction(txs[orderIndex].Order.Address, fAmonut))
-		}
-
-		dsAmount := each + fMod //10% 电商
-		txs = append(txs, transaction.NewCoinBaseTransaction(Ds, dsAmount))
-	} else {
-		dsAmount := each * 2 //20% 电商
-		txs = append(txs, transaction.NewCoinBaseTransaction(Ds, dsAmount))
-	}
-
-	jsAmount := each*4 + mod //40% 技术
-	txs = append(txs, transaction.NewCoinBaseTransaction(minaddr, jsAmount))
-
-	sqAmount := each * 4 //40% 社区
-	//	SetIncome(Samount)
-	txs = append(txs, transaction.NewCoinBaseTransaction(Cm, sqAmount))
-
-	return txs
-}
-
 func setTxbyaddr(DBTransaction store.Transaction, addr []byte, tx transaction.Transaction) error {
 	// txBytes, _ := json.Marshal(tx)
 	// return DBTransaction.Mset(addr, tx.Hash, txBytes)
@@ -779,8 +1011,7 @@ func setTxList(DBTransaction store.Transaction, tx *transaction.Transaction) err
 	}
 
 	//交易hash->交易数据
-	txBytes, _ := json.Marshal(tx)
-	if err := DBTransaction.Set(tx.Hash, txBytes); err != nil {
+	if err := DBTransaction.Set(tx.Hash, tx.Serialize()); err != nil {
 		logger.Error("Failed to set transaction", zap.Error(err))
 		return err
 	}
@@ -813,28 +1044,21 @@ func setAccount(DBTransaction store.Transaction, tx *transaction.Transaction) er
 
 	fromBalBytes, _ := DBTransaction.Get(from)
 	fromBalance, _ := miscellaneous.D64func(fromBalBytes)
-	if tx.IsTokenTransaction() {
-		fromBalance -= tx.Amount + tx.Fee
-	} else {
-		fromBalance -= tx.Amount
+	fromBalance -= tx.Amount + tx.Fee
+	if err := setBalance(DBTransaction, from, miscellaneous.E64func(fromBalance)); err != nil {
+		return err
 	}
 
+	//先写入from再读取to，from与to相同(取消交易)时手续费不会丢失
 	tobalance, err := DBTransaction.Get(to)
 	if err != nil {
-		setBalance(DBTransaction, to, miscellaneous.E64func(0))
 		tobalance = miscellaneous.E64func(0)
 	}
 
 	toBalance, _ := miscellaneous.D64func(tobalance)
 	toBalance += tx.Amount
 
-	Frombytes := miscellaneous.E64func(fromBalance)
-	Tobytes := miscellaneous.E64func(toBalance)
-
-	if err := setBalance(DBTransaction, from, Frombytes); err != nil {
-		return err
-	}
-	if err := setBalance(DBTransaction, to, Tobytes); err != nil {
+	if err := setBalance(DBTransaction, to, miscellaneous.E64func(toBalance)); err != nil {
 		return err
 	}
 
@@ -854,11 +1078,11 @@ func setFreezeBalance(tx store.Transaction, addr, freezeBal []byte) error {
 func (bc *Blockchain) GetFreezeBalance(address []byte) (uint64, error) {
 	bc.mu.RLock()
 	defer bc.mu.RUnlock()
-	return bc.getFreezeBalance(address)
+	return getFreezeBalance(bc.db, address)
 }
 
-func (bc *Blockchain) getFreezeBalance(address []byte) (uint64, error) {
-	freezeBalBytes, err := bc.db.Mget(FreezeKey, address)
+func getFreezeBalance(r reader, address []byte) (uint64, error) {
+	freezeBalBytes, err := r.Mget(FreezeKey, address)
 	if err == store.NotExist {
 		return 0, nil
 	} else if err != nil {
@@ -869,6 +1093,13 @@ func (bc *Blockchain) getFreezeBalance(address []byte) (uint64, error) {
 
 // CalculationResults 计算出该block上链后个地址的可用余额，如果余额不正确则返回错误
 func (bc *Blockchain) CalculationResults(block *block.Block) ([]byte, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return calculationResults(bc.db, block)
+}
+
+// calculationResults 在r的数据上计算block上链后各地址的余额
+func calculationResults(r reader, block *block.Block) ([]byte, error) {
 	//TODO:计算出余额后，进行hash
 	var ok bool
 	var err error
@@ -884,16 +1115,49 @@ func (bc *Blockchain) CalculationResults(block *block.Block) ([]byte, error) {
 		pck  uint64
 		dkto uint64
 	})
+	vals, err := getValidators(r)
+	if err != nil {
+		return nil, err
+	}
 	for _, tx := range block.Transactions {
+		if err := tx.CheckVersion(block.Height); err != nil {
+			logger.Info("failed to verify version", zap.Error(err), zap.Uint64("height", block.Height),
+				zap.Uint32("version", tx.Version))
+			return nil, err
+		}
+		if err := tx.CheckChainID(block.Height); err != nil {
+			logger.Info("failed to verify chain id", zap.Error(err), zap.Uint64("height", block.Height),
+				zap.Uint64("transaction chain id", tx.ChainID), zap.Uint64("chain id", transaction.ChainID))
+			return nil, err
+		}
+		if tx.Expired(block.Height, block.Timestamp) {
+			logger.Info("transaction expired", zap.Uint64("height", block.Height), zap.Int64("timestamp", block.Timestamp),
+				zap.Uint64("valid until height", tx.ValidUntilHeight), zap.Int64("valid until time", tx.ValidUntilTime))
+			return nil, errors.New("transaction expired")
+		}
+		if tx.Fee > 0 && !tx.IsTransferTrasnaction() {
+			logger.Info("fee is only paid by transfer transactions", zap.Int32("tag", tx.Tag), zap.Uint64("fee", tx.Fee))
+			return nil, errors.New("fee of non-transfer transaction")
+		}
+
+		//验证者交易不改变余额，只检查对验证者集合的修改
+		if tx.IsValidatorTransaction() {
+			if vals, err = ChangeValidators(vals, tx); err != nil {
+				logger.Info("failed to change validators", zap.Error(err), zap.String("validator", tx.Script))
+				return nil, err
+			}
+			continue
+		}
+
 		//1、from余额计算
 		if tx.IsTransferTrasnaction() || tx.IsConvertKtoTransaction() || tx.IsConvertPckTransaction() {
 			if avlBalance, ok = avlBalanceResults[tx.From.String()]; !ok {
-				balance, err := bc.GetBalance(tx.From.Bytes())
+				balance, err := getBalance(r, tx.From.Bytes())
 				if err != nil {
 					return nil, err
 				}
 
-				frozenBalance, err := bc.GetFreezeBalance(tx.From.Bytes())
+				frozenBalance, err := getFreezeBalance(r, tx.From.Bytes())
 				if err != nil {
 					return nil, err
 				}
@@ -908,12 +1172,12 @@ func (bc *Blockchain) CalculationResults(block *block.Block) ([]byte, error) {
 
 			if tx.IsConvertKtoTransaction() || tx.IsConvertPckTransaction() {
 				if pckDkto, ok = pckDktoResults[tx.From.String()]; !ok {
-					pckDkto.dkto, err = bc.GetDKto(tx.From.Bytes())
+					pckDkto.dkto, err = getDKto(r, tx.From.Bytes())
 					if err != nil {
 						return nil, err
 					}
 
-					pckDkto.pck, err = bc.GetPck(tx.From.Bytes())
+					pckDkto.pck, err = getPck(r, tx.From.Bytes())
 					if err != nil {
 						return nil, err
 					}
@@ -956,12 +1220,12 @@ func (bc *Blockchain) CalculationResults(block *block.Block) ([]byte, error) {
 		//2、to余额计算
 		if !tx.IsConvertKtoTransaction() && !tx.IsConvertPckTransaction() {
 			if avlBalance, ok = avlBalanceResults[tx.To.String()]; !ok {
-				balance, err := bc.GetBalance(tx.To.Bytes())
+				balance, err := getBalance(r, tx.To.Bytes())
 				if err != nil {
 					return nil, err
 				}
 
-				frozenBalance, err := bc.GetFreezeBalance(tx.To.Bytes())
+				frozenBalance, err := getFreezeBalance(r, tx.To.Bytes())
 				if err != nil {
 					return nil, err
 				}
@@ -977,7 +1241,7 @@ func (bc *Blockchain) CalculationResults(block *block.Block) ([]byte, error) {
 			}
 
 			if frozenBalance, ok = frozenBalanceResults[tx.To.String()]; !ok {
-				frozenBalance, err = bc.getFreezeBalance(tx.To.Bytes())
+				frozenBalance, err = getFreezeBalance(r, tx.To.Bytes())
 				if err != nil {
 					return nil, err
 				}
@@ -1057,18 +1321,62 @@ func (bc *Blockchain) CalculationResults(block *block.Block) ([]byte, error) {
 	return hash[:], nil
 }
 
-// CheckResults  重新计算结果，并与结果集对比，相同为true，否则为false
-func (bc *Blockchain) CheckResults(block *block.Block, resultHash, Ds, Cm, qtj []byte) bool {
+// checkBlock 在r的数据上检查块的coinbase交易、时间戳、出块者、交易数量和出块奖励，并计算结果集
+func checkBlock(r reader, block *block.Block, Ds, Cm, qtj []byte, now int64) ([]byte, error) {
 	//1、最后一笔交易必须是coinbase交易
-	if !block.Transactions[len(block.Transactions)-1].IsCoinBaseTransaction() {
-		logger.Error("the end is not a coinbase transaction")
-		return false
+	if n := len(block.Transactions); n == 0 || !block.Transactions[n-1].IsCoinBaseTransaction() {
+		return nil, errors.New("the end is not a coinbase transaction")
 	}
 
-	//2、验证leader和follower的结果集是否相同
-	currResultHash, err := bc.CalculationResults(block)
+	//2、检查时间戳
+	if err := checkTimestamp(r, block, now); err != nil {
+		return nil, err
+	}
+
+	//3、检查出块者和交易数量
+	params, err := getChainParams(r.Get(ParamsKey))
 	if err != nil {
-		logger.Error("failed to calculation results")
+		return nil, err
+	}
+	if err := checkProposer(r, block, params.ProposerTerm); err != nil {
+		return nil, err
+	}
+	var txs uint64
+	for _, tx := range block.Transactions {
+		if !tx.IsCoinBaseTransaction() {
+			txs++
+		}
+	}
+	if txs > params.MaxBlockTxs {
+		return nil, fmt.Errorf("block has %d transactions,more than %d", txs, params.MaxBlockTxs)
+	}
+
+	//4、检查出块奖励
+	emission, err := getEmission(r.Get(EmissionKey))
+	if err != nil {
+		return nil, err
+	}
+	dsAddr, _ := types.BytesToAddress(Ds)
+	cmAddr, _ := types.BytesToAddress(Cm)
+	qtjAddr, _ := types.BytesToAddress(qtj)
+	if err := emission.CheckCoinbase(block, *dsAddr, *cmAddr, *qtjAddr); err != nil {
+		return nil, err
+	}
+
+	//5、计算结果集
+	return calculationResults(r, block)
+}
+
+// CheckResults  重新计算结果，并与结果集对比，相同为true，否则为false
+func (bc *Blockchain) CheckResults(block *block.Block, resultHash, Ds, Cm, qtj []byte) bool {
+	//结果集和状态根在同一个读锁下计算，期间不会提交新块
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	//1、检查块并计算结果集
+	currResultHash, err := checkBlock(bc.db, block, Ds, Cm, qtj, time.Now().Unix())
+	if err != nil {
+		logger.Error("failed to check block", zap.Error(err), zap.Uint64("height", block.Height))
 		return false
 	}
 
@@ -1103,16 +1411,46 @@ func (bc *Blockchain) CheckResults(block *block.Block, resultHash, Ds, Cm, qtj [
 	// 		}
 	// 	}
 	// }
-	//3、检查各个地址余额
+	//2、验证leader和follower的结果集是否相同
 	log.Debug("length", zap.Int("prev len", len(resultHash)), zap.Int("curr len", len(currResultHash)))
 	if bytes.Compare(resultHash, currResultHash) != 0 {
 		logger.Error("hash not equal")
 		return false
 	}
 
+	//3、检查状态根
+	stateRoot, err := bc.stateRoot(block)
+	if err != nil {
+		logger.Error("failed to calculate state root", zap.Error(err))
+		return false
+	}
+	if !bytes.Equal(block.StateRoot, stateRoot) {
+		logger.Error("state root not equal", zap.String("block state root", hex.EncodeToString(block.StateRoot)),
+			zap.String("state root", hex.EncodeToString(stateRoot)))
+		return false
+	}
+
 	return true
 }
 
+// CheckBlock 检查不经过共识提交的块(如同步的块)，规则与CheckResults相同，没有leader的结果集，只对比状态根
+func (bc *Blockchain) CheckBlock(block *block.Block, Ds, Cm, qtj []byte) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if _, err := checkBlock(bc.db, block, Ds, Cm, qtj, time.Now().Unix()); err != nil {
+		return err
+	}
+	stateRoot, err := bc.stateRoot(block)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(block.StateRoot, stateRoot) {
+		return fmt.Errorf("state root error:block %d state root=%x,state root=%x", block.Height, block.StateRoot, stateRoot)
+	}
+	return nil
+}
+
 // GetBlockSection 获取冲lowH到heiH的所有block
 func (bc *Blockchain) GetBlockSection(lowH, heiH uint64) ([]*block.Block, error) {
 	var blocks []*block.Block
@@ -1129,8 +1467,8 @@ func (bc *Blockchain) GetBlockSection(lowH, heiH uint64) ([]*block.Block, error)
 			return nil, err
 		}
 
-		blcok := &block.Block{}
-		if err := json.Unmarshal(B, blcok); err != nil {
+		blcok, err := block.Deserialize(B)
+		if err != nil {
 			logger.Error("Failed to unmarshal block", zap.Error(err), zap.String("hash", string(hash)))
 			return nil, err
 		}
@@ -1159,6 +1497,8 @@ func (bc *Blockchain) DeleteBlock(height uint64) error {
 
 	DBTransaction := bc.db.NewTransaction()
 	defer DBTransaction.Cancel()
+	CDBTransaction := bc.cdb.NewTransaction()
+	defer CDBTransaction.Cancel()
 
 	dbHeight, err := bc.getHeight()
 	if err != nil {
@@ -1166,142 +1506,174 @@ func (bc *Blockchain) DeleteBlock(height uint64) error {
 		return err
 	}
 
-	if height > dbHeight {
+	if height > dbHeight || height < 1 {
 		return fmt.Errorf("Wrong height to delete,[%v] should <= current height[%v]", height, dbHeight)
 	}
 
-	for dH := dbHeight; dH >= height; dH-- {
-		logger.Info("Start to delete block", zap.Uint64("height", dH))
-		block, err := bc.getBlockByheight(dH)
-		if err != nil {
-			logger.Error("failed to get block", zap.Error(err))
-			return err
-		}
+	if _, err = bc.rollback(DBTransaction, CDBTransaction, height-1); err != nil {
+		return err
+	}
 
-		for i, tx := range block.Transactions {
-			if tx.IsCoinBaseTransaction() {
-				if err = deleteTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(i)); err != nil {
-					logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.Uint64("amount", tx.Amount))
-					return err
-				}
+	logger.Info("End delete")
+	return bc.commit(DBTransaction, CDBTransaction)
+}
 
-				if err := delToAccount(DBTransaction, tx); err != nil {
-					logger.Error("Failed to set account", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.Uint64("amount", tx.Amount))
-					return err
-				}
-			} else if !tx.IsTransferTrasnaction() {
-				if err := deleteTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(i)); err != nil {
-					logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-					return err
-				}
+// deleteLegacyBlock 删除没有撤销记录的块，按交易反向恢复数据
+func (bc *Blockchain) deleteLegacyBlock(DBTransaction, CDBTransaction store.Transaction, block *block.Block) error {
+	var err error
+	for i, tx := range block.Transactions {
+		if tx.IsCoinBaseTransaction() {
+			if err = deleteTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(i)); err != nil {
+				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.Uint64("amount", tx.Amount))
+				return err
+			}
 
-				if err := deleteTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(i)); err != nil {
-					logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-					return err
-				}
+			if err := delToAccount(DBTransaction, tx); err != nil {
+				logger.Error("Failed to set account", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.Uint64("amount", tx.Amount))
+				return err
+			}
+		} else if !tx.IsTransferTrasnaction() {
+			if err := deleteTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(i)); err != nil {
+				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+				return err
+			}
 
-				nonce := tx.Nonce
-				if err := setNonce(DBTransaction, tx.From.Bytes(), miscellaneous.E64func(nonce)); err != nil {
-					logger.Error("Failed to set nonce", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-				}
+			if err := deleteTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(i)); err != nil {
+				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+				return err
+			}
 
-				var frozenBalBytes []byte
-				frozenBal, _ := bc.getFreezeBalance(tx.To.Bytes())
-				if tx.IsFreezeTransaction() {
-					frozenBalBytes = miscellaneous.E64func(tx.Amount - frozenBal)
-				} else {
-					frozenBalBytes = miscellaneous.E64func(frozenBal + tx.Amount)
-				}
-				if err := setFreezeBalance(DBTransaction, tx.To.Bytes(), frozenBalBytes); err != nil {
-					logger.Error("Faile to freeze balance", zap.String("address", tx.To.String()),
-						zap.Uint64("amount", tx.Amount))
-					return err
-				}
+			nonce := tx.Nonce
+			if err := setNonce(DBTransaction, tx.From.Bytes(), miscellaneous.E64func(nonce)); err != nil {
+				logger.Error("Failed to set nonce", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+			}
+
+			var frozenBalBytes []byte
+			frozenBal, _ := getUint64(DBTransaction.Mget(FreezeKey, tx.To.Bytes()))
+			if tx.IsFreezeTransaction() {
+				frozenBalBytes = miscellaneous.E64func(tx.Amount - frozenBal)
 			} else {
-				if tx.IsTokenTransaction() {
-					spilt := strings.Split(tx.Script, "\"")
-					if spilt[0] == "transfer " {
-						script := fmt.Sprintf("transfer \"%s\" %s \"%s\"", spilt[1], spilt[2], tx.From.String())
-
-						sc := parser.Parser([]byte(script))
-						e, err := exec.New(bc.cdb, sc, tx.To.String())
-						if err != nil {
-							logger.Error("Failed to new exec", zap.String("script", script),
-								zap.String("from address", tx.To.String()))
-							return err
-
-						}
-
-						if err = e.Flush(); err != nil {
-							logger.Error("Failed to flush exec", zap.String("script", script),
-								zap.String("from address", tx.To.String()))
-							return err
-						}
+				frozenBalBytes = miscellaneous.E64func(frozenBal + tx.Amount)
+			}
+			if err := setFreezeBalance(DBTransaction, tx.To.Bytes(), frozenBalBytes); err != nil {
+				logger.Error("Faile to freeze balance", zap.String("address", tx.To.String()),
+					zap.Uint64("amount", tx.Amount))
+				return err
+			}
+		} else {
+			if tx.IsTokenTransaction() {
+				spilt := strings.Split(tx.Script, "\"")
+				if spilt[0] == "transfer " {
+					script := fmt.Sprintf("transfer \"%s\" %s \"%s\"", spilt[1], spilt[2], tx.From.String())
+
+					sc := parser.Parser([]byte(script))
+					e, err := exec.NewWithTx(CDBTransaction, sc, tx.To.String())
+					if err != nil {
+						logger.Error("Failed to new exec", zap.String("script", script),
+							zap.String("from address", tx.To.String()))
+						return err
+
 					}
-					if err = delMinerFee(DBTransaction, block.Miner.Bytes(), tx.Fee); err != nil {
-						logger.Error("Failed to set fee", zap.Error(err), zap.String("script", tx.Script),
-							zap.String("from address", tx.From.String()), zap.Uint64("fee", tx.Fee))
+
+					if err = e.Flush(); err != nil {
+						logger.Error("Failed to flush exec", zap.String("script", script),
+							zap.String("from address", tx.To.String()))
 						return err
 					}
 				}
-
-				if err := deleteTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(i)); err != nil {
-					logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+			}
+			if tx.Fee > 0 {
+				if err = delMinerFee(DBTransaction, block.Miner.Bytes(), tx.Fee); err != nil {
+					logger.Error("Failed to set fee", zap.Error(err), zap.String("script", tx.Script),
+						zap.String("from address", tx.From.String()), zap.Uint64("fee", tx.Fee))
 					return err
 				}
+			}
 
-				if err := deleteTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(i)); err != nil {
-					logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-					return err
-				}
+			if err := deleteTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(i)); err != nil {
+				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+				return err
+			}
 
-				//更新nonce,block中txs必须是有序的
-				nonce := tx.Nonce
-				if err := setNonce(DBTransaction, tx.From.Bytes(), miscellaneous.E64func(nonce)); err != nil {
-					logger.Error("Failed to set nonce", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-					return err
-				}
+			if err := deleteTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(i)); err != nil {
+				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+				return err
+			}
 
-				//更新余额
-				if err := setAccount(DBTransaction, tx); err != nil {
-					logger.Error("Failed to set balance", zap.Error(err), zap.String("from address", tx.From.String()),
-						zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-					return err
-				}
+			//更新nonce,block中txs必须是有序的
+			nonce := tx.Nonce
+			if err := setNonce(DBTransaction, tx.From.Bytes(), miscellaneous.E64func(nonce)); err != nil {
+				logger.Error("Failed to set nonce", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+				return err
 			}
 
-			// if err := setTxList(DBTransaction, tx); err != nil {
-			// 	logger.Error("Failed to set block data", zap.String("from", tx.From.String()), zap.Uint64("nonce", tx.Nonce))
-			// 	return err
-			// }
+			//更新余额
+			if err := setAccount(DBTransaction, tx); err != nil {
+				logger.Error("Failed to set balance", zap.Error(err), zap.String("from address", tx.From.String()),
+					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
+				return err
+			}
 		}
 
-		//高度->哈希
-		hash := block.Hash
-		if err = DBTransaction.Del(append(HeightPrefix, miscellaneous.E64func(block.Height)...)); err != nil {
-			logger.Error("Failed to Del height and hash", zap.Error(err))
+		// if err := setTxList(DBTransaction, tx); err != nil {
+		// 	logger.Error("Failed to set block data", zap.String("from", tx.From.String()), zap.Uint64("nonce", tx.Nonce))
+		// 	return err
+		// }
+	}
+
+	//高度->哈希
+	hash := block.Hash
+	if err = DBTransaction.Del(append(HeightPrefix, miscellaneous.E64func(block.Height)...)); err != nil {
+		logger.Error("Failed to Del height and hash", zap.Error(err))
+		return err
+	}
+
+	//哈希-> 块
+	if err = DBTransaction.Del(hash); err != nil {
+		logger.Error("Failed to Del block", zap.Error(err))
+		return err
+	}
+
+	//哈希-> 块头
+	if err = DBTransaction.Del(append(HeaderPrefix, hash...)); err != nil {
+		logger.Error("Failed to Del block header", zap.Error(err))
+		return err
+	}
+
+	DBTransaction.Set(HeightKey, miscellaneous.E64func(block.Height-1))
+
+	//删除发行总量和锁仓总量，下次使用时重新统计
+	for _, key := range [][]byte{MintedTotalKey, FrozenTotalKey} {
+		if err := DBTransaction.Del(key); err != nil {
+			logger.Error("Failed to Del supply", zap.Error(err))
 			return err
 		}
+	}
 
-		//哈希-> 块
-		if err = DBTransaction.Del(hash); err != nil {
-			logger.Error("Failed to Del block", zap.Error(err))
+	//回到上一块的状态根，没有时下次使用状态树时重建
+	if err := DBTransaction.Del(StateRootKey); err != nil {
+		logger.Error("Failed to Del state root", zap.Error(err))
+		return err
+	}
+	if block.Height > 1 {
+		prev, err := getHeaderByHeight(DBTransaction, block.Height-1)
+		if err != nil {
+			logger.Error("failed to get block header", zap.Error(err))
 			return err
 		}
-		// last
-		DBTransaction.Set(HeightKey, miscellaneous.E64func(dH-1))
+		if len(prev.StateRoot) > 0 {
+			DBTransaction.Set(StateRootKey, prev.StateRoot)
+		}
 	}
-
-	logger.Info("End delete")
-	return DBTransaction.Commit()
+	return nil
 }
 
 func deleteTxbyaddrKV(DBTransaction store.Transaction, addr []byte, tx transaction.Transaction, index uint64) error {
@@ -1350,28 +1722,21 @@ func delAccount(DBTransaction store.Transaction, tx *transaction.Transaction) er
 
 	fromBalBytes, _ := DBTransaction.Get(from)
 	fromBalance, _ := miscellaneous.D64func(fromBalBytes)
-	if tx.IsTokenTransaction() {
-		fromBalance = fromBalance + tx.Amount + tx.Fee
-	} else {
-		fromBalance += tx.Amount
+	fromBalance += tx.Amount + tx.Fee
+	if err := setBalance(DBTransaction, from, miscellaneous.E64func(fromBalance)); err != nil {
+		return err
 	}
 
+	//先写入from再读取to，from与to相同(取消交易)时手续费不会丢失
 	tobalance, err := DBTransaction.Get(to)
 	if err != nil {
-		setBalance(DBTransaction, to, miscellaneous.E64func(0))
 		tobalance = miscellaneous.E64func(0)
 	}
 
 	toBalance, _ := miscellaneous.D64func(tobalance)
 	toBalance -= tx.Amount
 
-	Frombytes := miscellaneous.E64func(fromBalance)
-	Tobytes := miscellaneous.E64func(toBalance)
-
-	if err := setBalance(DBTransaction, from, Frombytes); err != nil {
-		return err
-	}
-	if err := setBalance(DBTransaction, to, Tobytes); err != nil {
+	if err := setBalance(DBTransaction, to, miscellaneous.E64func(toBalance)); err != nil {
 		return err
 	}
 
@@ -1392,153 +1757,6 @@ func delMinerFee(tx store.Transaction, to []byte, amount uint64) error {
 	return setBalance(tx, to, toBalanceBytes)
 }
 
-//RecoverBlock 向数据库添加新的block数据，minaddr矿工地址
-func (bc *Blockchain) RecoverBlock(block *block.Block, minaddr []byte) error {
-	logger.Info("Start to recover block...", zap.Uint64("height", block.Height))
-	bc.mu.Lock()
-	defer bc.mu.Unlock()
-
-	DBTransaction := bc.db.NewTransaction()
-	defer DBTransaction.Cancel()
-	var err error
-	var height, prevHeight uint64
-	//拿出块高
-	prevHeight, err = bc.getHeight()
-	if err != nil {
-		logger.Error("failed to get height", zap.Error(err))
-		return err
-	}
-
-	height = prevHeight + 1
-	if block.Height != height {
-		return fmt.Errorf("height error:previous height=%d,current height=%d", prevHeight, height)
-	}
-
-	//高度->哈希
-	hash := block.Hash
-	if err = DBTransaction.Set(append(HeightPrefix, miscellaneous.E64func(height)...), hash); err != nil {
-		logger.Error("Failed to set height and hash", zap.Error(err))
-		return err
-	}
-
-	//哈希-> 块
-	if err = DBTransaction.Set(hash, block.Serialize()); err != nil {
-		logger.Error("Failed to set block", zap.Error(err))
-		return err
-	}
-
-	//重置块高
-	DBTransaction.Del(HeightKey)
-	DBTransaction.Set(HeightKey, miscellaneous.E64func(height))
-
-	for index, tx := range block.Transactions {
-		if tx.IsCoinBaseTransaction() {
-			if err = setTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(index)); err != nil {
-				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.Uint64("amount", tx.Amount))
-				return err
-			}
-
-			if err := setToAccount(DBTransaction, tx); err != nil {
-				logger.Error("Failed to set account", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.Uint64("amount", tx.Amount))
-				return err
-			}
-		} else if !tx.IsTransferTrasnaction() {
-			if err := setTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(index)); err != nil {
-				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-				return err
-			}
-
-			if err := setTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(index)); err != nil {
-				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-				return err
-			}
-
-			nonce := tx.Nonce + 1
-			if err := setNonce(DBTransaction, tx.From.Bytes(), miscellaneous.E64func(nonce)); err != nil {
-				logger.Error("Failed to set nonce", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-			}
-
-			var frozenBalBytes []byte
-			frozenBal, _ := bc.getFreezeBalance(tx.To.Bytes())
-			if tx.IsFreezeTransaction() {
-				frozenBalBytes = miscellaneous.E64func(tx.Amount + frozenBal)
-			} else {
-				frozenBalBytes = miscellaneous.E64func(frozenBal - tx.Amount)
-			}
-			if err := setFreezeBalance(DBTransaction, tx.To.Bytes(), frozenBalBytes); err != nil {
-				logger.Error("Faile to freeze balance", zap.String("address", tx.To.String()),
-					zap.Uint64("amount", tx.Amount))
-				return err
-			}
-		} else {
-			if tx.IsTokenTransaction() {
-				spilt := strings.Split(tx.Script, "\"")
-				if spilt[0] == "transfer " {
-					sc := parser.Parser([]byte(tx.Script))
-					e, err := exec.New(bc.cdb, sc, tx.From.String())
-					if err != nil {
-						logger.Error("Failed to new exec", zap.String("script", tx.Script),
-							zap.String("from address", tx.From.String()))
-						return err
-					}
-
-					if err = e.Flush(); err != nil {
-						logger.Error("Failed to flush exec", zap.String("script", tx.Script),
-							zap.String("from address", tx.From.String()))
-						return err
-					}
-
-				}
-
-				if err = setMinerFee(DBTransaction, minaddr, tx.Fee); err != nil {
-					logger.Error("Failed to set fee", zap.Error(err), zap.String("script", tx.Script),
-						zap.String("from address", tx.From.String()), zap.Uint64("fee", tx.Fee))
-					return err
-				}
-			}
-
-			if err := setTxbyaddrKV(DBTransaction, tx.From.Bytes(), *tx, uint64(index)); err != nil {
-				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-				return err
-			}
-
-			if err := setTxbyaddrKV(DBTransaction, tx.To.Bytes(), *tx, uint64(index)); err != nil {
-				logger.Error("Failed to set transaction", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-				return err
-			}
-
-			//更新nonce,block中txs必须是有序的
-			nonce := tx.Nonce + 1
-			if err := setNonce(DBTransaction, tx.From.Bytes(), miscellaneous.E64func(nonce)); err != nil {
-				logger.Error("Failed to set nonce", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-				return err
-			}
-
-			//更新余额
-			if err := setAccount(DBTransaction, tx); err != nil {
-				logger.Error("Failed to set balance", zap.Error(err), zap.String("from address", tx.From.String()),
-					zap.String("to address", tx.To.String()), zap.Uint64("amount", tx.Amount))
-				return err
-			}
-		}
-
-		if err := setTxList(DBTransaction, tx); err != nil {
-			logger.Error("Failed to set block data", zap.String("from", tx.From.String()), zap.Uint64("nonce", tx.Nonce))
-			return err
-		}
-	}
-	logger.Info("End recover.")
-	return DBTransaction.Commit()
-}
-
 func setConvertPck(tx store.Transaction, from []byte, ktoNum, pckNum uint64) error {
 	var bal, pckBal, dKto uint64
 	// pck
@@ -1731,7 +1949,7 @@ func setPckAndDktoToatal(tx store.Transaction, pckTotal, dKtoTotal uint64) error
 	return nil
 }
 
-func getPck(tx store.Transaction, addr []byte) (uint64, error) {
+func getPck(tx reader, addr []byte) (uint64, error) {
 	var num uint64
 	key := append([]byte(pckPrefix), addr...)
 	data, err := tx.Get(key)
@@ -1746,7 +1964,7 @@ func getPck(tx store.Transaction, addr []byte) (uint64, error) {
 	return num, nil
 }
 
-func getDKto(tx store.Transaction, addr []byte) (uint64, error) {
+func getDKto(tx reader, addr []byte) (uint64, error) {
 	var num uint64
 	key := append([]byte(dKtoPrefix), addr...)
 
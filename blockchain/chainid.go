@@ -0,0 +1,48 @@
+package blockchain
+
+import (
+	"fmt"
+	"kortho/logger"
+	"kortho/util/miscellaneous"
+	"kortho/util/store"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// ChainIDKey 数据库中存储链ID的键
+	ChainIDKey = []byte("chainid")
+)
+
+// GenesisPrevHash 创世块的PrevHash，由链ID得到，链ID为0时为32字节的0
+func GenesisPrevHash(chainID uint64) []byte {
+	if chainID == 0 {
+		return make([]byte, 32)
+	}
+	hash := sha3.Sum256(append([]byte("kortho chain id"), miscellaneous.E64func(chainID)...))
+	return hash[:]
+}
+
+// CheckChainID 检查数据库中的链ID与配置的链ID相同，数据库中没有链ID时写入
+func (bc *Blockchain) CheckChainID(chainID uint64) error {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	data, err := bc.db.Get(ChainIDKey)
+	if err == store.NotExist {
+		logger.Info("set chain id", zap.Uint64("chain id", chainID))
+		return bc.db.Set(ChainIDKey, miscellaneous.E64func(chainID))
+	} else if err != nil {
+		return err
+	}
+
+	stored, err := miscellaneous.D64func(data)
+	if err != nil {
+		return err
+	}
+	if stored != chainID {
+		return fmt.Errorf("chain id %d in config does not match chain id %d in database", chainID, stored)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package blockchain
+
+import (
+	"kortho/logger"
+	"kortho/util/codec"
+	"kortho/util/store"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// RedoKey 合约数据库待提交修改的key，区块数据库提交后合约数据库提交前存在
+	RedoKey = []byte("contractredo")
+)
+
+// commit 原子提交区块数据库和合约数据库：
+// 1. 合约数据库的修改作为重做记录与区块数据库一起提交，此后块视为已提交
+// 2. 提交合约数据库
+// 3. 删除重做记录
+// 在2之前崩溃时，下次打开时由recoverContract重做合约数据库的修改
+func (bc *Blockchain) commit(DBTransaction, CDBTransaction store.Transaction) error {
+	changes, err := CDBTransaction.Changes()
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return DBTransaction.Commit()
+	}
+
+	e := codec.NewEncoder()
+	encodeUndos(e, changes)
+	if err = DBTransaction.Set(RedoKey, e.Result()); err != nil {
+		return err
+	}
+	if err = bc.crash("db"); err != nil {
+		return err
+	}
+	if err = DBTransaction.Commit(); err != nil {
+		return err
+	}
+
+	if err = bc.crash("cdb"); err != nil {
+		return err
+	}
+	if err = CDBTransaction.Commit(); err != nil {
+		logger.Error("failed to commit contract db, redo", zap.Error(err))
+		return bc.recoverContract()
+	}
+
+	if err = bc.crash("redo"); err != nil {
+		return err
+	}
+	return bc.db.Del(RedoKey)
+}
+
+// crash 测试时通过crashAt在提交的各个阶段注入崩溃
+func (bc *Blockchain) crash(stage string) error {
+	if bc.crashAt == nil {
+		return nil
+	}
+	return bc.crashAt(stage)
+}
+
+// recoverContract 把区块数据库中的重做记录写入合约数据库
+func (bc *Blockchain) recoverContract() error {
+	data, err := bc.db.Get(RedoKey)
+	if err == store.NotExist {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	d := codec.NewDecoder(data)
+	changes := decodeUndos(d)
+	if err = d.Finish(); err != nil {
+		return err
+	}
+
+	logger.Info("redo contract db", zap.Int("keys", len(changes)))
+	CDBTransaction := bc.cdb.NewTransaction()
+	defer CDBTransaction.Cancel()
+	//key不重复，Revert按记录写入
+	if err = CDBTransaction.Revert(changes); err != nil {
+		return err
+	}
+	if err = CDBTransaction.Commit(); err != nil {
+		return err
+	}
+	return bc.db.Del(RedoKey)
+}
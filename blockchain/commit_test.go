@@ -0,0 +1,179 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"kortho/block"
+	"kortho/types"
+	"kortho/util/merkle"
+	"testing"
+)
+
+func TestCommitCrash(t *testing.T) {
+	ref := newTestChain(t)
+	defer ref.close()
+	ref.add(t)
+	ref.add(t, ref.tx(1, `new "USDT" 100000 2`), ref.tx(2, `mint "USDT" 10000`))
+	ref.add(t, ref.tx(3, fmt.Sprintf(`transfer "USDT" 20 "%s"`, ref.cm.String())))
+
+	var blocks []*block.Block
+	for h := uint64(1); h <= 3; h++ {
+		b, err := ref.GetBlockByHeight(h)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, b)
+	}
+	db, cdb := dump(t, ref.db), dump(t, ref.cdb)
+
+	errCrash := errors.New("crash")
+	for _, stage := range []string{"db", "cdb", "redo"} {
+		c := newTestChain(t)
+		for _, b := range blocks[:2] {
+			if err := c.AddBlock(b, nil); err != nil {
+				t.Fatal(err)
+			}
+		}
+		prevDB, prevCDB := dump(t, c.db), dump(t, c.cdb)
+
+		c.crashAt = func(s string) error {
+			if s == stage {
+				return errCrash
+			}
+			return nil
+		}
+		if err := c.AddBlock(blocks[2], nil); err != errCrash {
+			t.Fatalf("%s: AddBlock returned %v", stage, err)
+		}
+
+		//重启
+		c.db.Close()
+		c.cdb.Close()
+		c.open()
+		if err := c.recoverContract(); err != nil {
+			t.Fatal(err)
+		}
+
+		wantDB, wantCDB := db, cdb
+		if stage == "db" {
+			wantDB, wantCDB = prevDB, prevCDB
+		}
+		if !bytes.Equal(wantDB, dump(t, c.db)) {
+			t.Errorf("%s: blockchain.db not consistent", stage)
+		}
+		if !bytes.Equal(wantCDB, dump(t, c.cdb)) {
+			t.Errorf("%s: contract.db not consistent", stage)
+		}
+		c.close()
+	}
+}
+
+//轻节点只用提交证书和块头验证交易证明
+func TestTxProofHeader(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+	c.add(t)
+	tx := c.tx(1, "")
+	c.add(t, tx)
+
+	vals, keys := testValidatorKeys(4)
+	b, index, proof, err := c.GetTxProof(tx.Hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := testCommit(b, vals, keys[:3])
+	h, err := block.DeserializeHeader(b.Header().Serialize())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := commit.VerifyHeader(h, vals, 3); err != nil {
+		t.Fatal(err)
+	}
+	data := b.TxsBytes()[index]
+	if !merkle.VerifyProof(sha256.New(), h.Root, data, proof) {
+		t.Fatal("proof not verified by the certified header")
+	}
+
+	//块头的任何字段改变后证书不再有效
+	for _, change := range []func(*block.BlockHeader){
+		func(h *block.BlockHeader) { h.Root = []byte{1} },
+		func(h *block.BlockHeader) { h.Miner[0]++ },
+		func(h *block.BlockHeader) { h.Version++ },
+	} {
+		h, _ := block.DeserializeHeader(b.Header().Serialize())
+		change(h)
+		if err := commit.VerifyHeader(h, vals, 3); err == nil {
+			t.Fatalf("changed header %+v verified", h)
+		}
+	}
+}
+
+func testValidatorKeys(n int) ([]string, []ed25519.PrivateKey) {
+	var vals []string
+	var keys []ed25519.PrivateKey
+	for i := 0; i < n; i++ {
+		pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+		vals = append(vals, types.PublicKeyToAddress(pub))
+		keys = append(keys, priv)
+	}
+	return vals, keys
+}
+
+//keys[i]对应vals[i]的验证者
+func testCommit(b *block.Block, vals []string, keys []ed25519.PrivateKey) *block.Commit {
+	c := &block.Commit{Height: b.Height, Hash: b.Hash}
+	for i, key := range keys {
+		c.Signatures = append(c.Signatures, &block.CommitSig{
+			Validator: vals[i],
+			Signature: ed25519.Sign(key, block.CommitSignBytes(b.Height, b.Hash)),
+		})
+	}
+	return c
+}
+
+//提交证书和块在同一个事务中保存，无效的证书使块不能上链
+func TestAddBlockCommit(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	vals, keys := testValidatorKeys(4)
+	var set []string
+	for i, v := range vals {
+		set = append(set, fmt.Sprintf("%s@127.0.0.1:%d", v, 9600+i))
+	}
+	tx := c.db.NewTransaction()
+	if err := setValidators(tx, set); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := c.NewBlock(nil, c.miner, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//签名不足和签名其他块的证书
+	other := *b
+	other.Hash = []byte{1}
+	for _, commit := range []*block.Commit{testCommit(b, vals, keys[:2]), testCommit(&other, vals, keys)} {
+		if err := c.AddBlock(b, commit); err == nil {
+			t.Fatal("block added with an invalid commit")
+		}
+	}
+	if h, _ := c.GetHeight(); h != 0 {
+		t.Fatalf("height %d", h)
+	}
+
+	commit := testCommit(b, vals[1:], keys[1:])
+	if err := c.AddBlock(b, commit); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := c.GetBlockCommit(b.Height); err != nil || !bytes.Equal(got.Serialize(), commit.Serialize()) {
+		t.Fatalf("commit %+v,%v", got, err)
+	}
+}
@@ -0,0 +1,330 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"kortho/block"
+	"kortho/transaction"
+	"kortho/types"
+	"kortho/util/codec"
+	"kortho/util/store"
+)
+
+const (
+	// RecipientOrders 由块中带有效订单的交易的订单地址平分，没有订单时记入Fallback
+	RecipientOrders = "orders"
+	// RecipientMiner 出块矿工
+	RecipientMiner = "miner"
+	// RecipientDs 配置中的电商地址
+	RecipientDs = "ds"
+	// RecipientCm 配置中的社区地址
+	RecipientCm = "cm"
+)
+
+var (
+	// EmissionKey 数据库中存储出币计划的键
+	EmissionKey = []byte("emission")
+)
+
+// EmissionShare 每块奖励中的一份，Recipient为RecipientOrders、RecipientMiner、RecipientDs、RecipientCm或地址
+type EmissionShare struct {
+	Recipient string `json:"recipient"`
+	Weight    uint64 `json:"weight"`             //占奖励的权重
+	Fallback  string `json:"fallback,omitempty"` //RecipientOrders的份额在没有订单时和平分的余数记入该接收方
+}
+
+// EmissionPeriod 出币曲线的一段，从StartHeight开始生效直到下一段的StartHeight
+// 每块奖励从Reward开始，每DecayPeriod个块变为之前的DecayRate%
+type EmissionPeriod struct {
+	StartHeight uint64          `json:"startheight"`
+	Reward      uint64          `json:"reward"`
+	DecayPeriod uint64          `json:"decayperiod"` //0表示不衰减
+	DecayRate   uint64          `json:"decayrate"`   //百分比
+	Shares      []EmissionShare `json:"shares"`
+	Remainder   string          `json:"remainder"` //按权重分配后的余数记入该接收方
+}
+
+// EmissionSchedule 出币计划，由创世文件设置，leader出块和follower验证块时使用相同的计划
+type EmissionSchedule struct {
+	Periods []EmissionPeriod `json:"periods"` //按StartHeight升序，第一段从0开始
+}
+
+// DefaultEmission 没有在创世文件中设置出币计划时使用的计划：
+// 每块49460000000，每31536000块衰减为80%，订单用户10%、电商10%、技术(出块矿工)40%、社区40%
+func DefaultEmission() *EmissionSchedule {
+	return &EmissionSchedule{Periods: []EmissionPeriod{{
+		StartHeight: 0,
+		Reward:      49460000000,
+		DecayPeriod: 31536000,
+		DecayRate:   80,
+		Shares: []EmissionShare{
+			{Recipient: RecipientOrders, Weight: 1, Fallback: RecipientDs},
+			{Recipient: RecipientDs, Weight: 1},
+			{Recipient: RecipientMiner, Weight: 4},
+			{Recipient: RecipientCm, Weight: 4},
+		},
+		Remainder: RecipientMiner,
+	}}}
+}
+
+func validRecipient(recipient string) bool {
+	switch recipient {
+	case RecipientMiner, RecipientDs, RecipientCm:
+		return true
+	}
+	_, err := types.StringToAddress(recipient)
+	return err == nil
+}
+
+// Validate 检查出币计划
+func (s *EmissionSchedule) Validate() error {
+	if len(s.Periods) == 0 || s.Periods[0].StartHeight != 0 {
+		return errors.New("emission: the first period must start at height 0")
+	}
+	for i, p := range s.Periods {
+		if i > 0 && p.StartHeight <= s.Periods[i-1].StartHeight {
+			return errors.New("emission: periods must be sorted by start height")
+		}
+		if p.DecayRate > 100 || (p.DecayPeriod != 0 && p.DecayRate == 0) {
+			return fmt.Errorf("emission: invalid decay rate %d", p.DecayRate)
+		}
+		if p.Reward > MAXUINT64/100 {
+			return fmt.Errorf("emission: reward %d is too large", p.Reward)
+		}
+		var weight uint64
+		for _, share := range p.Shares {
+			if share.Recipient == RecipientOrders {
+				if !validRecipient(share.Fallback) {
+					return fmt.Errorf("emission: invalid fallback %q", share.Fallback)
+				}
+			} else if !validRecipient(share.Recipient) {
+				return fmt.Errorf("emission: invalid recipient %q", share.Recipient)
+			}
+			weight += share.Weight
+		}
+		if weight == 0 {
+			return errors.New("emission: total weight is 0")
+		}
+		if !validRecipient(p.Remainder) {
+			return fmt.Errorf("emission: invalid remainder %q", p.Remainder)
+		}
+	}
+	return nil
+}
+
+// period 块高height所在的段
+func (s *EmissionSchedule) period(height uint64) *EmissionPeriod {
+	p := &s.Periods[0]
+	for i := range s.Periods {
+		if s.Periods[i].StartHeight <= height {
+			p = &s.Periods[i]
+		}
+	}
+	return p
+}
+
+// decay 段开始后第epoch个衰减周期的每块奖励，奖励为0时提前结束，DecayRate为100时不衰减
+func (p *EmissionPeriod) decay(epoch uint64) uint64 {
+	reward := p.Reward
+	if p.DecayRate == 100 {
+		return reward
+	}
+	for i := uint64(0); i < epoch && reward != 0; i++ {
+		reward = reward * p.DecayRate / 100
+	}
+	return reward
+}
+
+// Reward 块高height的出块奖励
+func (s *EmissionSchedule) Reward(height uint64) uint64 {
+	p := s.period(height)
+	if p.DecayPeriod == 0 {
+		return p.Reward
+	}
+	return p.decay((height - p.StartHeight) / p.DecayPeriod)
+}
+
+// Emitted 块高1到height的出块奖励总和
+func (s *EmissionSchedule) Emitted(height uint64) uint64 {
+	var total uint64
+	for i, p := range s.Periods {
+		start, end := p.StartHeight, height
+		if start == 0 {
+			start = 1
+		}
+		if i+1 < len(s.Periods) && s.Periods[i+1].StartHeight-1 < end {
+			end = s.Periods[i+1].StartHeight - 1
+		}
+		if start > end {
+			continue
+		}
+		if p.DecayPeriod == 0 || p.DecayRate == 100 {
+			total += (end - start + 1) * p.Reward
+			continue
+		}
+		//按衰减周期分段求和，每个周期在上一周期的奖励上衰减一次
+		epoch := (start - p.StartHeight) / p.DecayPeriod
+		reward := p.decay(epoch)
+		for h := start; h <= end && reward != 0; epoch++ {
+			next := p.StartHeight + (epoch+1)*p.DecayPeriod
+			if next-1 > end {
+				next = end + 1
+			}
+			total += (next - h) * reward
+			h = next
+			reward = reward * p.DecayRate / 100
+		}
+	}
+	return total
+}
+
+// Distr 按出币计划在txs之后追加块高为height的矿工交易，QTJ用来验证订单签名
+func (s *EmissionSchedule) Distr(txs []*transaction.Transaction, minaddr, Ds, Cm, QTJ types.Address, height uint64) []*transaction.Transaction {
+	p := s.period(height)
+	total := s.Reward(height)
+	var weight uint64
+	for _, share := range p.Shares {
+		weight += share.Weight
+	}
+	each, mod := total/weight, total%weight
+
+	resolve := func(recipient string) types.Address {
+		switch recipient {
+		case RecipientMiner:
+			return minaddr
+		case RecipientDs:
+			return Ds
+		case RecipientCm:
+			return Cm
+		}
+		addr, _ := types.StringToAddress(recipient)
+		return *addr
+	}
+
+	var orders []types.Address
+	for _, tx := range txs {
+		if tx.IsOrderTransaction() && tx.Order.Vertify(QTJ, tx.ChainID) {
+			orders = append(orders, tx.Order.Address)
+		}
+	}
+
+	//同一接收方的份额合并为一笔交易，按第一次出现的顺序
+	var recipients []types.Address
+	amounts := make(map[types.Address]uint64)
+	credit := func(addr types.Address, amount uint64) {
+		if _, ok := amounts[addr]; !ok {
+			recipients = append(recipients, addr)
+		}
+		amounts[addr] += amount
+	}
+
+	var coinbases []*transaction.Transaction
+	for _, share := range p.Shares {
+		amount := each * share.Weight
+		if share.Recipient != RecipientOrders {
+			credit(resolve(share.Recipient), amount)
+		} else if len(orders) != 0 {
+			fAmount, fMod := amount/uint64(len(orders)), amount%uint64(len(orders))
+			for _, addr := range orders {
+				coinbases = append(coinbases, transaction.NewCoinBaseTransaction(addr, fAmount))
+			}
+			credit(resolve(share.Fallback), fMod)
+		} else {
+			credit(resolve(share.Fallback), amount)
+		}
+	}
+	credit(resolve(p.Remainder), mod)
+
+	for _, addr := range recipients {
+		coinbases = append(coinbases, transaction.NewCoinBaseTransaction(addr, amounts[addr]))
+	}
+	return append(txs, coinbases...)
+}
+
+// CheckCoinbase 检查块末尾的矿工交易与出币计划相同
+func (s *EmissionSchedule) CheckCoinbase(b *block.Block, Ds, Cm, QTJ types.Address) error {
+	n := len(b.Transactions)
+	for n > 0 && b.Transactions[n-1].IsCoinBaseTransaction() {
+		n--
+	}
+	txs := append([]*transaction.Transaction{}, b.Transactions[:n]...)
+	expected := s.Distr(txs, b.Miner, Ds, Cm, QTJ, b.Height)[n:]
+	coinbases := b.Transactions[n:]
+	if len(coinbases) != len(expected) {
+		return fmt.Errorf("emission: %d coinbase transactions, expected %d", len(coinbases), len(expected))
+	}
+	for i, tx := range coinbases {
+		if tx.To != expected[i].To || tx.Amount != expected[i].Amount {
+			return fmt.Errorf("emission: coinbase %d pays %d to %s, expected %d to %s", i,
+				tx.Amount, tx.To.String(), expected[i].Amount, expected[i].To.String())
+		}
+	}
+	return nil
+}
+
+// Encode 把出币计划写入编码器，字段顺序固定
+func (s *EmissionSchedule) Encode(e *codec.Encoder) {
+	e.Uint32(uint32(len(s.Periods)))
+	for _, p := range s.Periods {
+		e.Uint64(p.StartHeight)
+		e.Uint64(p.Reward)
+		e.Uint64(p.DecayPeriod)
+		e.Uint64(p.DecayRate)
+		e.Uint32(uint32(len(p.Shares)))
+		for _, share := range p.Shares {
+			e.String(share.Recipient)
+			e.Uint64(share.Weight)
+			e.String(share.Fallback)
+		}
+		e.String(p.Remainder)
+	}
+}
+
+// DecodeEmission 从解码器读取出币计划
+func DecodeEmission(d *codec.Decoder) (*EmissionSchedule, error) {
+	var s EmissionSchedule
+	n := d.Uint32()
+	for i := uint32(0); i < n && d.Err() == nil; i++ {
+		var p EmissionPeriod
+		p.StartHeight = d.Uint64()
+		p.Reward = d.Uint64()
+		p.DecayPeriod = d.Uint64()
+		p.DecayRate = d.Uint64()
+		m := d.Uint32()
+		for j := uint32(0); j < m && d.Err() == nil; j++ {
+			var share EmissionShare
+			share.Recipient = d.String()
+			share.Weight = d.Uint64()
+			share.Fallback = d.String()
+			p.Shares = append(p.Shares, share)
+		}
+		p.Remainder = d.String()
+		s.Periods = append(s.Periods, p)
+	}
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetEmissionSchedule 获取出币计划，创世文件中没有设置时为DefaultEmission
+func (bc *Blockchain) GetEmissionSchedule() (*EmissionSchedule, error) {
+	return getEmission(bc.db.Get(EmissionKey))
+}
+
+func getEmission(data []byte, err error) (*EmissionSchedule, error) {
+	if err == store.NotExist {
+		return DefaultEmission(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	d := codec.NewDecoder(data)
+	s, err := DecodeEmission(d)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
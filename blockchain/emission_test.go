@@ -0,0 +1,67 @@
+package blockchain
+
+import (
+	"kortho/block"
+	"testing"
+)
+
+func TestDefaultEmission(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	s := DefaultEmission()
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Reward(31535999) != 49460000000 || s.Reward(31536000) != 39568000000 {
+		t.Fatalf("reward %d %d", s.Reward(31535999), s.Reward(31536000))
+	}
+
+	//没有订单时订单用户的10%记入电商，余数记入技术
+	txs := s.Distr(nil, c.miner, c.ds, c.cm, c.qtj, 1)
+	if len(txs) != 3 {
+		t.Fatalf("%d coinbase transactions", len(txs))
+	}
+	for i, expected := range []struct {
+		to     string
+		amount uint64
+	}{{c.ds.String(), 9892000000}, {c.miner.String(), 19784000000}, {c.cm.String(), 19784000000}} {
+		if txs[i].To.String() != expected.to || txs[i].Amount != expected.amount {
+			t.Fatalf("coinbase %d pays %d to %s", i, txs[i].Amount, txs[i].To.String())
+		}
+	}
+
+	b := &block.Block{Height: 1, Miner: c.miner, Transactions: txs}
+	if err := s.CheckCoinbase(b, c.ds, c.cm, c.qtj); err != nil {
+		t.Fatal(err)
+	}
+	txs[1].Amount++
+	if err := s.CheckCoinbase(b, c.ds, c.cm, c.qtj); err == nil {
+		t.Fatal("wrong coinbase amount accepted")
+	}
+}
+
+func TestEmitted(t *testing.T) {
+	s := &EmissionSchedule{Periods: []EmissionPeriod{
+		{StartHeight: 0, Reward: 1000, DecayPeriod: 7, DecayRate: 50, Shares: []EmissionShare{{Recipient: RecipientMiner, Weight: 1}}, Remainder: RecipientMiner},
+		{StartHeight: 30, Reward: 300, Shares: []EmissionShare{{Recipient: RecipientMiner, Weight: 1}}, Remainder: RecipientMiner},
+		{StartHeight: 40, Reward: 80, DecayPeriod: 3, DecayRate: 90, Shares: []EmissionShare{{Recipient: RecipientMiner, Weight: 1}}, Remainder: RecipientMiner},
+		{StartHeight: 70, Reward: 5, DecayPeriod: 1, DecayRate: 100, Shares: []EmissionShare{{Recipient: RecipientMiner, Weight: 1}}, Remainder: RecipientMiner},
+	}}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var total uint64
+	for h := uint64(1); h <= 100; h++ {
+		total += s.Reward(h)
+		if emitted := s.Emitted(h); emitted != total {
+			t.Fatalf("height %d: emitted %d, expected %d", h, emitted, total)
+		}
+	}
+
+	//不衰减的段直接相乘，不按衰减周期循环
+	if emitted := s.Emitted(1 << 40); emitted != total+((1<<40)-100)*5 {
+		t.Fatalf("emitted %d at height 1<<40", emitted)
+	}
+}
@@ -0,0 +1,358 @@
+package blockchain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"kortho/block"
+	"kortho/contract/exec"
+	"kortho/contract/parser"
+	"kortho/logger"
+	"kortho/transaction"
+	"kortho/types"
+	"kortho/util/codec"
+	"kortho/util/merkle"
+	"kortho/util/miscellaneous"
+	"kortho/util/store"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/sha3"
+)
+
+var (
+	// GenesisKey 数据库中存储创世块hash的键
+	GenesisKey = []byte("genesis")
+	// ValidatorsKey 数据库中存储验证者集合的键
+	ValidatorsKey = []byte("validators")
+)
+
+// Genesis 创世文件genesis.json的内容
+type Genesis struct {
+	ChainID    uint64           `json:"chainid"`    //链ID
+	Timestamp  int64            `json:"timestamp"`  //创世块的时间戳
+	Admin      string           `json:"admin"`      //锁仓管理员地址
+	Alloc      []GenesisAccount `json:"alloc"`      //初始余额
+	Validators []string         `json:"validators"` //初始验证者，格式为"address@host:port"
+	Tokens     []GenesisToken   `json:"tokens"`     //初始代币
+
+	Emission *EmissionSchedule `json:"emission,omitempty"` //出币计划，为空时使用DefaultEmission
+	Params   *ChainParams      `json:"params,omitempty"`   //出块规则，为空时使用DefaultChainParams
+}
+
+// GenesisAccount 创世时分配的余额
+type GenesisAccount struct {
+	Address string `json:"address"`
+	Balance uint64 `json:"balance"`
+}
+
+// GenesisToken 创世时创建的代币，总量记入Owner
+type GenesisToken struct {
+	Symbol   string `json:"symbol"`
+	Owner    string `json:"owner"`
+	Total    uint64 `json:"total"`
+	Decimals uint64 `json:"decimals"`
+}
+
+// LoadGenesis 读取并检查创世文件
+func LoadGenesis(path string) (*Genesis, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var g Genesis
+	if err := json.Unmarshal(data, &g); err != nil {
+		return nil, err
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// Validate 检查创世文件中的地址、重复项和余额总量
+func (g *Genesis) Validate() error {
+	if g.Admin != "" {
+		if _, err := types.StringToAddress(g.Admin); err != nil {
+			return fmt.Errorf("genesis: invalid admin %s", g.Admin)
+		}
+	}
+
+	var total uint64
+	addrs := make(map[string]bool)
+	for _, a := range g.Alloc {
+		if _, err := types.StringToAddress(a.Address); err != nil {
+			return fmt.Errorf("genesis: invalid alloc address %s", a.Address)
+		}
+		if addrs[a.Address] {
+			return fmt.Errorf("genesis: duplicate alloc address %s", a.Address)
+		}
+		addrs[a.Address] = true
+		if total+a.Balance < total {
+			return errors.New("genesis: total balance overflows")
+		}
+		total += a.Balance
+	}
+
+	vals := make(map[string]bool)
+	for _, v := range g.Validators {
+		if err := CheckValidator(v); err != nil {
+			return fmt.Errorf("genesis: %v", err)
+		}
+		if vals[v] {
+			return fmt.Errorf("genesis: duplicate validator %s", v)
+		}
+		vals[v] = true
+	}
+
+	symbols := make(map[string]bool)
+	for _, t := range g.Tokens {
+		if t.Symbol == "" || strings.ContainsAny(t.Symbol, "\" ") {
+			return fmt.Errorf("genesis: invalid token symbol %q", t.Symbol)
+		}
+		if symbols[t.Symbol] {
+			return fmt.Errorf("genesis: duplicate token %s", t.Symbol)
+		}
+		symbols[t.Symbol] = true
+		if _, err := types.StringToAddress(t.Owner); err != nil {
+			return fmt.Errorf("genesis: invalid token owner %s", t.Owner)
+		}
+	}
+
+	if g.Emission != nil {
+		if err := g.Emission.Validate(); err != nil {
+			return err
+		}
+	}
+	if g.Params != nil {
+		return g.Params.Validate()
+	}
+	return nil
+}
+
+// Hash 创世文件内容的hash，字段顺序固定，分配的余额按地址排列
+func (g *Genesis) Hash() []byte {
+	alloc := append([]GenesisAccount{}, g.Alloc...)
+	sort.Slice(alloc, func(i, j int) bool { return alloc[i].Address < alloc[j].Address })
+
+	e := codec.NewEncoder()
+	e.Uint64(g.ChainID)
+	e.Int64(g.Timestamp)
+	e.String(g.Admin)
+	e.Uint32(uint32(len(alloc)))
+	for _, a := range alloc {
+		e.String(a.Address)
+		e.Uint64(a.Balance)
+	}
+	e.Uint32(uint32(len(g.Validators)))
+	for _, v := range g.Validators {
+		e.String(v)
+	}
+	e.Uint32(uint32(len(g.Tokens)))
+	for _, t := range g.Tokens {
+		e.String(t.Symbol)
+		e.String(t.Owner)
+		e.Uint64(t.Total)
+		e.Uint64(t.Decimals)
+	}
+	if g.Emission != nil {
+		g.Emission.Encode(e)
+	}
+	if g.Params != nil {
+		g.Params.Encode(e)
+	}
+	hash := sha3.Sum256(e.Result())
+	return hash[:]
+}
+
+// Block 由创世文件得到的高度为0的创世块，PrevHash为创世文件内容的hash，因此块hash覆盖全部创世数据
+func (g *Genesis) Block() *block.Block {
+	b := &block.Block{
+		Height:    0,
+		PrevHash:  g.Hash(),
+		Root:      merkle.New(sha256.New(), nil).GetMtHash(),
+		Version:   block.BinaryVersion,
+		Timestamp: g.Timestamp,
+	}
+	b.SetHash()
+	return b
+}
+
+// InitGenesis 用创世文件初始化空的数据库，写入创世块、初始余额、验证者、代币和链ID，返回创世块hash
+func (bc *Blockchain) InitGenesis(g *Genesis) ([]byte, error) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	DBTransaction := bc.db.NewTransaction()
+	defer DBTransaction.Cancel()
+	CDBTransaction := bc.cdb.NewTransaction()
+	defer CDBTransaction.Cancel()
+
+	if _, err := DBTransaction.Get(GenesisKey); err == nil {
+		return nil, errors.New("genesis already initialized")
+	}
+	if height, err := getUint64(DBTransaction.Get(HeightKey)); err != nil {
+		return nil, err
+	} else if height != 0 {
+		return nil, fmt.Errorf("database already has %d blocks", height)
+	}
+
+	b := g.Block()
+	if err := DBTransaction.Set(append(HeightPrefix, miscellaneous.E64func(0)...), b.Hash); err != nil {
+		return nil, err
+	}
+	if err := DBTransaction.Set(b.Hash, b.Serialize()); err != nil {
+		return nil, err
+	}
+	if err := DBTransaction.Set(append(HeaderPrefix, b.Hash...), b.Header().Serialize()); err != nil {
+		return nil, err
+	}
+	if err := DBTransaction.Set(HeightKey, miscellaneous.E64func(0)); err != nil {
+		return nil, err
+	}
+
+	//初始余额，计入发行总量
+	var minted uint64
+	addrs := make([][]byte, 0, len(g.Alloc))
+	for _, a := range g.Alloc {
+		if err := setBalance(DBTransaction, []byte(a.Address), miscellaneous.E64func(a.Balance)); err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, []byte(a.Address))
+		minted += a.Balance
+	}
+	if err := setSupplyTotals(DBTransaction, minted, 0); err != nil {
+		return nil, err
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i], addrs[j]) < 0 })
+	t, err := loadState(DBTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateAccounts(t, DBTransaction, addrs); err != nil {
+		return nil, err
+	}
+	root, err := t.Commit(DBTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := DBTransaction.Set(StateRootKey, root); err != nil {
+		return nil, err
+	}
+
+	//初始代币
+	for _, token := range g.Tokens {
+		script := fmt.Sprintf("new \"%s\" %d %d", token.Symbol, token.Total, token.Decimals)
+		e, err := exec.NewWithTx(CDBTransaction, parser.Parser([]byte(script)), token.Owner)
+		if err != nil {
+			logger.Error("Failed to new exec", zap.Error(err), zap.String("script", script))
+			return nil, err
+		}
+		if err := e.Flush(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := setValidators(DBTransaction, g.Validators); err != nil {
+		return nil, err
+	}
+	if g.Emission != nil {
+		e := codec.NewEncoder()
+		g.Emission.Encode(e)
+		if err := DBTransaction.Set(EmissionKey, e.Result()); err != nil {
+			return nil, err
+		}
+	}
+	if g.Params != nil {
+		e := codec.NewEncoder()
+		g.Params.Encode(e)
+		if err := DBTransaction.Set(ParamsKey, e.Result()); err != nil {
+			return nil, err
+		}
+	}
+	if err := DBTransaction.Set(ChainIDKey, miscellaneous.E64func(g.ChainID)); err != nil {
+		return nil, err
+	}
+	if err := DBTransaction.Set(GenesisKey, b.Hash); err != nil {
+		return nil, err
+	}
+
+	if err := bc.commit(DBTransaction, CDBTransaction); err != nil {
+		return nil, err
+	}
+	logger.Info("init genesis", zap.Uint64("chain id", g.ChainID), zap.Int("alloc", len(g.Alloc)),
+		zap.Int("validators", len(g.Validators)), zap.Int("tokens", len(g.Tokens)))
+	return b.Hash, nil
+}
+
+// CheckGenesis 检查数据库中的创世块hash与创世文件相同
+func (bc *Blockchain) CheckGenesis(g *Genesis) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	hash, err := bc.db.Get(GenesisKey)
+	if err == store.NotExist {
+		return errors.New("genesis is not initialized, run kortho init --genesis first")
+	} else if err != nil {
+		return err
+	}
+	if expected := g.Block().Hash; !bytes.Equal(hash, expected) {
+		return fmt.Errorf("genesis hash %x in database does not match genesis file %x", hash, expected)
+	}
+	return nil
+}
+
+// GetGenesisHash 获取创世块hash，没有用创世文件初始化时返回store.NotExist
+func (bc *Blockchain) GetGenesisHash() ([]byte, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.db.Get(GenesisKey)
+}
+
+// firstPrevHash 高度为1的块的PrevHash，用创世文件初始化时为创世块hash，否则由链ID得到
+func firstPrevHash(genesis []byte, err error) ([]byte, error) {
+	if err == store.NotExist {
+		return GenesisPrevHash(transaction.ChainID), nil
+	}
+	return genesis, err
+}
+
+// GetValidators 获取数据库中的验证者集合
+func (bc *Blockchain) GetValidators() ([]string, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	data, err := bc.db.Get(ValidatorsKey)
+	if err == store.NotExist {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return decodeValidators(data)
+}
+
+func setValidators(DBTransaction store.Transaction, vals []string) error {
+	e := codec.NewEncoder()
+	e.Uint32(uint32(len(vals)))
+	for _, v := range vals {
+		e.String(v)
+	}
+	return DBTransaction.Set(ValidatorsKey, e.Result())
+}
+
+func decodeValidators(data []byte) ([]string, error) {
+	d := codec.NewDecoder(data)
+	n := d.Uint32()
+	var vals []string
+	for i := uint32(0); i < n && d.Err() == nil; i++ {
+		vals = append(vals, d.String())
+	}
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return vals, nil
+}
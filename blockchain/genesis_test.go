@@ -0,0 +1,77 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+)
+
+func testGenesis() *Genesis {
+	return &Genesis{
+		ChainID:   0,
+		Timestamp: 1577256099,
+		Admin:     testAddrs[3],
+		Alloc: []GenesisAccount{
+			{Address: testAddrs[1], Balance: 5000},
+			{Address: testAddrs[0], Balance: 1000},
+		},
+		Validators: []string{testAddrs[0] + "@127.0.0.1:9505"},
+		Tokens:     []GenesisToken{{Symbol: "USDT", Owner: testAddrs[2], Total: 100000, Decimals: 2}},
+	}
+}
+
+func TestGenesis(t *testing.T) {
+	g := testGenesis()
+	if err := g.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	var dbs, cdbs [][]byte
+	for i := 0; i < 2; i++ {
+		c := newTestChain(t)
+		defer c.close()
+
+		hash, err := c.InitGenesis(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(hash, g.Block().Hash) {
+			t.Fatalf("genesis hash %x", hash)
+		}
+		if _, err := c.InitGenesis(g); err == nil {
+			t.Fatal("genesis initialized twice")
+		}
+		dbs, cdbs = append(dbs, dump(t, c.db)), append(cdbs, dump(t, c.cdb))
+
+		if err := c.CheckGenesis(g); err != nil {
+			t.Fatal(err)
+		}
+		other := testGenesis()
+		other.Alloc[0].Balance++
+		if err := c.CheckGenesis(other); err == nil {
+			t.Fatal("different genesis accepted")
+		}
+
+		if balance, _ := c.GetBalance([]byte(testAddrs[1])); balance != 5000 {
+			t.Fatalf("balance %d", balance)
+		}
+		if decimals, _ := c.GetTokenDemic([]byte("USDT")); decimals != 2 {
+			t.Fatalf("token decimals %d", decimals)
+		}
+		if vals, _ := c.GetValidators(); len(vals) != 1 || vals[0] != g.Validators[0] {
+			t.Fatalf("validators %v", vals)
+		}
+
+		//高度为1的块连接到创世块
+		c.add(t)
+		b, err := c.GetBlockByHeight(1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(b.PrevHash, hash) {
+			t.Fatalf("prev hash %x", b.PrevHash)
+		}
+	}
+	if !bytes.Equal(dbs[0], dbs[1]) || !bytes.Equal(cdbs[0], cdbs[1]) {
+		t.Fatal("genesis is not deterministic")
+	}
+}
@@ -2,15 +2,19 @@
 package blockchain
 
 import (
+	"io"
 	"kortho/block"
 	"kortho/transaction"
 	"kortho/types"
+	"kortho/util/merkle"
 )
 
 //Blockchains blockchain的接口规范
 type Blockchains interface {
 	NewBlock([]*transaction.Transaction, types.Address, types.Address, types.Address, types.Address) (*block.Block, error)
-	AddBlock(*block.Block, []byte) error
+	AddBlock(*block.Block, *block.Commit) error
+	Rollback(uint64) ([]*transaction.Transaction, error)
+	Reorg(uint64, []*block.Block, []*block.Commit, []byte, []byte, []byte) ([]*transaction.Transaction, error)
 
 	GetNonce([]byte) (uint64, error)
 	GetBalance([]byte) (uint64, error)
@@ -18,10 +22,17 @@ type Blockchains interface {
 	GetHash(uint64) ([]byte, error)
 	GetBlockByHash([]byte) (*block.Block, error)
 	GetBlockByHeight(uint64) (*block.Block, error)
+	GetHeaderByHeight(uint64) (*block.BlockHeader, error)
+	GetHeaders(uint64, uint64) ([]*block.BlockHeader, error)
+	GetBlockCommit(uint64) (*block.Commit, error)
+	VerifyCommit(*block.Block, *block.Commit) error
 	GetFreezeBalance(address []byte) (uint64, error)
+	//最新状态树中的账户证明：块高、状态根、账户和证明
+	GetAccountProof(address []byte) (uint64, []byte, *Account, [][]byte, error)
 
 	GetTransactions(int64, int64) ([]*transaction.Transaction, error)
 	GetTransactionByHash([]byte) (*transaction.Transaction, error)
+	GetTxProof([]byte) (*block.Block, uint64, []merkle.ProofStep, error)
 	GetTransactionByAddr([]byte, int64, int64) ([]*transaction.Transaction, error)
 	GetMaxBlockHeight() (uint64, error)
 
@@ -30,6 +41,7 @@ type Blockchains interface {
 
 	CalculationResults(block *block.Block) ([]byte, error)
 	CheckResults(block *block.Block, resultHash, Ds, Cm, qtj []byte) bool
+	CheckBlock(block *block.Block, Ds, Cm, qtj []byte) error
 	GetBlockSection(lowH, heiH uint64) ([]*block.Block, error)
 
 	//pck操作
@@ -39,4 +51,19 @@ type Blockchains interface {
 	GetPck(addr []byte) (uint64, error)
 
 	GetTokenDemic(symbol []byte) (uint64, error)
+
+	//出币计划
+	GetEmissionSchedule() (*EmissionSchedule, error)
+	GetSupply() (*Supply, error)
+
+	//出块规则，由创世文件决定
+	GetChainParams() (*ChainParams, error)
+
+	//验证者集合，由创世文件和管理员的验证者交易决定
+	GetValidators() ([]string, error)
+	GetProposer(uint64) (string, error)
+
+	//快照
+	Snapshot() (*Snapshot, error)
+	Restore(r io.Reader) (uint64, error)
 }
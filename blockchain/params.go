@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"errors"
+	"kortho/util/codec"
+	"kortho/util/store"
+)
+
+var (
+	// ParamsKey 数据库中存储出块规则的键
+	ParamsKey = []byte("params")
+)
+
+// ChainParams 出块规则，属于共识规则，所有节点必须相同，由创世文件设置
+type ChainParams struct {
+	BlockInterval int64  `json:"blockinterval"` //出块间隔，以秒为单位，块的时间戳至少比父块大这么多
+	MaxBlockTxs   uint64 `json:"maxblocktxs"`   //每个块最多打包的交易数量，不含出币交易
+	CertHeight    uint64 `json:"certheight"`    //从该高度开始块必须带有验证者的提交证书(pbft)，0表示不需要(raft)
+	ProposerTerm  uint64 `json:"proposerterm"`  //每个验证者连续出块的数量，验证者按顺序轮流出块，0为不轮换
+}
+
+// DefaultChainParams 创世文件中没有设置时的出块规则，间隔1秒，每块最多500笔交易
+func DefaultChainParams() *ChainParams {
+	return &ChainParams{BlockInterval: 1, MaxBlockTxs: 500}
+}
+
+// Validate 检查出块间隔和交易数量为正数
+func (p *ChainParams) Validate() error {
+	if p.BlockInterval <= 0 {
+		return errors.New("params: block interval must be positive")
+	}
+	if p.MaxBlockTxs == 0 {
+		return errors.New("params: max block transactions must be positive")
+	}
+	return nil
+}
+
+// Encode 把出块规则写入编码器，字段顺序固定
+func (p *ChainParams) Encode(e *codec.Encoder) {
+	e.Int64(p.BlockInterval)
+	e.Uint64(p.MaxBlockTxs)
+	e.Uint64(p.CertHeight)
+	e.Uint64(p.ProposerTerm)
+}
+
+// DecodeChainParams 从解码器读取Encode写入的出块规则
+func DecodeChainParams(d *codec.Decoder) (*ChainParams, error) {
+	var p ChainParams
+	p.BlockInterval = d.Int64()
+	p.MaxBlockTxs = d.Uint64()
+	p.CertHeight = d.Uint64()
+	p.ProposerTerm = d.Uint64()
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// GetChainParams 获取出块规则，创世文件中没有设置时为DefaultChainParams
+func (bc *Blockchain) GetChainParams() (*ChainParams, error) {
+	return getChainParams(bc.db.Get(ParamsKey))
+}
+
+func getChainParams(data []byte, err error) (*ChainParams, error) {
+	if err == store.NotExist {
+		return DefaultChainParams(), nil
+	} else if err != nil {
+		return nil, err
+	}
+	d := codec.NewDecoder(data)
+	p, err := DecodeChainParams(d)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// NeedCommit 检查该高度的块是否必须带有提交证书
+func (p *ChainParams) NeedCommit(height uint64) bool {
+	return p.CertHeight != 0 && height >= p.CertHeight
+}
@@ -0,0 +1,73 @@
+package blockchain
+
+import (
+	"kortho/transaction"
+	"testing"
+)
+
+func TestCalculationResults(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+	c.add(t)
+
+	newBlock := func(tx *transaction.Transaction) error {
+		b, err := c.NewBlock([]*transaction.Transaction{tx}, c.miner, c.ds, c.cm, c.qtj)
+		if err != nil {
+			return err
+		}
+		_, err = c.CalculationResults(b)
+		return err
+	}
+	if err := newBlock(c.tx(1, "")); err != nil {
+		t.Fatal(err)
+	}
+
+	//其他链的交易
+	foreign := c.tx(1, "")
+	foreign.ChainID = transaction.ChainID + 1
+	foreign.HashTransaction()
+	if err := newBlock(foreign); err != transaction.ErrChainID {
+		t.Fatalf("foreign chain transaction: %v", err)
+	}
+
+	//只有转账交易支付手续费
+	transaction.InitAdmin(c.miner.String())
+	defer transaction.InitAdmin("")
+	freeze := &transaction.Transaction{From: c.miner, To: c.ds, Amount: 1000, Nonce: 1, Fee: 10, Tag: transaction.FreezeTag, Version: transaction.Version}
+	freeze.HashTransaction()
+	if err := newBlock(freeze); err == nil {
+		t.Fatal("freeze transaction with fee accepted")
+	}
+	freeze.Fee = 0
+	freeze.HashTransaction()
+	if err := newBlock(freeze); err != nil {
+		t.Fatal(err)
+	}
+}
+
+//启用块高之前的旧格式交易仍能通过检查
+func TestLegacyVersion(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+	c.add(t)
+
+	legacy := c.tx(1, "")
+	legacy.Version = transaction.VersionLegacy
+	legacy.HashTransaction()
+	b, err := c.NewBlock([]*transaction.Transaction{legacy}, c.miner, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer transaction.InitVersionHeight(0)
+	for _, h := range []uint64{0, b.Height + 1} {
+		transaction.InitVersionHeight(h)
+		if _, err := c.CalculationResults(b); err != nil {
+			t.Fatalf("activation height %d: %v", h, err)
+		}
+	}
+	transaction.InitVersionHeight(b.Height)
+	if _, err := c.CalculationResults(b); err != transaction.ErrLegacyVersion {
+		t.Fatalf("legacy transaction at the activation height: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package blockchain
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"kortho/logger"
+	"kortho/util/miscellaneous"
+	"kortho/util/store"
+	"kortho/util/store/bg"
+	"os"
+
+	"go.uber.org/zap"
+)
+
+// Snapshot 区块链在某一高度的快照，blockchain.db和contract.db处于同一时刻
+type Snapshot struct {
+	Height uint64
+	db     store.Snapshot
+	cdb    store.Snapshot
+}
+
+// Snapshot 创建当前已提交状态的快照，使用完毕后需要调用Release
+func (bc *Blockchain) Snapshot() (*Snapshot, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	height, err := bc.getHeight()
+	if err != nil {
+		logger.Error("failed to get height", zap.Error(err))
+		return nil, err
+	}
+
+	return &Snapshot{
+		Height: height,
+		db:     bc.db.NewSnapshot(),
+		cdb:    bc.cdb.NewSnapshot(),
+	}, nil
+}
+
+// Dump 把快照写入w，格式为：高度 || blockchain.db || contract.db
+func (s *Snapshot) Dump(w io.Writer) error {
+	if _, err := w.Write(miscellaneous.E64func(s.Height)); err != nil {
+		return err
+	}
+	if err := s.db.Dump(w); err != nil {
+		return err
+	}
+	return s.cdb.Dump(w)
+}
+
+// Release 释放快照占用的只读事务
+func (s *Snapshot) Release() {
+	s.db.Release()
+	s.cdb.Release()
+}
+
+// Restore 用Snapshot.Dump导出的数据重建区块链，返回重建后的高度。
+// 如果本地高度不低于快照高度，则保留本地数据
+func (bc *Blockchain) Restore(r io.Reader) (uint64, error) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	buf := make([]byte, 8)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		logger.Error("failed to read snapshot height", zap.Error(err))
+		return 0, err
+	}
+	height, _ := miscellaneous.D64func(buf)
+
+	localHeight, err := bc.getHeight()
+	if err != nil {
+		logger.Error("failed to get height", zap.Error(err))
+		return 0, err
+	}
+	if localHeight >= height {
+		logger.Info("skip restore", zap.Uint64("local height", localHeight), zap.Uint64("snapshot height", height))
+		return localHeight, nil
+	}
+
+	logger.Info("Start to restore snapshot", zap.Uint64("local height", localHeight), zap.Uint64("snapshot height", height))
+	f, err := bc.stageSnapshot(r, height)
+	if err != nil {
+		logger.Error("failed to read snapshot", zap.Error(err))
+		return 0, err
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	sr := bufio.NewReader(f)
+	if err := bc.db.Restore(sr); err != nil {
+		logger.Error("failed to restore blockchain db", zap.Error(err))
+		return 0, err
+	}
+	if err := bc.cdb.Restore(sr); err != nil {
+		logger.Error("failed to restore contract db", zap.Error(err))
+		return 0, err
+	}
+	logger.Info("End restore snapshot", zap.Uint64("height", height))
+	return height, nil
+}
+
+// stageSnapshot 把blockchain.db和contract.db的数据完整读入临时文件，并检查格式和快照高度，
+// 全部检查通过后才清空数据库，快照不完整时不会破坏本地数据
+func (bc *Blockchain) stageSnapshot(r io.Reader, height uint64) (*os.File, error) {
+	f, err := ioutil.TempFile("", "kortho-snapshot")
+	if err != nil {
+		return nil, err
+	}
+	w := bufio.NewWriter(f)
+	tr := io.TeeReader(r, w)
+
+	var dbHeight []byte
+	err = bg.Scan(tr, func(k, v []byte) error {
+		if bytes.Equal(k, HeightKey) {
+			dbHeight = v
+		}
+		return nil
+	})
+	if err == nil {
+		err = bg.Scan(tr, nil)
+	}
+	if err == nil {
+		if h, herr := miscellaneous.D64func(dbHeight); herr != nil || h != height {
+			err = fmt.Errorf("snapshot height mismatch:blockchain db height=%x,snapshot height=%d", dbHeight, height)
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
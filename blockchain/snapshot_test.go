@@ -0,0 +1,47 @@
+package blockchain
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRestore(t *testing.T) {
+	src := newTestChain(t)
+	defer src.close()
+	src.add(t)
+	src.add(t, src.tx(1, `new "USDT" 100000 2`))
+	src.add(t, src.tx(2, ""))
+	s, err := src.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	err = s.Dump(&buf)
+	s.Release()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := newTestChain(t)
+	defer c.close()
+	c.add(t)
+	db, cdb := dump(t, c.db), dump(t, c.cdb)
+
+	//快照不完整时本地数据不变
+	data := buf.Bytes()
+	for _, n := range []int{len(data) - 1, len(data) / 2, 12} {
+		if _, err := c.Restore(bytes.NewReader(data[:n])); err == nil {
+			t.Fatalf("restored %d of %d bytes", n, len(data))
+		}
+		if !bytes.Equal(db, dump(t, c.db)) || !bytes.Equal(cdb, dump(t, c.cdb)) {
+			t.Fatalf("stores changed by %d of %d bytes", n, len(data))
+		}
+	}
+
+	if h, err := c.Restore(bytes.NewReader(data)); err != nil || h != 3 {
+		t.Fatalf("restore height %d,%v", h, err)
+	}
+	if !bytes.Equal(dump(t, src.db), dump(t, c.db)) || !bytes.Equal(dump(t, src.cdb), dump(t, c.cdb)) {
+		t.Fatal("restored stores differ")
+	}
+}
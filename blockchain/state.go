@@ -0,0 +1,287 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"kortho/block"
+	"kortho/logger"
+	"kortho/util/miscellaneous"
+	"kortho/util/store"
+	"kortho/util/trie"
+	"sort"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// StateRootKey 数据库中存储最新状态根的键
+	StateRootKey = []byte("stateroot")
+)
+
+// Account 状态树中的账户
+type Account struct {
+	Balance uint64 `json:"balance"` //余额
+	Nonce   uint64 `json:"nonce"`   //nonce
+	Freeze  uint64 `json:"freeze"`  //冻结金额
+	Pck     uint64 `json:"pck"`     //pck余额
+	DKto    uint64 `json:"dkto"`    //注入资金池的kto
+}
+
+// Serialize 编码为 balance || nonce || freeze || pck || dkto
+func (a *Account) Serialize() []byte {
+	return bytes.Join([][]byte{
+		miscellaneous.E64func(a.Balance),
+		miscellaneous.E64func(a.Nonce),
+		miscellaneous.E64func(a.Freeze),
+		miscellaneous.E64func(a.Pck),
+		miscellaneous.E64func(a.DKto),
+	}, []byte{})
+}
+
+// DeserializeAccount 对状态树中的账户数据反序列化
+func DeserializeAccount(data []byte) (*Account, error) {
+	if len(data) != 40 {
+		return nil, errors.New("illegal account length")
+	}
+	var a Account
+	a.Balance, _ = miscellaneous.D64func(data[0:8])
+	a.Nonce, _ = miscellaneous.D64func(data[8:16])
+	a.Freeze, _ = miscellaneous.D64func(data[16:24])
+	a.Pck, _ = miscellaneous.D64func(data[24:32])
+	a.DKto, _ = miscellaneous.D64func(data[32:40])
+	return &a, nil
+}
+
+// 和从未使用过的地址相同的账户不放入状态树
+func (a *Account) isEmpty() bool {
+	return a.Balance == 0 && a.Nonce <= 1 && a.Freeze == 0 && a.Pck == 0 && a.DKto == 0
+}
+
+// 读取地址的账户状态，没有nonce时和GetNonce一样视为1
+func getAccount(tx store.Transaction, addr []byte) (*Account, error) {
+	var a Account
+	var err error
+
+	if a.Balance, err = getUint64(tx.Get(addr)); err != nil {
+		return nil, err
+	}
+	if a.Freeze, err = getUint64(tx.Mget(FreezeKey, addr)); err != nil {
+		return nil, err
+	}
+	if a.Nonce, err = getUint64(tx.Mget(NonceKey, addr)); err != nil {
+		return nil, err
+	} else if a.Nonce == 0 {
+		a.Nonce = 1
+	}
+	if a.Pck, err = getPck(tx, addr); err != nil {
+		return nil, err
+	}
+	if a.DKto, err = getDKto(tx, addr); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func getUint64(data []byte, err error) (uint64, error) {
+	if err == store.NotExist {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return miscellaneous.D64func(data)
+}
+
+// 块中的交易修改了账户状态的地址(含收取手续费的矿工)，按字节序排列
+func stateAddrs(b *block.Block) [][]byte {
+	set := make(map[string]bool)
+	for _, tx := range b.Transactions {
+		if !tx.IsCoinBaseTransaction() {
+			set[tx.From.String()] = true
+		}
+		set[tx.To.String()] = true
+	}
+	if !b.Miner.IsNil() {
+		set[b.Miner.String()] = true
+	}
+
+	addrs := make([]string, 0, len(set))
+	for addr := range set {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	result := make([][]byte, 0, len(addrs))
+	for _, addr := range addrs {
+		result = append(result, []byte(addr))
+	}
+	return result
+}
+
+// 用数据库中的账户更新状态树
+func updateAccounts(t *trie.Trie, tx store.Transaction, addrs [][]byte) error {
+	for _, addr := range addrs {
+		a, err := getAccount(tx, addr)
+		if err != nil {
+			return err
+		}
+		if a.isEmpty() {
+			err = t.Delete(addr)
+		} else {
+			err = t.Update(addr, a.Serialize())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadState 打开当前的状态树，升级前的数据没有状态根，用所有块中出现过的地址重建
+func loadState(tx store.Transaction) (*trie.Trie, error) {
+	root, err := tx.Get(StateRootKey)
+	if err == nil {
+		return trie.New(root, tx), nil
+	} else if err != store.NotExist {
+		return nil, err
+	}
+
+	t := trie.New(nil, tx)
+	height, err := getUint64(tx.Get(HeightKey))
+	if err != nil {
+		return nil, err
+	}
+	if height == 0 {
+		return t, nil
+	}
+
+	logger.Info("Start to rebuild state trie", zap.Uint64("height", height))
+	set := make(map[string][]byte)
+	for h := uint64(1); h <= height; h++ {
+		hash, err := tx.Get(append(HeightPrefix, miscellaneous.E64func(h)...))
+		if err != nil {
+			logger.Error("failed to get hash", zap.Error(err), zap.Uint64("height", h))
+			return nil, err
+		}
+		data, err := tx.Get(hash)
+		if err != nil {
+			logger.Error("failed to get block", zap.Error(err), zap.Uint64("height", h))
+			return nil, err
+		}
+		b, err := block.Deserialize(data)
+		if err != nil {
+			logger.Error("failed to get block", zap.Error(err), zap.Uint64("height", h))
+			return nil, err
+		}
+		for _, addr := range stateAddrs(b) {
+			set[string(addr)] = addr
+		}
+	}
+	addrs := make([][]byte, 0, len(set))
+	for _, addr := range set {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool { return bytes.Compare(addrs[i], addrs[j]) < 0 })
+	if err := updateAccounts(t, tx, addrs); err != nil {
+		return nil, err
+	}
+	logger.Info("End rebuild state trie", zap.Int("accounts", len(addrs)))
+	return t, nil
+}
+
+// commitState 在DBTransaction中更新状态树并保存新的状态根，block的交易必须已经写入DBTransaction
+func (bc *Blockchain) commitState(DBTransaction store.Transaction, block *block.Block) ([]byte, error) {
+	t, err := loadState(DBTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateAccounts(t, DBTransaction, stateAddrs(block)); err != nil {
+		return nil, err
+	}
+	root, err := t.Commit(DBTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := DBTransaction.Set(StateRootKey, root); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// StateRoot 计算block上链后的状态根，不修改数据库
+func (bc *Blockchain) StateRoot(block *block.Block) ([]byte, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return bc.stateRoot(block)
+}
+
+func (bc *Blockchain) stateRoot(block *block.Block) ([]byte, error) {
+	DBTransaction := bc.db.NewTransaction()
+	defer DBTransaction.Cancel()
+
+	if err := bc.applyTransactions(DBTransaction, nil, block); err != nil {
+		return nil, err
+	}
+	t, err := loadState(DBTransaction)
+	if err != nil {
+		return nil, err
+	}
+	if err := updateAccounts(t, DBTransaction, stateAddrs(block)); err != nil {
+		return nil, err
+	}
+	return t.Hash(), nil
+}
+
+// GetStateRoot 获取最新的状态根
+func (bc *Blockchain) GetStateRoot() ([]byte, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	root, err := bc.db.Get(StateRootKey)
+	if err == store.NotExist {
+		return trie.EmptyRoot, nil
+	}
+	return root, err
+}
+
+// GetAccountProof 获取地址在最新状态树中的账户和证明，同时返回状态树所属的块高，账户不存在时返回nil和不存在的证明
+func (bc *Blockchain) GetAccountProof(address []byte) (uint64, []byte, *Account, [][]byte, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	DBTransaction := bc.db.NewTransaction()
+	defer DBTransaction.Cancel()
+
+	height, err := getUint64(DBTransaction.Get(HeightKey))
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	t, err := loadState(DBTransaction)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	proof, err := t.Prove(address)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	data, err := t.Get(address)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	if data == nil {
+		return height, t.Hash(), nil, proof, nil
+	}
+	a, err := DeserializeAccount(data)
+	if err != nil {
+		return 0, nil, nil, nil, err
+	}
+	return height, t.Hash(), a, proof, nil
+}
+
+// VerifyAccountProof 用状态根验证账户证明，返回账户，不存在时返回nil
+func VerifyAccountProof(root, address []byte, proof [][]byte) (*Account, error) {
+	data, err := trie.VerifyProof(root, address, proof)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return DeserializeAccount(data)
+}
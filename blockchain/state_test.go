@@ -0,0 +1,56 @@
+package blockchain
+
+import (
+	"bytes"
+	"kortho/transaction"
+	"testing"
+)
+
+func TestStateRoot(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	c.add(t)
+	c.add(t, c.tx(1, ""))
+
+	//状态根错误的块不能上链
+	b, err := c.NewBlock([]*transaction.Transaction{c.tx(2, "")}, c.miner, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.StateRoot = append([]byte{}, b.StateRoot...)
+	b.StateRoot[0]++
+	b.SetHash()
+	if err := c.AddBlock(b, nil); err == nil {
+		t.Fatal("block with wrong state root added")
+	}
+	if h, _ := c.GetHeight(); h != 2 {
+		t.Fatalf("height %d,want 2", h)
+	}
+
+	//账户证明可以用已提交块头的状态根验证
+	height, root, a, proof, err := c.GetAccountProof(c.cm.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := c.GetHeaderByHeight(height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 2 || !bytes.Equal(root, h.StateRoot) {
+		t.Fatalf("proof at %d root %x,header state root %x", height, root, h.StateRoot)
+	}
+	got, err := VerifyAccountProof(h.StateRoot, c.cm.Bytes(), proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, _ := c.GetBalance(c.cm.Bytes())
+	if got == nil || *got != *a || got.Balance != balance {
+		t.Fatalf("account %+v,want %+v balance %d", got, a, balance)
+	}
+
+	//证明不能用于其他地址
+	if got, err := VerifyAccountProof(h.StateRoot, c.miner.Bytes(), proof); err == nil && got != nil {
+		t.Fatal("proof verified for another address")
+	}
+}
@@ -0,0 +1,146 @@
+package blockchain
+
+import (
+	"errors"
+	"kortho/block"
+	"kortho/logger"
+	"kortho/util"
+	"kortho/util/miscellaneous"
+	"kortho/util/store"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// MintedTotalKey 数据库中存储KTO发行总量(创世分配和出块奖励)的键
+	MintedTotalKey = []byte("mintedtotal")
+	// FrozenTotalKey 数据库中存储锁仓KTO总量的键
+	FrozenTotalKey = []byte("frozentotal")
+)
+
+// Supply KTO的供应量
+type Supply struct {
+	Height      uint64 `json:"height"`      //统计时的块高
+	Minted      uint64 `json:"minted"`      //发行总量
+	Frozen      uint64 `json:"frozen"`      //锁仓总量
+	PckPool     uint64 `json:"pckpool"`     //兑换pck注入资金池的KTO总量
+	Circulating uint64 `json:"circulating"` //流通量，即发行总量减去锁仓和资金池中的KTO
+}
+
+// getSupplyTotals 读取块高为height时的发行总量和锁仓总量，升级前的数据没有总量，用height及之前的块重新统计
+func getSupplyTotals(tx store.Transaction, height uint64) (minted, frozen uint64, err error) {
+	data, err := tx.Get(MintedTotalKey)
+	if err == store.NotExist {
+		return rebuildSupply(tx, height)
+	} else if err != nil {
+		return 0, 0, err
+	}
+	if minted, err = miscellaneous.D64func(data); err != nil {
+		return 0, 0, err
+	}
+	if frozen, err = getUint64(tx.Get(FrozenTotalKey)); err != nil {
+		return 0, 0, err
+	}
+	return minted, frozen, nil
+}
+
+// rebuildSupply 发行总量为块高1到height中矿工交易的金额之和，锁仓总量为所有地址的冻结金额之和
+func rebuildSupply(tx store.Transaction, height uint64) (minted, frozen uint64, err error) {
+	if height == 0 {
+		return 0, 0, nil
+	}
+
+	logger.Info("Start to rebuild supply", zap.Uint64("height", height))
+	for h := uint64(1); h <= height; h++ {
+		hash, err := tx.Get(append(HeightPrefix, miscellaneous.E64func(h)...))
+		if err != nil {
+			logger.Error("failed to get hash", zap.Error(err), zap.Uint64("height", h))
+			return 0, 0, err
+		}
+		data, err := tx.Get(hash)
+		if err != nil {
+			logger.Error("failed to get block", zap.Error(err), zap.Uint64("height", h))
+			return 0, 0, err
+		}
+		b, err := block.Deserialize(data)
+		if err != nil {
+			logger.Error("failed to get block", zap.Error(err), zap.Uint64("height", h))
+			return 0, 0, err
+		}
+		for _, t := range b.Transactions {
+			if t.IsCoinBaseTransaction() {
+				minted += t.Amount
+			}
+		}
+	}
+
+	_, vals, err := tx.Mkvs(FreezeKey)
+	if err != nil && err != store.NotExist {
+		return 0, 0, err
+	}
+	for _, val := range vals {
+		bal, err := miscellaneous.D64func(val)
+		if err != nil {
+			return 0, 0, err
+		}
+		frozen += bal
+	}
+	logger.Info("End rebuild supply", zap.Uint64("minted", minted), zap.Uint64("frozen", frozen))
+	return minted, frozen, nil
+}
+
+func setSupplyTotals(tx store.Transaction, minted, frozen uint64) error {
+	if err := tx.Set(MintedTotalKey, miscellaneous.E64func(minted)); err != nil {
+		return err
+	}
+	return tx.Set(FrozenTotalKey, miscellaneous.E64func(frozen))
+}
+
+// addSupply 按block中的矿工交易和锁仓、解锁交易更新总量
+func addSupply(block *block.Block, minted, frozen uint64) (uint64, uint64, error) {
+	for _, tx := range block.Transactions {
+		switch {
+		case tx.IsCoinBaseTransaction():
+			if util.Uint64AddOverflow(minted, tx.Amount) {
+				return 0, 0, errors.New("minted total overflow")
+			}
+			minted += tx.Amount
+		case tx.IsFreezeTransaction():
+			if util.Uint64AddOverflow(frozen, tx.Amount) {
+				return 0, 0, errors.New("frozen total overflow")
+			}
+			frozen += tx.Amount
+		case tx.IsUnfreezeTransaction():
+			if frozen < tx.Amount {
+				return 0, 0, errors.New("frozen total underflow")
+			}
+			frozen -= tx.Amount
+		}
+	}
+	return minted, frozen, nil
+}
+
+// GetSupply 获取当前的发行总量、锁仓总量、资金池总量和流通量
+func (bc *Blockchain) GetSupply() (*Supply, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	tx := bc.db.NewTransaction()
+	defer tx.Cancel()
+
+	var s Supply
+	var err error
+	if s.Height, err = getUint64(tx.Get(HeightKey)); err != nil {
+		return nil, err
+	}
+	if s.Minted, s.Frozen, err = getSupplyTotals(tx, s.Height); err != nil {
+		return nil, err
+	}
+	if s.PckPool, err = getDKtoTotal(tx); err != nil {
+		return nil, err
+	}
+	if !util.Uint64SubOverflow(s.Minted, s.Frozen, s.PckPool) {
+		s.Circulating = s.Minted - s.Frozen - s.PckPool
+	}
+	return &s, nil
+}
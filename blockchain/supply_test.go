@@ -0,0 +1,50 @@
+package blockchain
+
+import (
+	"kortho/block"
+	"kortho/transaction"
+	"math"
+	"testing"
+)
+
+func TestSupply(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	transaction.InitAdmin(c.ds.String())
+	defer transaction.InitAdmin("")
+
+	c.add(t)
+	freeze := &transaction.Transaction{From: c.ds, To: c.cm, Amount: 5, Nonce: 1, Tag: transaction.FreezeTag}
+	freeze.HashTransaction()
+	c.add(t, freeze)
+	s, err := c.GetSupply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	emitted := DefaultEmission().Emitted(2)
+	if s.Height != 2 || s.Minted != emitted || s.Frozen != 5 || s.Circulating != emitted-5 {
+		t.Fatalf("supply %+v", s)
+	}
+
+	//删除总量后重新统计
+	if err := c.db.Del(MintedTotalKey); err != nil {
+		t.Fatal(err)
+	}
+	if rebuilt, err := c.GetSupply(); err != nil || *rebuilt != *s {
+		t.Fatalf("rebuilt supply %+v, %v", rebuilt, err)
+	}
+
+	if err := c.DeleteBlock(2); err != nil {
+		t.Fatal(err)
+	}
+	if s, err = c.GetSupply(); err != nil || s.Minted != DefaultEmission().Emitted(1) || s.Frozen != 0 {
+		t.Fatalf("supply after DeleteBlock %+v, %v", s, err)
+	}
+
+	//锁仓总量溢出
+	b := &block.Block{Transactions: []*transaction.Transaction{freeze}}
+	if _, _, err := addSupply(b, 0, math.MaxUint64-4); err == nil {
+		t.Fatal("frozen total overflow")
+	}
+}
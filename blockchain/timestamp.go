@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"fmt"
+	"kortho/block"
+	"kortho/util/store"
+	"math"
+	"sort"
+)
+
+const (
+	// MedianTimeBlocks 计算过去中位时间(median time past)使用的父块数量
+	MedianTimeBlocks = 11
+	// MaxFutureDrift 块的时间戳最多可以超过本地时间的秒数
+	MaxFutureDrift = 15
+)
+
+// minTimestamp 返回高度height的块允许的最小时间戳，至少比父块的时间戳大出块间隔，并且大于之前MedianTimeBlocks个块时间戳的中位数
+// 没有创世块的链的第一个块不限制
+func minTimestamp(r reader, height uint64) (int64, error) {
+	var times []int64
+	for h := height; h > 0 && len(times) < MedianTimeBlocks; h-- {
+		header, err := getHeaderByHeight(r, h-1)
+		if err == store.NotExist && h == 1 {
+			break
+		} else if err != nil {
+			return 0, err
+		}
+		times = append(times, header.Timestamp)
+	}
+	if len(times) == 0 {
+		return math.MinInt64, nil
+	}
+
+	params, err := getChainParams(r.Get(ParamsKey))
+	if err != nil {
+		return 0, err
+	}
+	min := times[0] + params.BlockInterval
+	sort.Slice(times, func(i, j int) bool { return times[i] < times[j] })
+	if median := times[len(times)/2] + 1; median > min {
+		min = median
+	}
+	return min, nil
+}
+
+// CheckTimestamp 检查块的时间戳不早于父块的时间戳加出块间隔，严格大于过去中位时间，并且不超过本地时间now加MaxFutureDrift
+func (bc *Blockchain) CheckTimestamp(b *block.Block, now int64) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+	return checkTimestamp(bc.db, b, now)
+}
+
+func checkTimestamp(r reader, b *block.Block, now int64) error {
+	min, err := minTimestamp(r, b.Height)
+	if err != nil {
+		return err
+	}
+	if b.Timestamp < min {
+		return fmt.Errorf("block timestamp %d is not one block interval after the parent or not after the median time past, min %d", b.Timestamp, min)
+	}
+	if b.Timestamp > now+MaxFutureDrift {
+		return fmt.Errorf("block timestamp %d is more than %d seconds ahead of local time %d", b.Timestamp, MaxFutureDrift, now)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package blockchain
+
+import (
+	"kortho/block"
+	"testing"
+	"time"
+)
+
+func TestCheckTimestamp(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	g := testGenesis()
+	if _, err := c.InitGenesis(g); err != nil {
+		t.Fatal(err)
+	}
+	//AddBlock不检查时间戳，写入不递增的时间戳
+	for _, d := range []int64{100, 300, 400, 50} {
+		b, err := c.NewBlock(nil, c.miner, c.ds, c.cm, c.qtj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Timestamp = g.Timestamp + d
+		b.SetHash()
+		if err := c.AddBlock(b, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	//父块为g+50，过去中位时间为g+100
+	now := g.Timestamp + 1000
+	for _, tc := range []struct {
+		timestamp int64
+		ok        bool
+	}{
+		{g.Timestamp + 50, false},
+		{g.Timestamp + 60, false},
+		{g.Timestamp + 101, true},
+		{now + MaxFutureDrift, true},
+		{now + MaxFutureDrift + 1, false},
+	} {
+		err := c.CheckTimestamp(&block.Block{Height: 5, Timestamp: tc.timestamp}, now)
+		if (err == nil) != tc.ok {
+			t.Fatalf("timestamp %d: %v", tc.timestamp, err)
+		}
+	}
+
+	//本地时钟落后于父块时新块使用父块时间戳加出块间隔
+	b, err := c.NewBlock(nil, c.miner, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Unix() + 100
+	b.Timestamp = future
+	b.SetHash()
+	if err := c.AddBlock(b, nil); err != nil {
+		t.Fatal(err)
+	}
+	if b, err = c.NewBlock(nil, c.miner, c.ds, c.cm, c.qtj); err != nil {
+		t.Fatal(err)
+	}
+	if b.Timestamp != future+1 {
+		t.Fatalf("new block timestamp %d, want %d", b.Timestamp, future+1)
+	}
+}
+
+func TestBlockInterval(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	g := testGenesis()
+	g.Timestamp = time.Now().Unix()
+	g.Params = &ChainParams{BlockInterval: 10, MaxBlockTxs: 1}
+	if _, err := c.InitGenesis(g); err != nil {
+		t.Fatal(err)
+	}
+	if params, err := c.GetChainParams(); err != nil || *params != *g.Params {
+		t.Fatalf("params %v, %v", params, err)
+	}
+
+	now := g.Timestamp + 1000
+	for _, tc := range []struct {
+		timestamp int64
+		ok        bool
+	}{
+		{g.Timestamp + 9, false},
+		{g.Timestamp + 10, true},
+	} {
+		err := c.CheckTimestamp(&block.Block{Height: 1, Timestamp: tc.timestamp}, now)
+		if (err == nil) != tc.ok {
+			t.Fatalf("timestamp %d: %v", tc.timestamp, err)
+		}
+	}
+
+	//本地时钟落后时新块使用父块时间戳加出块间隔
+	b, err := c.NewBlock(nil, c.miner, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Timestamp != g.Timestamp+10 {
+		t.Fatalf("new block timestamp %d, want %d", b.Timestamp, g.Timestamp+10)
+	}
+
+	g.Params = &ChainParams{BlockInterval: 0, MaxBlockTxs: 1}
+	if err := g.Validate(); err == nil {
+		t.Fatal("genesis with zero block interval")
+	}
+}
@@ -0,0 +1,247 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+	"kortho/block"
+	"kortho/logger"
+	"kortho/transaction"
+	"kortho/util/codec"
+	"kortho/util/miscellaneous"
+	"kortho/util/store"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var (
+	// UndoPrefix 块撤销记录key的前缀，key为前缀+块高
+	UndoPrefix = []byte("blockundo")
+)
+
+// blockUndo 块的撤销记录，db和cdb中被块修改的key的原始数据
+type blockUndo struct {
+	db  []*store.Undo
+	cdb []*store.Undo
+}
+
+// Serialize 编码为 db undos || cdb undos
+func (u *blockUndo) Serialize() []byte {
+	e := codec.NewEncoder()
+	encodeUndos(e, u.db)
+	encodeUndos(e, u.cdb)
+	return e.Result()
+}
+
+func deserializeUndo(data []byte) (*blockUndo, error) {
+	d := codec.NewDecoder(data)
+	u := &blockUndo{db: decodeUndos(d), cdb: decodeUndos(d)}
+	if err := d.Finish(); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
+
+// encodeUndos 编码为 count || (key || exist || value)...
+func encodeUndos(e *codec.Encoder, undos []*store.Undo) {
+	e.Uint32(uint32(len(undos)))
+	for _, u := range undos {
+		e.Bytes(u.Key)
+		e.Bool(u.Exist)
+		e.Bytes(u.Value)
+	}
+}
+
+func decodeUndos(d *codec.Decoder) []*store.Undo {
+	n := d.Uint32()
+	var undos []*store.Undo
+	for i := uint32(0); i < n && d.Err() == nil; i++ {
+		u := &store.Undo{}
+		u.Key = d.Bytes()
+		u.Exist = d.Bool()
+		u.Value = d.Bytes()
+		undos = append(undos, u)
+	}
+	return undos
+}
+
+// startJournal 开始记录db和cdb中被修改的key
+func startJournal(DBTransaction, CDBTransaction store.Transaction) {
+	DBTransaction.StartJournal()
+	CDBTransaction.StartJournal()
+}
+
+// writeUndo 停止记录，并把撤销记录写入DBTransaction
+func writeUndo(DBTransaction, CDBTransaction store.Transaction, height uint64) error {
+	u := &blockUndo{db: DBTransaction.Journal(), cdb: CDBTransaction.Journal()}
+	return DBTransaction.Set(append(UndoPrefix, miscellaneous.E64func(height)...), u.Serialize())
+}
+
+// Rollback 在一个事务中回滚到toHeight，返回被回滚块中的交易(不含coinbase)，按块高升序
+func (bc *Blockchain) Rollback(toHeight uint64) ([]*transaction.Transaction, error) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	DBTransaction := bc.db.NewTransaction()
+	defer DBTransaction.Cancel()
+	CDBTransaction := bc.cdb.NewTransaction()
+	defer CDBTransaction.Cancel()
+
+	orphans, err := bc.rollback(DBTransaction, CDBTransaction, toHeight)
+	if err != nil {
+		return nil, err
+	}
+	if err = bc.commit(DBTransaction, CDBTransaction); err != nil {
+		return nil, err
+	}
+	return orphans, nil
+}
+
+// Reorg 在一个事务中回滚到toHeight并依次检查和添加newBlocks，返回不在newBlocks中的被回滚交易
+// 新块在回滚后的数据上按CheckResults的规则检查，commits[i]为newBlocks[i]的提交证书，规则同VerifyCommit，任何一个块出错时不修改数据库
+func (bc *Blockchain) Reorg(toHeight uint64, newBlocks []*block.Block, commits []*block.Commit, Ds, Cm, qtj []byte) ([]*transaction.Transaction, error) {
+	if len(commits) != len(newBlocks) {
+		return nil, fmt.Errorf("%d commit certificates for %d blocks", len(commits), len(newBlocks))
+	}
+
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	DBTransaction := bc.db.NewTransaction()
+	defer DBTransaction.Cancel()
+	CDBTransaction := bc.cdb.NewTransaction()
+	defer CDBTransaction.Cancel()
+
+	orphans, err := bc.rollback(DBTransaction, CDBTransaction, toHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	prevHash, err := DBTransaction.Get(append(HeightPrefix, miscellaneous.E64func(toHeight)...))
+	if err != nil && err != store.NotExist {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	included := make(map[string]bool)
+	for i, b := range newBlocks {
+		if toHeight > 0 && !bytes.Equal(b.PrevHash, prevHash) {
+			return nil, fmt.Errorf("block %d does not link to previous block", b.Height)
+		}
+		if err = b.CheckHash(); err != nil {
+			return nil, err
+		}
+		//证书由块之前的验证者集合签名，块中的验证者交易从下一个块开始生效
+		c, err := checkCommit(DBTransaction, b, commits[i])
+		if err != nil {
+			logger.Error("failed to check commit certificate", zap.Error(err), zap.Uint64("height", b.Height))
+			return nil, err
+		}
+		//addBlock对比块中的状态根和计算的状态根，没有状态根的旧块不对比
+		if _, err = checkBlock(DBTransaction, b, Ds, Cm, qtj, now); err != nil {
+			logger.Error("failed to check block", zap.Error(err), zap.Uint64("height", b.Height))
+			return nil, err
+		}
+		if err = bc.addBlock(DBTransaction, CDBTransaction, b); err != nil {
+			logger.Error("failed to add block", zap.Error(err), zap.Uint64("height", b.Height))
+			return nil, err
+		}
+		if c != nil {
+			if err = DBTransaction.Set(append(CommitPrefix, miscellaneous.E64func(b.Height)...), c.Serialize()); err != nil {
+				return nil, err
+			}
+		}
+		for _, tx := range b.Transactions {
+			included[string(tx.Hash)] = true
+		}
+		prevHash = b.Hash
+	}
+
+	if err = bc.commit(DBTransaction, CDBTransaction); err != nil {
+		return nil, err
+	}
+
+	txs := make([]*transaction.Transaction, 0, len(orphans))
+	for _, tx := range orphans {
+		if !included[string(tx.Hash)] {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+// rollback 从当前块高开始依次回放撤销记录，直到块高为toHeight，没有撤销记录的旧块按交易反向处理
+func (bc *Blockchain) rollback(DBTransaction, CDBTransaction store.Transaction, toHeight uint64) ([]*transaction.Transaction, error) {
+	height, err := getUint64(DBTransaction.Get(HeightKey))
+	if err != nil {
+		logger.Error("failed to get height", zap.Error(err))
+		return nil, err
+	}
+	if toHeight > height {
+		return nil, fmt.Errorf("Wrong height to rollback,[%v] should <= current height[%v]", toHeight, height)
+	}
+
+	var blocks []*block.Block
+	for h := height; h > toHeight; h-- {
+		logger.Info("Start to rollback block", zap.Uint64("height", h))
+		hash, err := DBTransaction.Get(append(HeightPrefix, miscellaneous.E64func(h)...))
+		if err != nil {
+			logger.Error("failed to get hash", zap.Error(err), zap.Uint64("height", h))
+			return nil, err
+		}
+		data, err := DBTransaction.Get(hash)
+		if err != nil {
+			logger.Error("failed to get block", zap.Error(err), zap.Uint64("height", h))
+			return nil, err
+		}
+		b, err := block.Deserialize(data)
+		if err != nil {
+			return nil, err
+		}
+
+		undoKey := append(UndoPrefix, miscellaneous.E64func(h)...)
+		data, err = DBTransaction.Get(undoKey)
+		switch err {
+		case nil:
+			u, err := deserializeUndo(data)
+			if err != nil {
+				logger.Error("failed to decode undo record", zap.Error(err), zap.Uint64("height", h))
+				return nil, err
+			}
+			if err = CDBTransaction.Revert(u.cdb); err != nil {
+				logger.Error("failed to revert contract", zap.Error(err), zap.Uint64("height", h))
+				return nil, err
+			}
+			if err = DBTransaction.Revert(u.db); err != nil {
+				logger.Error("failed to revert block", zap.Error(err), zap.Uint64("height", h))
+				return nil, err
+			}
+			if err = DBTransaction.Del(undoKey); err != nil {
+				return nil, err
+			}
+		case store.NotExist:
+			if err = bc.deleteLegacyBlock(DBTransaction, CDBTransaction, b); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+
+		//提交证书
+		if err = DBTransaction.Del(append(CommitPrefix, miscellaneous.E64func(h)...)); err != nil {
+			logger.Error("Failed to Del commit", zap.Error(err))
+			return nil, err
+		}
+		blocks = append(blocks, b)
+	}
+
+	var orphans []*transaction.Transaction
+	for i := len(blocks) - 1; i >= 0; i-- {
+		for _, tx := range blocks[i].Transactions {
+			if !tx.IsCoinBaseTransaction() {
+				orphans = append(orphans, tx)
+			}
+		}
+	}
+	return orphans, nil
+}
@@ -0,0 +1,206 @@
+package blockchain
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"kortho/block"
+	"kortho/logger"
+	"kortho/transaction"
+	"kortho/types"
+	"kortho/util/store"
+	"kortho/util/store/bg"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+var testAddrs = []string{
+	"KtoC5gP1TLyUWbHRkp1gfpMrbdBawnqxQi3NdYtB31dgtJE",
+	"Kto3PYebE3gTorcqYf59uHc2PcCdoANzgvgmZXa21r559rR",
+	"KtoD6ELKyafRZU9SDMKfDpRZdyjHugsdmTvDXTH1ED2SmBt",
+	"Kto2YGvFKXQtSazWp9hPZyBrA9JPkxgNE6GW56o7jcdQXTq",
+}
+
+type testChain struct {
+	*Blockchain
+	dir                string
+	miner, ds, cm, qtj types.Address
+}
+
+func newTestChain(t *testing.T) *testChain {
+	logger.Logger = zap.NewNop()
+	dir, err := ioutil.TempDir("", "kortho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := &testChain{dir: dir}
+	c.open()
+	for i, a := range []*types.Address{&c.miner, &c.ds, &c.cm, &c.qtj} {
+		addr, _ := types.StringToAddress(testAddrs[i])
+		*a = *addr
+	}
+	return c
+}
+
+func (c *testChain) open() {
+	c.Blockchain = &Blockchain{
+		db:  bg.New(filepath.Join(c.dir, BlockchainDBName)),
+		cdb: bg.New(filepath.Join(c.dir, ContractDBName)),
+	}
+}
+
+func (c *testChain) close() {
+	c.db.Close()
+	c.cdb.Close()
+	os.RemoveAll(c.dir)
+}
+
+func (c *testChain) add(t *testing.T, txs ...*transaction.Transaction) {
+	b, err := c.NewBlock(txs, c.miner, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AddBlock(b, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func (c *testChain) tx(nonce uint64, script string) *transaction.Transaction {
+	tx := &transaction.Transaction{From: c.ds, To: c.cm, Amount: 1000, Nonce: nonce, Version: transaction.Version}
+	if script != "" {
+		tx.Script, tx.Fee = script, 10
+	}
+	tx.HashTransaction()
+	return tx
+}
+
+func dump(t *testing.T, db store.DB) []byte {
+	s := db.NewSnapshot()
+	defer s.Release()
+	var buf bytes.Buffer
+	if err := s.Dump(&buf); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDeleteBlockRestoresStores(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	c.add(t)
+	c.add(t, c.tx(1, `new "USDT" 100000 2`), c.tx(2, `mint "USDT" 10000`))
+	db, cdb := dump(t, c.db), dump(t, c.cdb)
+
+	c.add(t, c.tx(3, ""), c.tx(4, fmt.Sprintf(`transfer "USDT" 20 "%s"`, c.cm.String())))
+	c.add(t, transaction.NewFreezeTransaction(c.ds, 5, 5), c.tx(6, fmt.Sprintf(`transfer "USDT" 30 "%s"`, c.cm.String())))
+	if bytes.Equal(cdb, dump(t, c.cdb)) {
+		t.Fatal("token transfer did not change contract.db")
+	}
+
+	if err := c.DeleteBlock(4); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.DeleteBlock(3); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(db, dump(t, c.db)) {
+		t.Fatal("blockchain.db differs after DeleteBlock")
+	}
+	if !bytes.Equal(cdb, dump(t, c.cdb)) {
+		t.Fatal("contract.db differs after DeleteBlock")
+	}
+}
+
+func TestReorg(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	c.add(t)
+	db := dump(t, c.db)
+	b1, err := c.GetBlockByHeight(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx := c.tx(1, "")
+	c.add(t, tx)
+	c.add(t, c.tx(2, ""))
+	b2, err := c.GetBlockByHeight(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orphans, err := c.Rollback(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 2 || !bytes.Equal(orphans[0].Hash, tx.Hash) {
+		t.Fatalf("orphans: %v", orphans)
+	}
+	if !bytes.Equal(db, dump(t, c.db)) {
+		t.Fatal("blockchain.db differs after Rollback")
+	}
+
+	c.add(t, tx)
+	c.add(t, c.tx(2, ""))
+
+	//检查不通过的块不修改数据库
+	db = dump(t, c.db)
+	bad := *b2
+	bad.Timestamp = b1.Timestamp
+	bad.SetHash()
+	if _, err = c.Reorg(1, []*block.Block{&bad}, []*block.Commit{nil}, c.ds.Bytes(), c.cm.Bytes(), c.qtj.Bytes()); err == nil {
+		t.Fatal("reorg with a block not after its parent")
+	}
+	if !bytes.Equal(db, dump(t, c.db)) {
+		t.Fatal("blockchain.db differs after failed Reorg")
+	}
+
+	//没有状态根的旧块不对比状态根
+	legacy := *b2
+	legacy.StateRoot = nil
+	legacy.SetHash()
+	if orphans, err = c.Reorg(1, []*block.Block{&legacy}, []*block.Commit{nil}, c.ds.Bytes(), c.cm.Bytes(), c.qtj.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+	if len(orphans) != 1 {
+		t.Fatalf("orphans: %v", orphans)
+	}
+	if h, _ := c.GetHeight(); h != 2 {
+		t.Fatalf("height %d", h)
+	}
+}
+
+func TestReorgCommit(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	g := testGenesis()
+	g.Params = DefaultChainParams()
+	g.Params.CertHeight = 1
+	if _, err := c.InitGenesis(g); err != nil {
+		t.Fatal(err)
+	}
+	b, err := c.NewBlock(nil, c.miner, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db := dump(t, c.db)
+
+	//有验证者集合的链从CertHeight开始块必须带有有效的提交证书
+	for _, commits := range [][]*block.Commit{
+		nil,
+		{nil},
+		{{Height: b.Height, Hash: b.Hash}},
+	} {
+		if _, err = c.Reorg(0, []*block.Block{b}, commits, c.ds.Bytes(), c.cm.Bytes(), c.qtj.Bytes()); err == nil {
+			t.Fatalf("reorg with commits %v", commits)
+		}
+	}
+	if !bytes.Equal(db, dump(t, c.db)) {
+		t.Fatal("blockchain.db differs after failed Reorg")
+	}
+}
@@ -0,0 +1,189 @@
+package blockchain
+
+import (
+	"errors"
+	"fmt"
+	"kortho/block"
+	"kortho/transaction"
+	"kortho/types"
+	"kortho/util/store"
+	"net"
+	"strings"
+)
+
+// CheckValidator 检查验证者的格式"address@host:port"，address为验证者公钥对应的地址，host:port为共识的监听地址
+func CheckValidator(v string) error {
+	i := strings.Index(v, "@")
+	if i < 0 || strings.Count(v, "@") != 1 {
+		return fmt.Errorf("invalid validator %q", v)
+	}
+	addr, err := types.StringToAddress(v[:i])
+	if err != nil || len(v[:i]) != types.AddressSize || !addr.Verify() {
+		return fmt.Errorf("invalid validator address %q", v[:i])
+	}
+	if _, _, err := net.SplitHostPort(v[i+1:]); err != nil {
+		return fmt.Errorf("invalid validator host %q", v[i+1:])
+	}
+	return nil
+}
+
+// ChangeValidators 返回验证者集合vals执行验证者交易tx之后的集合，vals不变
+// 添加时地址不能已经是验证者，删除时验证者必须在集合中，并且不能删除最后一个验证者
+func ChangeValidators(vals []string, tx *transaction.Transaction) ([]string, error) {
+	if !tx.IsValidatorTransaction() {
+		return nil, errors.New("not a validator transaction")
+	}
+	//旧格式交易的签名不覆盖Tag和Script
+	if tx.Version < transaction.VersionFull {
+		return nil, transaction.ErrLegacyVersion
+	}
+	if err := CheckValidator(tx.Script); err != nil {
+		return nil, err
+	}
+
+	id := tx.Script[:strings.Index(tx.Script, "@")]
+	if tx.Tag == transaction.AddValidatorTag {
+		for _, v := range vals {
+			if strings.HasPrefix(v, id+"@") {
+				return nil, fmt.Errorf("validator %s already exists", id)
+			}
+		}
+		return append(append([]string{}, vals...), tx.Script), nil
+	}
+
+	var result []string
+	for _, v := range vals {
+		if v != tx.Script {
+			result = append(result, v)
+		}
+	}
+	if len(result) == len(vals) {
+		return nil, fmt.Errorf("validator %s does not exist", tx.Script)
+	}
+	if len(result) == 0 {
+		return nil, errors.New("can not remove the last validator")
+	}
+	return result, nil
+}
+
+// applyValidatorTransaction 按验证者交易修改数据库中的验证者集合，从下一个块开始生效
+func applyValidatorTransaction(DBTransaction store.Transaction, tx *transaction.Transaction) error {
+	vals, err := getValidators(DBTransaction)
+	if err != nil {
+		return err
+	}
+	if vals, err = ChangeValidators(vals, tx); err != nil {
+		return err
+	}
+	return setValidators(DBTransaction, vals)
+}
+
+// errNoValidators 链上没有验证者集合，无法检查提交证书
+var errNoValidators = errors.New("no validator set to verify the commit")
+
+// getValidators 获取事务中的验证者集合，没有时返回nil
+func getValidators(r reader) ([]string, error) {
+	data, err := r.Get(ValidatorsKey)
+	if err == store.NotExist {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return decodeValidators(data)
+}
+
+// Quorum n个验证者时提交一个块需要的签名数2f+1，f=(n-1)/3
+func Quorum(n int) int {
+	return 2*((n-1)/3) + 1
+}
+
+// VerifyCommit 用已提交的验证者集合检查下一个块的提交证书，
+// 链上有验证者集合且块高度达到出块规则的CertHeight时证书不能为空，raft产生的块没有证书
+func (bc *Blockchain) VerifyCommit(b *block.Block, c *block.Commit) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	_, err := checkCommit(bc.db, b, c)
+	return err
+}
+
+// checkCommit 检查块的提交证书，返回需要和块一起保存的证书，没有验证者集合时证书无法检查，返回nil
+func checkCommit(r reader, b *block.Block, c *block.Commit) (*block.Commit, error) {
+	if c == nil {
+		vals, err := getValidators(r)
+		if err != nil {
+			return nil, err
+		}
+		params, err := getChainParams(r.Get(ParamsKey))
+		if err != nil {
+			return nil, err
+		}
+		if len(vals) != 0 && params.NeedCommit(b.Height) {
+			return nil, errors.New("no commit certificate")
+		}
+		return nil, nil
+	}
+	if err := verifyCommit(r, b, c); err == errNoValidators {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// verifyCommit 用块之前的验证者集合检查提交证书，块中的验证者交易从下一个块开始生效
+func verifyCommit(r reader, b *block.Block, c *block.Commit) error {
+	vals, err := getValidators(r)
+	if err != nil {
+		return err
+	}
+	if len(vals) == 0 {
+		return errNoValidators
+	}
+	addrs := make([]string, 0, len(vals))
+	for _, v := range vals {
+		addrs = append(addrs, strings.Split(v, "@")[0])
+	}
+	return c.Verify(b, addrs, Quorum(len(addrs)))
+}
+
+// GetProposer 返回高度height的计划出块者，链上没有验证者集合或者出块规则不轮换时返回空字符串
+func (bc *Blockchain) GetProposer(height uint64) (string, error) {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	params, err := getChainParams(bc.db.Get(ParamsKey))
+	if err != nil {
+		return "", err
+	}
+	return getProposer(bc.db, height, params.ProposerTerm)
+}
+
+func getProposer(r reader, height, term uint64) (string, error) {
+	vals, err := getValidators(r)
+	if err != nil {
+		return "", err
+	}
+	return Proposer(vals, height, term), nil
+}
+
+// checkProposer 检查块的矿工地址是计划出块者的验证者地址
+func checkProposer(r reader, b *block.Block, term uint64) error {
+	proposer, err := getProposer(r, b.Height, term)
+	if err != nil || proposer == "" {
+		return err
+	}
+	if addr := proposer[:strings.Index(proposer, "@")]; addr != b.Miner.String() {
+		return fmt.Errorf("block %d is mined by %s,the scheduled proposer is %s", b.Height, b.Miner.String(), addr)
+	}
+	return nil
+}
+
+// Proposer 返回高度height的计划出块者，验证者按vals中的顺序轮流出块，每个验证者连续出term个块
+// vals为空或者term为0时不轮换，返回空字符串
+func Proposer(vals []string, height, term uint64) string {
+	if len(vals) == 0 || term == 0 || height == 0 {
+		return ""
+	}
+	return vals[(height-1)/term%uint64(len(vals))]
+}
@@ -0,0 +1,109 @@
+package blockchain
+
+import (
+	"kortho/block"
+	"kortho/transaction"
+	"testing"
+)
+
+func TestValidatorTransaction(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	transaction.InitAdmin(c.ds.String())
+	defer transaction.InitAdmin("")
+
+	g := testGenesis()
+	if _, err := c.InitGenesis(g); err != nil {
+		t.Fatal(err)
+	}
+	validator := func(nonce uint64, v string, opt func(string) transaction.ModOption) *transaction.Transaction {
+		return transaction.ZNewTransaction(nonce, 0, c.ds, c.ds, opt(v))
+	}
+
+	added := testAddrs[1] + "@127.0.0.1:9605"
+	c.add(t, validator(1, added, transaction.WithAddValidator))
+	if vals, _ := c.GetValidators(); len(vals) != 2 || vals[1] != added {
+		t.Fatalf("validators %v", vals)
+	}
+
+	//重复添加、删除不存在的验证者和删除最后一个验证者的块不能通过检查
+	for _, tx := range []*transaction.Transaction{
+		validator(2, testAddrs[1]+"@127.0.0.1:9705", transaction.WithAddValidator),
+		validator(2, testAddrs[2]+"@127.0.0.1:9705", transaction.WithRemoveValidator),
+		validator(2, "Kto@127.0.0.1:9705", transaction.WithAddValidator),
+	} {
+		if _, err := c.CalculationResults(&block.Block{Height: 2, Transactions: []*transaction.Transaction{tx}}); err == nil {
+			t.Fatalf("%s accepted", tx.Script)
+		}
+	}
+	remove := []*transaction.Transaction{
+		validator(2, g.Validators[0], transaction.WithRemoveValidator),
+		validator(3, added, transaction.WithRemoveValidator),
+	}
+	if _, err := c.CalculationResults(&block.Block{Height: 2, Transactions: remove}); err == nil {
+		t.Fatal("removed the last validator")
+	}
+
+	c.add(t, remove[0])
+	if vals, _ := c.GetValidators(); len(vals) != 1 || vals[0] != added {
+		t.Fatalf("validators %v", vals)
+	}
+	if err := c.DeleteBlock(2); err != nil {
+		t.Fatal(err)
+	}
+	if vals, _ := c.GetValidators(); len(vals) != 2 {
+		t.Fatalf("validators after DeleteBlock %v", vals)
+	}
+}
+
+//轮换出块时块的矿工地址必须是计划出块者的验证者地址
+func TestProposerMiner(t *testing.T) {
+	c := newTestChain(t)
+	defer c.close()
+
+	g := testGenesis()
+	g.Params = DefaultChainParams()
+	g.Params.ProposerTerm = 2
+	g.Validators = append(g.Validators, testAddrs[1]+"@127.0.0.1:9506")
+	if _, err := c.InitGenesis(g); err != nil {
+		t.Fatal(err)
+	}
+	for h := uint64(1); h <= 3; h++ {
+		if p, _ := c.GetProposer(h); p != g.Validators[(h-1)/2] {
+			t.Fatalf("proposer of %d: %q", h, p)
+		}
+	}
+
+	b, err := c.NewBlock(nil, c.ds, c.ds, c.cm, c.qtj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = c.CheckBlock(b, c.ds.Bytes(), c.cm.Bytes(), c.qtj.Bytes()); err == nil {
+		t.Fatal("block of an unscheduled miner accepted")
+	}
+	c.add(t)
+	c.add(t)
+	if b, err = c.NewBlock(nil, c.ds, c.ds, c.cm, c.qtj); err != nil {
+		t.Fatal(err)
+	}
+	b.Timestamp++
+	b.SetHash()
+	if err = c.CheckBlock(b, c.ds.Bytes(), c.cm.Bytes(), c.qtj.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProposer(t *testing.T) {
+	vals := []string{"a", "b", "c"}
+	for _, c := range []struct {
+		height, term uint64
+		want         string
+	}{
+		{1, 2, "a"}, {2, 2, "a"}, {3, 2, "b"}, {6, 2, "c"}, {7, 2, "a"}, {4, 1, "a"}, {5, 0, ""},
+	} {
+		if p := Proposer(vals, c.height, c.term); p != c.want {
+			t.Fatalf("height %d term %d: %q, want %q", c.height, c.term, p, c.want)
+		}
+	}
+}
@@ -280,7 +280,7 @@ func SetVote(V Vote, tx store.Transaction) {
 
 	logger.Info("===============Individual  voting statistics=============")
 
-	logger.Info("vote mes:", zap.String("node", string(V.node)), zap.String("amt", string(V.amount)), zap.String("addr", string(V.address)))
+	logger.Info("vote mes:", zap.String("node", string(V.node)), zap.Uint64("amt", V.amount), zap.String("addr", string(V.address)))
 
 	var vamt uint64
 
@@ -165,11 +165,13 @@ func (c *CLI) freezeBalance(freezeTransaction string) {
 	}
 
 	tx := &transaction.Transaction{
-		From:   *from,
-		To:     *to,
-		Amount: freezeTx.Amount,
-		Nonce:  nonceResp.Nonce,
-		Time:   time.Now().Unix(),
+		From:    *from,
+		To:      *to,
+		Amount:  freezeTx.Amount,
+		Nonce:   nonceResp.Nonce,
+		Time:    time.Now().Unix(),
+		Tag:     transaction.FreezeTag,
+		Version: transaction.Version,
 	}
 	tx.HashTransaction()
 
@@ -183,6 +185,7 @@ func (c *CLI) freezeBalance(freezeTransaction string) {
 	freezeTx.Nonce = tx.Nonce
 	freezeTx.Hash = tx.Hash
 	freezeTx.Signature = tx.Signature
+	freezeTx.Version = tx.Version
 
 	var req message.ReqSignedTransactions
 	req.Txs = append(req.Txs, &freezeTx)
@@ -231,11 +234,13 @@ func (c *CLI) unFreezeTransaction(unfreezeTransaction string) {
 	}
 
 	tx := &transaction.Transaction{
-		From:   *from,
-		To:     *to,
-		Amount: freezeTx.Amount,
-		Nonce:  nonceResp.Nonce,
-		Time:   time.Now().Unix(),
+		From:    *from,
+		To:      *to,
+		Amount:  freezeTx.Amount,
+		Nonce:   nonceResp.Nonce,
+		Time:    time.Now().Unix(),
+		Tag:     transaction.UnfreezeTag,
+		Version: transaction.Version,
 	}
 	tx.HashTransaction()
 
@@ -246,6 +251,7 @@ func (c *CLI) unFreezeTransaction(unfreezeTransaction string) {
 	freezeTx.Nonce = tx.Nonce
 	freezeTx.Hash = tx.Hash
 	freezeTx.Signature = tx.Signature
+	freezeTx.Version = tx.Version
 
 	var req message.ReqSignedTransactions
 	req.Txs = append(req.Txs, &freezeTx)
@@ -7,7 +7,10 @@ import (
 )
 
 type CfgInfo struct {
-	LogConfig *LogConfigInfo `yaml:"logconfig"`
+	ChainID         uint64         `yaml:"chainid"`         //链ID，参与交易hash和创世块
+	TxVersionHeight uint64         `yaml:"txversionheight"` //从该块高开始只接受签名覆盖全部字段的交易，0表示不启用
+	Genesis         string         `yaml:"genesis"`         //创世文件路径，启动时检查数据库中的创世块hash，为空时不检查
+	LogConfig       *LogConfigInfo `yaml:"logconfig"`
 	//AddressConfig *AddressConfigInfo `yaml:"addressconfig"`
 	P2PConfigList []*P2PConfigInfo `yaml:"p2pconfig"`
 	//	P2PTxConfig     *P2PTxConfigInfo     `yaml:"p2pTxconfig"`
@@ -88,6 +91,7 @@ type ConsensusConfigInfo struct {
 }
 
 type BftConfig struct {
+	Engine           string   `yaml:"engine"`
 	NodeNum          uint64   `yaml:"nodenum"`
 	Peers            []string `yaml:"peers"`
 	HttpAddr         string   `yaml:"httpaddr"`
@@ -110,6 +114,18 @@ type BftConfig struct {
 	LogLevel         int      `yaml:"loglevel"`
 	LogSaveMode      int      `yaml:"logsavemode"`
 	LogFileSize      int64    `yaml:"logfilesize"`
+	PrivKey          string   `yaml:"privkey"`
+	Validators       []string `yaml:"validators"`
+	ViewTimeout      int64    `yaml:"viewtimeout"`
+	TxLifetime       int64    `yaml:"txlifetime"` //交易在交易池中的最长存在时间，以秒为单位，0为默认值
+
+	//空块配置，出块间隔、每块交易数量和出块者轮换在创世文件的params中
+	EmptyBlockPolicy  string `yaml:"emptyblockpolicy"`  //没有交易时的出块策略，always总是出块，skip不出块，heartbeat最多隔heartbeatinterval秒出一个空块，默认always
+	HeartbeatInterval int64  `yaml:"heartbeatinterval"` //heartbeat策略下空块的最长间隔，以秒为单位
+
+	//落后时从其他节点批量同步块
+	SyncPeers []string `yaml:"syncpeers"` //同步块的节点的grpc地址，为空时使用leader的ip和rpcport
+	SyncBatch uint64   `yaml:"syncbatch"` //每次请求的块数量，0为默认值500
 }
 
 type MonitorConfig struct {
@@ -120,6 +136,7 @@ type MonitorConfig struct {
 	RpcPort          string   `yaml:"rpcport"`
 	RaftPort         string   `yaml:"raftport"`
 	AccountAddr      string   `yaml:"accountAddr"`
+	AdminKey         string   `yaml:"adminkey"` //管理员私钥，用来签名暂停和恢复本节点的请求
 }
 
 // LoadConfig 加载配置信息
@@ -52,13 +52,30 @@ func Precision(db store.DB, id string) (uint64, error) {
 }
 
 func New(db store.DB, scs []*parser.Script, owner string) (*exec, error) {
+	mp, err := run(db, scs, owner)
+	if err != nil {
+		return nil, err
+	}
+	return &exec{db: db, mp: mp}, nil
+}
+
+// NewWithTx reads from tx and Flush writes into tx without committing it
+func NewWithTx(tx store.Transaction, scs []*parser.Script, owner string) (*exec, error) {
+	mp, err := run(tx, scs, owner)
+	if err != nil {
+		return nil, err
+	}
+	return &exec{tx: tx, mp: mp}, nil
+}
+
+func run(db Getter, scs []*parser.Script, owner string) (map[string]string, error) {
 	mp := make(map[string]string)
 	for i, j := 0, len(scs); i < j; i++ {
 		if err := dealRegistry[scs[i].Name()](db, owner, mp, scs[i]); err != nil {
 			return nil, err
 		}
 	}
-	return &exec{db, mp}, nil
+	return mp, nil
 }
 
 func (e *exec) Root() []byte {
@@ -77,18 +94,34 @@ func (e *exec) Root() []byte {
 }
 
 func (e *exec) Flush() error {
+	if e.tx != nil {
+		return e.write(e.tx)
+	}
 	tx := e.db.NewTransaction()
 	defer tx.Cancel()
-	for k, v := range e.mp {
-		if err := tx.Set([]byte(k), []byte(v)); err != nil {
+	if err := e.write(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// write sets keys in sorted order so that the undo journal is deterministic
+func (e *exec) write(tx store.Transaction) error {
+	var ss []string
+	for k := range e.mp {
+		ss = append(ss, k)
+	}
+	sort.Strings(ss)
+	for _, k := range ss {
+		if err := tx.Set([]byte(k), []byte(e.mp[k])); err != nil {
 			return err
 		}
 	}
-	return tx.Commit()
+	return nil
 }
 
 // new tokenId total_amount precision
-func deal0(db store.DB, executor string, mp map[string]string, sc *parser.Script) error {
+func deal0(db Getter, executor string, mp map[string]string, sc *parser.Script) error {
 	arg0, _ := sc.Arguments()[0].Value().(string) // tokenId
 	arg1, _ := sc.Arguments()[1].Value().(uint64) // total_amount
 	arg2, _ := sc.Arguments()[2].Value().(uint64) // precision
@@ -122,7 +155,7 @@ func deal0(db store.DB, executor string, mp map[string]string, sc *parser.Script
 }
 
 // mint tokenId amount
-func deal1(db store.DB, executor string, mp map[string]string, sc *parser.Script) error {
+func deal1(db Getter, executor string, mp map[string]string, sc *parser.Script) error {
 	arg0, _ := sc.Arguments()[0].Value().(string) // tokenId
 	arg1, _ := sc.Arguments()[1].Value().(uint64) // amount
 	{
@@ -202,7 +235,7 @@ func deal1(db store.DB, executor string, mp map[string]string, sc *parser.Script
 }
 
 // transfer tokenId amount address
-func deal2(db store.DB, executor string, mp map[string]string, sc *parser.Script) error {
+func deal2(db Getter, executor string, mp map[string]string, sc *parser.Script) error {
 	var from, to uint64
 
 	arg0, _ := sc.Arguments()[0].Value().(string) // tokenId
@@ -283,7 +316,7 @@ func deal2(db store.DB, executor string, mp map[string]string, sc *parser.Script
 }
 
 // freeze tokenId address
-func deal3(db store.DB, executor string, mp map[string]string, sc *parser.Script) error {
+func deal3(db Getter, executor string, mp map[string]string, sc *parser.Script) error {
 	arg0, _ := sc.Arguments()[0].Value().(string) // tokenId
 	arg1, _ := sc.Arguments()[1].Value().(string) // address
 	{
@@ -300,7 +333,7 @@ func deal3(db store.DB, executor string, mp map[string]string, sc *parser.Script
 }
 
 // unfreeze tokenId address
-func deal4(db store.DB, executor string, mp map[string]string, sc *parser.Script) error {
+func deal4(db Getter, executor string, mp map[string]string, sc *parser.Script) error {
 	arg0, _ := sc.Arguments()[0].Value().(string) // tokenId
 	arg1, _ := sc.Arguments()[1].Value().(string) // address
 	{
@@ -317,12 +350,12 @@ func deal4(db store.DB, executor string, mp map[string]string, sc *parser.Script
 }
 
 // rate
-func deal5(db store.DB, executor string, mp map[string]string, sc *parser.Script) error {
+func deal5(db Getter, executor string, mp map[string]string, sc *parser.Script) error {
 	return nil
 }
 
 // post
-func deal6(db store.DB, executor string, mp map[string]string, sc *parser.Script) error {
+func deal6(db Getter, executor string, mp map[string]string, sc *parser.Script) error {
 	return nil
 }
 
@@ -11,9 +11,15 @@ type Exec interface {
 	Flush() error
 }
 
+// Getter is implemented by store.DB and store.Transaction
+type Getter interface {
+	Get([]byte) ([]byte, error)
+}
+
 type exec struct {
 	db store.DB
+	tx store.Transaction
 	mp map[string]string
 }
 
-type scriptDealFunc (func(store.DB, string, map[string]string, *parser.Script) error)
+type scriptDealFunc (func(Getter, string, map[string]string, *parser.Script) error)
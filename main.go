@@ -19,6 +19,14 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "init" {
+		if err := runInit(os.Args[2:]); err != nil {
+			fmt.Println("init failed:", err)
+			os.Exit(-1)
+		}
+		return
+	}
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		fmt.Println("load config failed:", err)
@@ -26,20 +34,47 @@ func main() {
 	}
 
 	transaction.InitAdmin(cfg.APIConfig.RPCConfig.AdminAddr)
+	transaction.InitChainID(cfg.ChainID)
+	transaction.InitVersionHeight(cfg.TxVersionHeight)
 
 	if err = logger.InitLogger(cfg.LogConfig); err != nil {
 		fmt.Println("logger.InitLogger failed:", err)
 		os.Exit(-1)
 	}
 
-	tp, err := txpool.New(cfg.BFTConfig.QTJ)
+	bc := blockchain.New()
+	if err = bc.CheckChainID(cfg.ChainID); err != nil {
+		logger.Error("Failed to check chain id", zap.Error(err))
+		os.Exit(-1)
+	}
+	if cfg.Genesis != "" {
+		g, err := blockchain.LoadGenesis(cfg.Genesis)
+		if err != nil {
+			logger.Error("Failed to load genesis", zap.Error(err), zap.String("file", cfg.Genesis))
+			os.Exit(-1)
+		}
+		if err = bc.CheckGenesis(g); err != nil {
+			logger.Error("Failed to check genesis", zap.Error(err))
+			os.Exit(-1)
+		}
+		if g.Admin != "" {
+			transaction.InitAdmin(g.Admin)
+		}
+	}
+	//链上的验证者集合由创世文件和验证者交易决定，链上没有时才使用配置中的验证者
+	if vals, err := bc.GetValidators(); err != nil {
+		logger.Error("Failed to get validators", zap.Error(err))
+		os.Exit(-1)
+	} else if cfg.BFTConfig != nil && len(vals) != 0 {
+		cfg.BFTConfig.Validators = vals
+	}
+
+	tp, err := txpool.New(cfg.BFTConfig.QTJ, cfg.BFTConfig.TxLifetime, bc)
 	if err != nil {
 		logger.Error("Failed to new txpool", zap.Error(err))
 		os.Exit(-1)
 	}
 
-	bc := blockchain.New()
-
 	nB, err := node.New(cfg.P2PConfigList[0], tp, bc) //use for blocks Broadcast
 	if err != nil {
 		logger.Error("failed to new p2p node", zap.Error(err))
@@ -70,7 +105,7 @@ func main() {
 	}
 
 	if cfg.MonitorCfg != nil {
-		monitor.Run(cfg.MonitorCfg, bc)
+		monitor.Run(cfg.MonitorCfg, cfg.BFTConfig, bc, tp)
 	}
 
 	if cfg.APIConfig == nil {
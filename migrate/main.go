@@ -0,0 +1,112 @@
+// migrate 把blockchain.db中json格式的块数据改写为二进制编码并补充单独存储的块头，块hash和块高索引不变
+//
+// 用法：migrate [blockchain.db路径]，需要在节点停止时执行
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"kortho/block"
+	"kortho/blockchain"
+	"kortho/transaction"
+	"kortho/util/miscellaneous"
+	"kortho/util/store"
+	"kortho/util/store/bg"
+	"log"
+	"os"
+)
+
+// 每个事务改写的块数
+const batchSize = 500
+
+func main() {
+	path := blockchain.BlockchainDBName
+	if len(os.Args) > 2 {
+		fmt.Println("Too many parameters: should <= 1!")
+		return
+	}
+	if len(os.Args) == 2 {
+		path = os.Args[1]
+	}
+	if _, err := os.Stat(path); err != nil {
+		log.Fatalf("failed to open %s: %v\n", path, err)
+	}
+
+	db := bg.New(path)
+	defer db.Close()
+
+	n, err := Migrate(db)
+	if err != nil {
+		log.Fatalf("failed to migrate: %v\n", err)
+	}
+	fmt.Printf("Migrated %d blocks.\n", n)
+}
+
+// Migrate 改写所有json格式的块并补充缺少的块头，返回修改的块数，可以重复执行
+func Migrate(db store.DB) (int, error) {
+	heightBytes, err := db.Get(blockchain.HeightKey)
+	if err == store.NotExist {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	height, err := miscellaneous.D64func(heightBytes)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	tx := db.NewTransaction()
+	defer func() { tx.Cancel() }()
+	//创世块的块高为0
+	for h := uint64(0); h <= height; h++ {
+		hash, err := tx.Get(append(blockchain.HeightPrefix, miscellaneous.E64func(h)...))
+		if err == store.NotExist && h == 0 {
+			continue
+		} else if err != nil {
+			return count, fmt.Errorf("height %d: %v", h, err)
+		}
+		data, err := tx.Get(hash)
+		if err != nil {
+			return count, fmt.Errorf("block %d: %v", h, err)
+		}
+		headerKey := append(append([]byte{}, blockchain.HeaderPrefix...), hash...)
+		_, err = tx.Get(headerKey)
+		if err != nil && err != store.NotExist {
+			return count, fmt.Errorf("header %d: %v", h, err)
+		}
+		if !transaction.IsLegacy(data) && err == nil {
+			continue
+		}
+
+		b, err := block.Deserialize(data)
+		if err != nil {
+			return count, fmt.Errorf("block %d: %v", h, err)
+		}
+		if err := tx.Set(headerKey, b.Header().Serialize()); err != nil {
+			return count, err
+		}
+		if transaction.IsLegacy(data) {
+			//二进制编码必须能还原出同样的块
+			nb, err := block.Deserialize(b.Serialize())
+			if err != nil {
+				return count, fmt.Errorf("block %d: %v", h, err)
+			}
+			if !bytes.Equal(nb.Serialize(), b.Serialize()) || !bytes.Equal(nb.Hash, hash) {
+				return count, fmt.Errorf("block %d: encoding is not stable", h)
+			}
+			if err := tx.Set(hash, b.Serialize()); err != nil {
+				return count, err
+			}
+		}
+		count++
+		if count%batchSize == 0 {
+			if err := tx.Commit(); err != nil {
+				return count, err
+			}
+			tx = db.NewTransaction()
+			fmt.Printf("Migrated to height %d.\n", h)
+		}
+	}
+	return count, tx.Commit()
+}
@@ -2,15 +2,21 @@ package monitor
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	pb "kortho/api/message"
+	"kortho/bftconsensus/bftnode"
 	"kortho/block"
 	"kortho/blockchain"
 	"kortho/config"
 	"kortho/logger"
+	"kortho/txpool"
+	"kortho/util"
 	"net/rpc"
 	"os"
 	"strings"
@@ -29,7 +35,7 @@ var nodesNum int
 var isContinue bool = false
 
 //Run start monitor
-func Run(cfg *config.MonitorConfig, bc *blockchain.Blockchain) {
+func Run(cfg *config.MonitorConfig, bftCfg *config.BftConfig, bc *blockchain.Blockchain, pool *txpool.TxPool) {
 
 	m := &monitor{
 		startBlockHeight: cfg.StartBlockHeight,
@@ -39,11 +45,16 @@ func Run(cfg *config.MonitorConfig, bc *blockchain.Blockchain) {
 		rpcPort:          cfg.RpcPort,
 		raftPort:         cfg.RaftPort,
 		accountAddr:      cfg.AccountAddr,
+		adminKey:         util.Decode(cfg.AdminKey),
 		bc:               bc,
+		pool:             pool,
+		ds:               []byte(bftCfg.Ds),
+		cm:               []byte(bftCfg.Cm),
+		qtj:              []byte(bftCfg.QTJ),
 		falseCount:       0,
 		maxBlockHeight:   0,
 		peersLen:         len(cfg.MPeers),
-		mBlocks:          make(map[string]*pb.RespBlock),
+		mHeaders:         make(map[string]*pb.BlockHeader),
 	}
 
 	if m.startBlockHeight == 0 {
@@ -73,23 +84,23 @@ func (m *monitor) Monitoring() {
 				continue
 			}
 			m.maxBlockHeight = mbn
-			//get self start block: startB;
-			startB, err := m.getStartBlock()
+			//get self start block header: startB;
+			startB, err := m.getStartHeader()
 			if err != nil {
 				//logger.Error("getStartBlock error:", zap.Error(err))
 				continue
 			}
-			//get others Blocks []lbs by startB.Height;
-			err = m.getOtherNodesBlockByHeight(startB.Height)
+			//get others block headers by startB.Height;
+			err = m.getOtherNodesHeaderByHeight(startB.Height)
 			if err != nil {
-				logger.Error("getOtherNodesBlockByHeight error:", zap.Error(err))
+				logger.Error("getOtherNodesHeaderByHeight error:", zap.Error(err))
 				continue
 			}
-			//for compare(startB.Root,lbs[n].Root) ==> falseCount;
+			//for compare(startB.Root,headers[n].Root) ==> falseCount;
 			m.falseCount = m.compareBlocks(startB)
 
 			logger.Info("monitor info===>>>", zap.Uint64("start height", m.startBlockHeight), zap.Uint64("max height", m.maxBlockHeight), zap.Uint("falseCount", m.falseCount), zap.Int("nodesNum", nodesNum))
-			if len(m.mBlocks) != nodesNum {
+			if len(m.mHeaders) != nodesNum {
 				continue
 			}
 			if m.falseCount == 0 {
@@ -112,22 +123,24 @@ func (m *monitor) Monitoring() {
 					}
 				}
 
-				//***********remove fork block chain:***********
-				//deleteBlock,handleBalance
-				err := m.bc.DeleteBlock(m.startBlockHeight)
+				//***********replace fork block chain:***********
+				//get the main chain from leader
+				blks, commits, err := m.recoverBlocks()
 				if err != nil {
-					logger.Error("DeleteBlock error!", zap.Error(err))
+					logger.Error("recoverBlocks error!", zap.Error(err))
 					isContinue = true
 					continue
 				}
-
-				//recover the main chain from leader
-				err = m.recoverBlocks()
+				//check the main chain blocks and replace the fork blocks in one transaction
+				orphans, err := m.bc.Reorg(m.startBlockHeight-1, blks, commits, m.ds, m.cm, m.qtj)
 				if err != nil {
-					logger.Error("recoverBlocks error!", zap.Error(err))
+					logger.Error("Reorg error!", zap.Error(err))
 					isContinue = true
 					continue
 				}
+				//transactions only in the fork blocks go back to the txpool
+				n := m.pool.Reinject(orphans, m.bc)
+				logger.Info("reinject orphaned transactions", zap.Int("orphans", len(orphans)), zap.Int("reinjected", n))
 				//restart raft
 				go func() {
 					for {
@@ -155,28 +168,28 @@ func (m *monitor) getMaxBlockHeight() (uint64, error) {
 	return m.bc.GetMaxBlockHeight()
 }
 
-//Get a block to compare with other nodes.
-func (m *monitor) getStartBlock() (*block.Block, error) {
-	return m.bc.GetBlockByHeight(m.startBlockHeight)
+//Get a block header to compare with other nodes.
+func (m *monitor) getStartHeader() (*block.BlockHeader, error) {
+	return m.bc.GetHeaderByHeight(m.startBlockHeight)
 }
 
-//get a block by height
-func (m *monitor) getBlockByHeight(hi uint64, addr string) (*pb.RespBlock, error) {
+//get a block header by height
+func (m *monitor) getHeaderByHeight(hi uint64, addr string) (*pb.BlockHeader, error) {
 	conn, err := grpc.Dial(getAddress(addr, m.grpcPort), grpc.WithInsecure()) //, grpc.WithBlock())
 	if err != nil {
 		return nil, fmt.Errorf("grpc Dial error:%v", err)
 	}
 	defer conn.Close()
 	cc := pb.NewGreeterClient(conn)
-	res, err := cc.GetBlockByNum(context.Background(), &pb.ReqBlockByNumber{Height: hi})
+	res, err := cc.GetBlockHeaderByNum(context.Background(), &pb.ReqBlockByNumber{Height: hi})
 	if err != nil {
-		return nil, fmt.Errorf("Call GetMaxBlockNumber error:%v", err)
+		return nil, fmt.Errorf("Call GetBlockHeaderByNum error:%v", err)
 	}
 	return res, nil
 }
 
-//get Other Nodes Block By the same Height
-func (m *monitor) getOtherNodesBlockByHeight(hi uint64) error {
+//get Other Nodes Block Header By the same Height
+func (m *monitor) getOtherNodesHeaderByHeight(hi uint64) error {
 	if len(m.peers) <= 0 {
 		return fmt.Errorf("the length of peers <= 0")
 	}
@@ -184,26 +197,26 @@ func (m *monitor) getOtherNodesBlockByHeight(hi uint64) error {
 		if len(m.peers[i]) <= 0 {
 			continue
 		} else {
-			resb, err := m.getBlockByHeight(hi, m.peers[i])
+			resb, err := m.getHeaderByHeight(hi, m.peers[i])
 			if err != nil {
 				nodesNum-- //Prevent dead nodes from being counted
-				logger.Error("Call getBlockByHeight error:", zap.String("node address", m.peers[i]), zap.Uint64("height", hi), zap.Int("nodesNum", nodesNum), zap.Error(err))
+				logger.Error("Call getHeaderByHeight error:", zap.String("node address", m.peers[i]), zap.Uint64("height", hi), zap.Int("nodesNum", nodesNum), zap.Error(err))
 				continue
 			}
-			m.mBlocks[m.peers[i]] = resb
+			m.mHeaders[m.peers[i]] = resb
 		}
 	}
-	if len(m.mBlocks) == 0 {
-		return fmt.Errorf("the length of map mBlocks <= 0")
+	if len(m.mHeaders) == 0 {
+		return fmt.Errorf("the length of map mHeaders <= 0")
 	}
 	return nil
 }
 
-//compare block with other nodes.
-func (m *monitor) compareBlocks(b *block.Block) uint {
+//compare block header with other nodes.
+func (m *monitor) compareBlocks(b *block.BlockHeader) uint {
 	var falseCount uint = 0
 	//for i := 0; i < m.peersLen; i++ {
-	for k, v := range m.mBlocks {
+	for k, v := range m.mHeaders {
 		if b.Height == v.Height {
 			if hex.EncodeToString(b.Root) != v.Root {
 				logger.Error("Wrong Blocks, Root hash not equal:", zap.Uint64("Height", b.Height), zap.String("node address", m.peer), zap.String("hash", hex.EncodeToString(b.Hash)),
@@ -217,7 +230,7 @@ func (m *monitor) compareBlocks(b *block.Block) uint {
 
 func (m *monitor) cleanBlocks() {
 	for i := 0; i < m.peersLen; i++ {
-		delete(m.mBlocks, m.peers[i])
+		delete(m.mHeaders, m.peers[i])
 	}
 }
 
@@ -284,9 +297,7 @@ func (m *monitor) stopBFTNode() error {
 	}
 	defer client.Close()
 
-	req := ReqBlockrpc{
-		Addr: m.peer + m.raftPort,
-	}
+	req := m.peerRequest("RequestManage.HandleRemovePeer")
 	res := ReSBlockrpc{}
 	logger.Info("stopBFTNode", zap.String("leader", addr), zap.String("rm addr", req.Addr))
 	err = client.Call("RequestManage.HandleRemovePeer", req, &res)
@@ -297,6 +308,25 @@ func (m *monitor) stopBFTNode() error {
 	return nil
 }
 
+//build a peer request of this node signed by admin
+func (m *monitor) peerRequest(method string) ReqBlockrpc {
+	req := ReqBlockrpc{
+		Addr: m.peer + m.raftPort,
+		Time: time.Now().Unix(),
+	}
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		logger.Error("failed to read peer request nonce", zap.Error(err))
+	}
+	req.Nonce = binary.BigEndian.Uint64(nonce[:])
+	if len(m.adminKey) == ed25519.PrivateKeySize {
+		req.Sig = ed25519.Sign(ed25519.PrivateKey(m.adminKey), bftnode.PeerRequestHash(method, req.Addr, req.Time, req.Nonce))
+	} else {
+		logger.Error("no admin key to sign peer request", zap.String("method", method))
+	}
+	return req
+}
+
 //start node when finished to recover blocks
 func (m *monitor) startBFTNode() error {
 	logger.Info("Into startBFTNode")
@@ -322,9 +352,7 @@ func (m *monitor) startBFTNode() error {
 	defer client.Close()
 
 	time.Sleep(time.Millisecond * 5)
-	req := ReqBlockrpc{
-		Addr: m.peer + m.raftPort,
-	}
+	req := m.peerRequest("RequestManage.HandleAddPeer")
 	res := ReSBlockrpc{}
 
 	err = client.Call("RequestManage.HandleAddPeer", req, &res)
@@ -336,8 +364,8 @@ func (m *monitor) startBFTNode() error {
 	return nil
 }
 
-//recover fork chain from leader
-func (m *monitor) recoverBlocks() error {
+//get the main chain blocks replacing the fork chain and their commit certificates from leader
+func (m *monitor) recoverBlocks() ([]*block.Block, []*block.Commit, error) {
 	logger.Info("recoverBlocks", zap.Uint64("from height", m.startBlockHeight), zap.Uint64("to height", m.maxBlockHeight))
 	var laddr string
 	for _, adr := range m.peers {
@@ -350,43 +378,49 @@ func (m *monitor) recoverBlocks() error {
 		break
 	}
 	if len(laddr) <= 0 {
-		return fmt.Errorf("recoverBlocks GetLeader nil")
+		return nil, nil, fmt.Errorf("recoverBlocks GetLeader nil")
 	}
 
 	client, err := rpc.DialHTTP("tcp", getAddress(laddr, m.rpcPort)) //laddress)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
 	defer client.Close()
 
+	var blks []*block.Block
+	var commits []*block.Commit
 	lo := m.startBlockHeight
 	for hi := m.startBlockHeight + BlockRange; hi <= m.maxBlockHeight; hi = lo + BlockRange {
-		data, err := getBlocks(lo, hi, client)
+		res, err := getBlocks(lo, hi, client)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		err = m.commitBlocks(data)
+		bs, cs, err := decodeBlocks(res)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
+		blks = append(blks, bs...)
+		commits = append(commits, cs...)
 		lo = hi + 1
 	}
 
 	if m.maxBlockHeight >= lo {
-		data, err := getBlocks(lo, m.maxBlockHeight, client)
+		res, err := getBlocks(lo, m.maxBlockHeight, client)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		err = m.commitBlocks(data)
+		bs, cs, err := decodeBlocks(res)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
+		blks = append(blks, bs...)
+		commits = append(commits, cs...)
 	}
-	logger.Info("End recoverBlocks")
-	return nil
+	logger.Info("End recoverBlocks", zap.Int("blocks", len(blks)))
+	return blks, commits, nil
 }
 
-func getBlocks(lowH uint64, hiH uint64, conn *rpc.Client) ([]byte, error) {
+func getBlocks(lowH uint64, hiH uint64, conn *rpc.Client) (*ReSBlockrpc, error) {
 	logger.Info("Into getBlocks")
 	req := ReqBlockrpc{
 		LowH: lowH,
@@ -399,33 +433,40 @@ func getBlocks(lowH uint64, hiH uint64, conn *rpc.Client) ([]byte, error) {
 		return nil, fmt.Errorf("Call HandleGetBlockSection error:%v", err)
 	}
 	logger.Info("Finished getBlocks")
-	return res.Data, nil
+	return &res, nil
 }
 
-func (m *monitor) commitBlocks(data []byte) error {
+//decode the blocks and their commit certificates from leader,nil blocks are skipped.
+func decodeBlocks(res *ReSBlockrpc) ([]*block.Block, []*block.Commit, error) {
 	var blks []*block.Block
-	err := json.Unmarshal(data, &blks)
+	err := json.Unmarshal(res.Data, &blks)
 	if err != nil {
-		return fmt.Errorf("Call json.Unmarshal error:%v", err)
+		return nil, nil, fmt.Errorf("Call json.Unmarshal error:%v", err)
 	}
 
 	if len(blks) <= 0 {
-		return fmt.Errorf("len(blks) <= 0")
+		return nil, nil, fmt.Errorf("len(blks) <= 0")
+	}
+	if len(res.Commits) != len(blks) {
+		return nil, nil, fmt.Errorf("%d commit certificates for %d blocks", len(res.Commits), len(blks))
 	}
 
-	for _, b := range blks {
+	var bs []*block.Block
+	var cs []*block.Commit
+	for i, b := range blks {
 		if b == nil {
 			continue
 		}
-
-		err := m.bc.RecoverBlock(b, []byte(m.accountAddr))
-		if err != nil {
-			logger.Error("commit block error", zap.Error(err))
-			return err
+		var c *block.Commit
+		if len(res.Commits[i]) > 0 {
+			if c, err = block.DeserializeCommit(res.Commits[i]); err != nil {
+				return nil, nil, fmt.Errorf("block %d commit certificate error:%v", b.Height, err)
+			}
 		}
-		//m.startBlockHeight = b.Height
+		bs = append(bs, b)
+		cs = append(cs, c)
 	}
-	return nil
+	return bs, cs, nil
 }
 
 func getAddress(addr string, port string) string {
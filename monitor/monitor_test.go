@@ -14,20 +14,20 @@ func Test_getLeader(t *testing.T) {
 	}
 }
 
-func Test_getStartBlock(t *testing.T) {
+func Test_getStartHeader(t *testing.T) {
 	m := &monitor{
 		peer:             "182.61.186.204:9501",
 		startBlockHeight: 1,
 	}
 
-	res, err := m.getStartBlock()
+	res, err := m.getStartHeader()
 	if err != nil {
 		t.Logf("error:%v", err)
 	}
 	t.Logf("result:height=%v,hash=%v", res.Height, res.Hash)
 }
 
-func Test_getOtherNodesBlockByHeight(t *testing.T) {
+func Test_getOtherNodesHeaderByHeight(t *testing.T) {
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		t.Logf("load config failed:%v", err)
@@ -36,7 +36,7 @@ func Test_getOtherNodesBlockByHeight(t *testing.T) {
 		peers: cfg.MonitorCfg.MPeers,
 	}
 
-	err = m.getOtherNodesBlockByHeight(1)
+	err = m.getOtherNodesHeaderByHeight(1)
 	if err != nil {
 		t.Logf("error:%v", err)
 	}
@@ -3,6 +3,7 @@ package monitor
 import (
 	pb "kortho/api/message"
 	"kortho/blockchain"
+	"kortho/txpool"
 )
 
 type monitor struct {
@@ -13,12 +14,15 @@ type monitor struct {
 	rpcPort          string
 	raftPort         string
 	bc               *blockchain.Blockchain
+	pool             *txpool.TxPool //takes back the transactions of the fork blocks
+	ds, cm, qtj      []byte         //addresses in the coinbase transactions
 	accountAddr      string
+	adminKey         []byte //signs peer requests
 	falseCount       uint
 	maxBlockHeight   uint64
 
 	peersLen int
-	mBlocks  map[string]*pb.RespBlock
+	mHeaders map[string]*pb.BlockHeader
 }
 
 //ReqBlockrpc requests blocks from height 'LowH' to 'HeiH'.
@@ -29,11 +33,15 @@ type ReqBlockrpc struct {
 	ReqBlocks    bool   //request leader blocks from height 'LowH' to 'HeiH'
 	LowH         uint64 //form LowH
 	HeiH         uint64 //to HeiH
+	Time         int64  //peer request time
+	Nonce        uint64 //random number of a peer request,a signed request is accepted only once
+	Sig          []byte //admin signature of PeerRequestHash
 }
 
 //ReSBlockrpc result info
 type ReSBlockrpc struct {
-	Data       []byte //blocks data
+	Data       []byte   //blocks data
+	Commits    [][]byte //serialized commit certificates of the blocks,nil for a block without one
 	LeaderAddr string
 	MaxHieght  uint64 //leader max block height
 }
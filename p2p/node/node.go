@@ -40,8 +40,22 @@ func New(cfg *config.P2PConfigInfo, pool *txpool.TxPool, bc *blockchain.Blockcha
 	return n, nil
 }
 
+// 消息的第一个字节标记消息类型，gob编码的第一个字节不会是0x80-0xf7，用来区分老节点的消息
+const (
+	txMsg    byte = 0x80 //交易，后面是交易的二进制编码
+	checkMsg byte = 0x81 //检查结果
+)
+
 func (n *node) Broadcast(v interface{}) {
-	data, _ := p2p.Encode(v)
+	var data []byte
+	switch v := v.(type) {
+	case *transaction.Transaction:
+		data = append([]byte{txMsg}, v.Serialize()...)
+	case []byte:
+		data = append([]byte{checkMsg}, v...)
+	default:
+		return
+	}
 	n.p.Broadcast(data)
 }
 
@@ -58,11 +72,26 @@ func (n *node) Join(ns []string) error {
 }
 
 func recv(u interface{}, data []byte) {
-	var tx transaction.Transaction
 	n := u.(*node)
+	if len(data) == 0 {
+		return
+	}
+	switch data[0] {
+	case txMsg:
+		if tx, err := transaction.Deserialize(data[1:]); err == nil {
+			n.pool.Add(tx, n.bc)
+		}
+		return
+	case checkMsg:
+		n.pool.SetCheckData(data[1:])
+		return
+	}
+
+	//gob encoded messages from nodes before the binary codec
+	var tx transaction.Transaction
 	var dt []byte
 	if err := p2p.Decode(data, &dt); err == nil {
-		if dt[0] == 'c' {
+		if len(dt) > 0 && dt[0] == 'c' {
 			n.pool.SetCheckData(dt[1:])
 			return
 		}
@@ -0,0 +1,136 @@
+package transaction
+
+import (
+	"encoding/json"
+	"errors"
+	"kortho/types"
+	"kortho/util/codec"
+)
+
+const (
+	// CodecVersion 交易二进制编码的版本号，写在编码的第一个字节
+	CodecVersion byte = 1
+
+	// CodecVersionValidity 带有效期的交易的编码版本，在版本1之后增加ValidUntilHeight和ValidUntilTime
+	CodecVersionValidity byte = 2
+
+	// CodecVersionChainID 带链ID的交易的编码版本，在版本2之后增加ChainID
+	CodecVersionChainID byte = 3
+
+	// CodecVersionTx 带交易版本的编码版本，在版本3之后增加Version
+	CodecVersionTx byte = 4
+)
+
+// ErrCodecVersion 不支持的编码版本
+var ErrCodecVersion = errors.New("transaction: unknown codec version")
+
+// Encode 把交易写入编码器，字段顺序固定
+// 未设置链ID和有效期的旧格式交易仍使用版本1，编码与之前相同
+func (tx *Transaction) Encode(e *codec.Encoder) {
+	version := CodecVersion
+	if tx.Version != VersionLegacy {
+		version = CodecVersionTx
+	} else if tx.ChainID != 0 {
+		version = CodecVersionChainID
+	} else if tx.ValidUntilHeight != 0 || tx.ValidUntilTime != 0 {
+		version = CodecVersionValidity
+	}
+	e.Byte(version)
+	e.Uint64(tx.Nonce)
+	e.Uint64(tx.BlockNumber)
+	e.Uint64(tx.Amount)
+	e.Fixed(tx.From[:])
+	e.Fixed(tx.To[:])
+	e.Bytes(tx.Hash)
+	e.Bytes(tx.Signature)
+	e.Int64(tx.Time)
+	e.Bytes(tx.Root)
+	e.String(tx.Script)
+	e.Uint64(tx.Fee)
+	e.Uint32(uint32(tx.Tag))
+	e.Bool(tx.Order != nil)
+	if o := tx.Order; o != nil {
+		e.Bytes(o.ID)
+		e.Fixed(o.Address[:])
+		e.Uint64(o.Price)
+		e.Bytes(o.Hash)
+		e.Bytes(o.Ciphertext)
+		e.Bytes(o.Signature)
+		e.String(o.Tradename)
+		e.String(o.Region)
+	}
+	e.Uint64(tx.KtoNum)
+	e.Uint64(tx.PckNum)
+	if version >= CodecVersionValidity {
+		e.Uint64(tx.ValidUntilHeight)
+		e.Int64(tx.ValidUntilTime)
+	}
+	if version >= CodecVersionChainID {
+		e.Uint64(tx.ChainID)
+	}
+	if version >= CodecVersionTx {
+		e.Uint32(tx.Version)
+	}
+}
+
+// Decode 从解码器读取交易
+func Decode(d *codec.Decoder) (*Transaction, error) {
+	version := d.Byte()
+	if d.Err() == nil && (version < CodecVersion || version > CodecVersionTx) {
+		return nil, ErrCodecVersion
+	}
+	var tx Transaction
+	tx.Nonce = d.Uint64()
+	tx.BlockNumber = d.Uint64()
+	tx.Amount = d.Uint64()
+	copy(tx.From[:], d.Fixed(types.AddressSize))
+	copy(tx.To[:], d.Fixed(types.AddressSize))
+	tx.Hash = d.Bytes()
+	tx.Signature = d.Bytes()
+	tx.Time = d.Int64()
+	tx.Root = d.Bytes()
+	tx.Script = d.String()
+	tx.Fee = d.Uint64()
+	tx.Tag = int32(d.Uint32())
+	if d.Bool() {
+		var o Order
+		o.ID = d.Bytes()
+		copy(o.Address[:], d.Fixed(types.AddressSize))
+		o.Price = d.Uint64()
+		o.Hash = d.Bytes()
+		o.Ciphertext = d.Bytes()
+		o.Signature = d.Bytes()
+		o.Tradename = d.String()
+		o.Region = d.String()
+		tx.Order = &o
+	}
+	tx.KtoNum = d.Uint64()
+	tx.PckNum = d.Uint64()
+	if version >= CodecVersionValidity {
+		tx.ValidUntilHeight = d.Uint64()
+		tx.ValidUntilTime = d.Int64()
+	}
+	if version >= CodecVersionChainID {
+		tx.ChainID = d.Uint64()
+	}
+	if version >= CodecVersionTx {
+		tx.Version = d.Uint32()
+	}
+	if err := d.Err(); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// IsLegacy 升级前的数据是json格式
+func IsLegacy(data []byte) bool {
+	return len(data) > 0 && data[0] == '{'
+}
+
+func deserializeLegacy(data []byte) (*Transaction, error) {
+	var tx Transaction
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
@@ -3,6 +3,9 @@ package transaction
 import (
 	"crypto/ed25519"
 	"kortho/types"
+	"kortho/util/miscellaneous"
+
+	"golang.org/x/crypto/sha3"
 )
 
 // Order 订单数据结构
@@ -18,13 +21,22 @@ type Order struct {
 	Region     string        `json:"region"`
 }
 
-// SignOrder 用ed25519椭圆曲线签名算法对订单进行签名
-func (o *Order) SignOrder(privateKey []byte) {
-	o.Signature = ed25519.Sign(ed25519.PrivateKey(privateKey), o.Hash)
+// SignHash 订单签名的数据，链ID不为0时为hash(Hash || chainID)，否则为Hash
+func SignHash(hash []byte, chainID uint64) []byte {
+	if chainID == 0 {
+		return hash
+	}
+	h := sha3.Sum256(append(append([]byte{}, hash...), miscellaneous.E64func(chainID)...))
+	return h[:]
+}
+
+// SignOrder 用ed25519椭圆曲线签名算法对订单进行签名，chainID为交易所属链的ID
+func (o *Order) SignOrder(privateKey []byte, chainID uint64) {
+	o.Signature = ed25519.Sign(ed25519.PrivateKey(privateKey), SignHash(o.Hash, chainID))
 }
 
-// Vertify 对签名进行验证
-func (o *Order) Vertify(address types.Address) bool {
+// Vertify 对签名进行验证，chainID为交易所属链的ID
+func (o *Order) Vertify(address types.Address, chainID uint64) bool {
 	publicKey := address.ToPublicKey()
-	return ed25519.Verify(ed25519.PublicKey(publicKey), o.Hash, o.Signature)
+	return ed25519.Verify(ed25519.PublicKey(publicKey), SignHash(o.Hash, chainID), o.Signature)
 }
@@ -0,0 +1,105 @@
+package transaction
+
+import (
+	"errors"
+	"kortho/util/codec"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	// VersionLegacy 旧格式交易，签名只覆盖nonce、金额、地址、时间、有效期和链ID
+	VersionLegacy uint32 = 0
+
+	// VersionFull 签名覆盖所有影响执行的字段，见SigningPayload
+	VersionFull uint32 = 1
+
+	// Version 新建交易使用的版本
+	Version = VersionFull
+)
+
+var (
+	// ErrVersion 不支持的交易版本
+	ErrVersion = errors.New("transaction: unknown version")
+
+	// ErrLegacyVersion 启用块高之后不再接受旧格式的交易
+	ErrLegacyVersion = errors.New("transaction: legacy version is no longer accepted")
+
+	// ErrChainID 交易属于其他链
+	ErrChainID = errors.New("transaction: chain id mismatch")
+)
+
+// VersionHeight 从该块高开始只接受VersionFull及以上版本的交易，低于该块高的块仍接受旧格式交易，0表示不启用
+var VersionHeight uint64
+
+// InitVersionHeight 设置新格式交易的启用块高
+func InitVersionHeight(height uint64) {
+	VersionHeight = height
+}
+
+// CheckVersion 检查块高为height的块能否包含该交易
+func (tx *Transaction) CheckVersion(height uint64) error {
+	if tx.Version > Version {
+		return ErrVersion
+	}
+	if tx.Version == VersionLegacy && !legacyHeight(height) {
+		return ErrLegacyVersion
+	}
+	return nil
+}
+
+// CheckChainID 检查块高为height的块中的交易属于本链，coinbase交易和启用块高之前没有链ID的旧格式交易不检查
+func (tx *Transaction) CheckChainID(height uint64) error {
+	if tx.ChainID == ChainID || tx.IsCoinBaseTransaction() {
+		return nil
+	}
+	if tx.ChainID == 0 && tx.Version == VersionLegacy && legacyHeight(height) {
+		return nil
+	}
+	return ErrChainID
+}
+
+// legacyHeight 块高height的块是否还接受旧格式交易
+func legacyHeight(height uint64) bool {
+	return VersionHeight == 0 || height < VersionHeight
+}
+
+// SigningPayload 交易签名的数据，包含所有影响执行的字段，字段顺序固定
+// BlockNumber、Hash和Signature不参与签名，订单只有带签名时才会被执行，没有签名的订单与nil相同
+func (tx *Transaction) SigningPayload() []byte {
+	e := codec.NewEncoder()
+	e.Uint32(tx.Version)
+	e.Uint64(tx.ChainID)
+	e.Uint32(uint32(tx.Tag))
+	e.Uint64(tx.Nonce)
+	e.Fixed(tx.From[:])
+	e.Fixed(tx.To[:])
+	e.Uint64(tx.Amount)
+	e.Uint64(tx.Fee)
+	e.Int64(tx.Time)
+	e.String(tx.Script)
+	e.Bytes(tx.Root)
+	e.Uint64(tx.KtoNum)
+	e.Uint64(tx.PckNum)
+	e.Uint64(tx.ValidUntilHeight)
+	e.Int64(tx.ValidUntilTime)
+	e.Bool(tx.IsOrderTransaction())
+	if tx.IsOrderTransaction() {
+		o := tx.Order
+		e.Bytes(o.ID)
+		e.Fixed(o.Address[:])
+		e.Uint64(o.Price)
+		e.Bytes(o.Hash)
+		e.Bytes(o.Ciphertext)
+		e.Bytes(o.Signature)
+		e.String(o.Tradename)
+		e.String(o.Region)
+	}
+	return e.Result()
+}
+
+// signingHash 新格式交易的hash，即签名数据的hash
+func (tx *Transaction) signingHash() []byte {
+	hash := sha3.Sum256(tx.SigningPayload())
+	return hash[:]
+}
@@ -2,9 +2,9 @@ package transaction
 
 import (
 	"bytes"
-	"encoding/json"
 	"errors"
 	"kortho/types"
+	"kortho/util/codec"
 	"kortho/util/miscellaneous"
 	"time"
 
@@ -30,11 +30,18 @@ const (
 	ConvertPckTag
 	// ConvertKtoTag 兑换kto标志
 	ConvertKtoTag
+	// AddValidatorTag 添加验证者标记，Script为"address@host:port"
+	AddValidatorTag
+	// RemoveValidatorTag 删除验证者标记，Script为"address@host:port"
+	RemoveValidatorTag
 )
 
-// AdminAddr 用来锁仓的管理员地址
+// AdminAddr 用来锁仓和管理验证者的管理员地址
 var AdminAddr string
 
+// ChainID 本链的ID，新建的交易都带有该ID，0表示未设置
+var ChainID uint64
+
 // Transaction 交易信息
 type Transaction struct {
 	//Nonce 自增的正整数，同一地址当前交易必定比上次大一
@@ -67,7 +74,7 @@ type Transaction struct {
 	// Sctipt 代币的名称，非代币交易该字符串长度为0
 	Script string `json:"script,omitempty"`
 
-	// Fee 代币交易的手续费，如果不是代币交易，此项为0
+	// Fee 交易的手续费，记入出块矿工的余额，交易池按手续费从高到低打包
 	Fee uint64 `json:"fee,omitempty"`
 
 	// Tag 用不同的数值，标记不同的交易类型
@@ -75,6 +82,10 @@ type Transaction struct {
 	//  1：矿工交易
 	//	2：锁仓交易
 	//	3：解锁交易
+	//	4：兑换pck交易
+	//	5：兑换kto交易
+	//	6：添加验证者交易
+	//	7：删除验证者交易
 	Tag int32 `json:"tag"`
 
 	// Order 交易中携带的订单数据，没有订单此项为nil
@@ -84,6 +95,18 @@ type Transaction struct {
 
 	// PckNum
 	PckNum uint64 `json:"pcknum"`
+
+	// ValidUntilHeight 交易可以上链的最大块高，0表示不限制
+	ValidUntilHeight uint64 `json:"validuntilheight,omitempty"`
+
+	// ValidUntilTime 交易可以上链的最晚时间戳，以秒为单位，0表示不限制
+	ValidUntilTime int64 `json:"validuntiltime,omitempty"`
+
+	// ChainID 交易所属链的ID，参与hash，防止交易在其他链上重放，0表示未设置
+	ChainID uint64 `json:"chainid,omitempty"`
+
+	// Version 交易的版本，决定签名覆盖哪些字段，0为旧格式
+	Version uint32 `json:"version,omitempty"`
 }
 
 // Option 创建交易时的可选参数
@@ -94,6 +117,9 @@ type Option struct {
 	Message string
 	Tag     int32
 	Ord     *Order
+
+	ValidUntilHeight uint64
+	ValidUntilTime   int64
 }
 
 // ModOption 创建交易时可选参数的类型
@@ -108,6 +134,21 @@ func WithToken(fee uint64, script string, root []byte) ModOption {
 	}
 }
 
+// WithFee 设置交易的手续费
+func WithFee(fee uint64) ModOption {
+	return func(option *Option) {
+		option.Fee = fee
+	}
+}
+
+// WithValidUntil 设置交易可以上链的最大块高和最晚时间，0表示不限制
+func WithValidUntil(height uint64, unix int64) ModOption {
+	return func(option *Option) {
+		option.ValidUntilHeight = height
+		option.ValidUntilTime = unix
+	}
+}
+
 // WithOrder 添加订单信息
 func WithOrder(Order *Order) ModOption {
 	return func(option *Option) {
@@ -135,10 +176,31 @@ func WithUnfreezeBalance() ModOption {
 	}
 }
 
+// WithAddValidator 添加验证者交易，validator格式为"address@host:port"
+func WithAddValidator(validator string) ModOption {
+	return func(option *Option) {
+		option.Tag = AddValidatorTag
+		option.Script = validator
+	}
+}
+
+// WithRemoveValidator 删除验证者交易，validator格式为"address@host:port"
+func WithRemoveValidator(validator string) ModOption {
+	return func(option *Option) {
+		option.Tag = RemoveValidatorTag
+		option.Script = validator
+	}
+}
+
 func InitAdmin(address string) {
 	AdminAddr = address
 }
 
+// InitChainID 设置本链的ID
+func InitChainID(chainID uint64) {
+	ChainID = chainID
+}
+
 // ZNewTransaction 新建一个交易，其中nonce，amount，from，to是必须的参数
 func ZNewTransaction(nonce, amount uint64, from, to types.Address, modOptions ...ModOption) *Transaction {
 	var option Option
@@ -160,6 +222,10 @@ func ZNewTransaction(nonce, amount uint64, from, to types.Address, modOptions ..
 		Tag:   option.Tag,
 		Order: option.Ord, //Ord是商城订单
 
+		ValidUntilHeight: option.ValidUntilHeight,
+		ValidUntilTime:   option.ValidUntilTime,
+		ChainID:          ChainID,
+		Version:          Version,
 	}
 	tx.HashTransaction()
 
@@ -190,6 +256,9 @@ func NewCoinBaseTransaction(address types.Address, amount uint64) *Transaction {
 		Amount: amount,
 		Time:   time.Now().Unix(),
 		Tag:    MinerTag,
+
+		ChainID: ChainID,
+		Version: Version,
 	}
 	transaction.HashTransaction()
 	return &transaction
@@ -203,6 +272,9 @@ func NewFreezeTransaction(address types.Address, amount uint64, nonce uint64) *T
 		To:    *to,
 		Nonce: nonce,
 		Time:  time.Now().Unix(),
+
+		ChainID: ChainID,
+		Version: Version,
 	}
 	transaction.HashTransaction()
 	return &transaction
@@ -229,6 +301,11 @@ func (tx *Transaction) IsUnfreezeTransaction() bool {
 	return bytes.Equal(tx.From.Bytes(), []byte(AdminAddr)) && tx.Tag == UnfreezeTag
 }
 
+// IsValidatorTransaction 如果是管理员发起的添加或删除验证者交易返回true，否则返回false
+func (tx *Transaction) IsValidatorTransaction() bool {
+	return bytes.Equal(tx.From.Bytes(), []byte(AdminAddr)) && (tx.Tag == AddValidatorTag || tx.Tag == RemoveValidatorTag)
+}
+
 // IsTokenTransaction 如果是代币交易返回ture，否则返回false
 func (tx *Transaction) IsTokenTransaction() bool {
 	if len(tx.Script) != 0 && tx.Fee != 0 {
@@ -237,6 +314,11 @@ func (tx *Transaction) IsTokenTransaction() bool {
 	return false
 }
 
+// IsCancelTransaction 如果是取消交易(金额为0的自转账，用于替换交易池中nonce相同的交易)返回true，否则返回false
+func (tx *Transaction) IsCancelTransaction() bool {
+	return tx.Tag == TransferTag && tx.From == tx.To && tx.Amount == 0 && len(tx.Script) == 0
+}
+
 // IsOrderTransaction 如果交易中含有订单信息返回ture，否则返回false
 func (tx *Transaction) IsOrderTransaction() bool {
 	if tx.Order != nil && tx.Order.Signature != nil && len(tx.Order.Signature) != 0 {
@@ -245,19 +327,27 @@ func (tx *Transaction) IsOrderTransaction() bool {
 	return false
 }
 
-// Serialize 序列化交易信息
+// Serialize 使用二进制格式序列化交易信息
 func (tx *Transaction) Serialize() []byte {
-	txBytes, _ := json.Marshal(tx)
-	return txBytes
+	e := codec.NewEncoder()
+	tx.Encode(e)
+	return e.Result()
 }
 
-// Deserialize 反序列化json形式的交易数据，成功返回交易对象指针，否则会返回error
+// Deserialize 反序列化交易数据，兼容升级前的json格式，成功返回交易对象指针，否则会返回error
 func Deserialize(data []byte) (*Transaction, error) {
-	var tx Transaction
-	if err := json.Unmarshal(data, &tx); err != nil {
+	if IsLegacy(data) {
+		return deserializeLegacy(data)
+	}
+	d := codec.NewDecoder(data)
+	tx, err := Decode(d)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Finish(); err != nil {
 		return nil, err
 	}
-	return &tx, nil
+	return tx, nil
 }
 
 // GetTime 获取交易的时间戳，以秒为单位
@@ -285,19 +375,42 @@ func (tx *Transaction) GetNonce() int64 {
 // 	return tx
 // }
 
-// HashTransaction 对交易进行hash
+// HashTransaction 对交易进行hash，新格式交易的hash为签名数据的hash
 func (tx *Transaction) HashTransaction() {
+	if tx.Version >= VersionFull {
+		tx.Hash = tx.signingHash()
+		return
+	}
 	fromBytes := tx.From[:]
 	toBytes := tx.To[:]
 	nonceBytes := miscellaneous.E64func(tx.Nonce)
 	amountBytes := miscellaneous.E64func(tx.Amount)
 	timeBytes := miscellaneous.E64func(uint64(tx.Time))
-	txBytes := bytes.Join([][]byte{nonceBytes, amountBytes, fromBytes, toBytes, timeBytes}, []byte{})
+	txBytes := bytes.Join([][]byte{nonceBytes, amountBytes, fromBytes, toBytes, timeBytes, tx.extraBytes()}, []byte{})
 	hash := sha3.Sum256(txBytes)
 	tx.Hash = hash[:]
 }
 
-// TrimmedCopy 有选择的对交易的字段进行拷贝，用以验证签名
+// extraBytes 链ID和有效期参与hash，未设置时为空，与之前的交易hash相同
+func (tx *Transaction) extraBytes() []byte {
+	var data []byte
+	if tx.ChainID != 0 {
+		data = append(data, miscellaneous.E64func(tx.ChainID)...)
+	}
+	if tx.ValidUntilHeight != 0 || tx.ValidUntilTime != 0 {
+		data = append(data, miscellaneous.E64func(tx.ValidUntilHeight)...)
+		data = append(data, miscellaneous.E64func(uint64(tx.ValidUntilTime))...)
+	}
+	return data
+}
+
+// Expired 交易在块高height、时间now时已过有效期返回true，否则返回false
+func (tx *Transaction) Expired(height uint64, now int64) bool {
+	return (tx.ValidUntilHeight != 0 && height > tx.ValidUntilHeight) ||
+		(tx.ValidUntilTime != 0 && now > tx.ValidUntilTime)
+}
+
+// TrimmedCopy 有选择的对交易的字段进行拷贝，用以验证旧格式交易的签名
 func (tx *Transaction) TrimmedCopy() *Transaction {
 	txCopy := &Transaction{
 		Nonce:  tx.Nonce,
@@ -305,22 +418,41 @@ func (tx *Transaction) TrimmedCopy() *Transaction {
 		From:   tx.From,
 		To:     tx.To,
 		Time:   tx.Time,
+
+		ValidUntilHeight: tx.ValidUntilHeight,
+		ValidUntilTime:   tx.ValidUntilTime,
+		ChainID:          tx.ChainID,
 	}
 	return txCopy
 }
 
-// Sign 用ed25519椭圆曲线签名算法，对交易进行签名
+// Sign 用ed25519椭圆曲线签名算法，对交易进行签名，新格式交易签名前会重新计算hash
 func (tx *Transaction) Sign(privateKey []byte) error {
 	if len(privateKey) != ed25519.PrivateKeySize {
 		return errors.New("invalid private key")
 	}
+	if tx.Version >= VersionFull {
+		tx.Hash = tx.signingHash()
+	}
 	signatures := ed25519.Sign(ed25519.PrivateKey(privateKey), tx.Hash)
 	tx.Signature = signatures
 	return nil
 }
 
 // Verify 验证签名，成功返回true，否则返回false
+// 新格式交易的hash必须与签名数据的hash相同
 func (tx *Transaction) Verify() bool {
+	if tx.Version > Version {
+		return false
+	} else if tx.Version >= VersionFull {
+		publicKey := tx.From.ToPublicKey()
+		if len(publicKey) != ed25519.PublicKeySize {
+			return false
+		}
+		hash := tx.signingHash()
+		return bytes.Equal(tx.Hash, hash) && ed25519.Verify(publicKey, hash, tx.Signature)
+	}
+
 	txCopy := tx.TrimmedCopy()
 	txCopy.HashTransaction()
 	publicKey := tx.From.ToPublicKey()
@@ -339,11 +471,15 @@ func (tx *Transaction) EqualNonce(transaction *Transaction) bool {
 }
 
 func (tx *Transaction) ConvertHash() {
+	if tx.Version >= VersionFull {
+		tx.Hash = tx.signingHash()
+		return
+	}
 	nonce := miscellaneous.E64func(tx.Nonce)
 	timestamap := miscellaneous.E64func(uint64(tx.Time))
 	ktoNum := miscellaneous.E64func(tx.KtoNum)
 	pckNum := miscellaneous.E64func(tx.PckNum)
-	hashBytes := bytes.Join([][]byte{nonce, ktoNum, pckNum, tx.From[:], tx.To[:], timestamap}, []byte{})
+	hashBytes := bytes.Join([][]byte{nonce, ktoNum, pckNum, tx.From[:], tx.To[:], timestamap, tx.extraBytes()}, []byte{})
 	hash := sha3.Sum256(hashBytes)
 	tx.Hash = hash[:]
 	return
@@ -360,10 +496,17 @@ func (tx *Transaction) ConvertCopy() *Transaction {
 		KtoNum: tx.KtoNum,
 		From:   from,
 		To:     to,
+
+		ValidUntilHeight: tx.ValidUntilHeight,
+		ValidUntilTime:   tx.ValidUntilTime,
+		ChainID:          tx.ChainID,
 	}
 }
 
 func (tx *Transaction) ConvertVerify() bool {
+	if tx.Version >= VersionFull {
+		return tx.Verify()
+	}
 	txCopy := tx.ConvertCopy()
 	txCopy.ConvertHash()
 	publicKey := types.AddressToPublicKey(string(tx.From.Bytes()))
@@ -0,0 +1,120 @@
+package txpool
+
+import (
+	"kortho/transaction"
+	"kortho/types"
+)
+
+// account 交易池中同一地址的交易
+type account struct {
+	addr types.Address
+
+	// nonce pending中第一笔交易的nonce，即该地址下一笔可以上链的交易的nonce
+	nonce uint64
+
+	// pending 从nonce开始nonce连续、可以上链的交易
+	pending []*transaction.Transaction
+
+	// queued 与pending的nonce不连续的交易，按nonce索引
+	queued map[uint64]*transaction.Transaction
+
+	// index 在priceHeap中的下标，不在堆中时为-1
+	index int
+}
+
+func newAccount(addr types.Address, nonce uint64) *account {
+	return &account{
+		addr:   addr,
+		nonce:  nonce,
+		queued: make(map[uint64]*transaction.Transaction),
+		index:  -1,
+	}
+}
+
+// len 该地址在交易池中的交易数量
+func (a *account) len() int {
+	return len(a.pending) + len(a.queued)
+}
+
+// get 获取nonce对应的交易，不存在时返回nil
+func (a *account) get(nonce uint64) *transaction.Transaction {
+	if nonce >= a.nonce && nonce-a.nonce < uint64(len(a.pending)) {
+		return a.pending[nonce-a.nonce]
+	}
+	return a.queued[nonce]
+}
+
+// put 加入交易，nonce相同的交易会被替换
+func (a *account) put(tx *transaction.Transaction) {
+	if tx.Nonce < a.nonce {
+		//回滚后放回的交易，pending中的交易都变为不连续
+		a.demote(0)
+		a.nonce = tx.Nonce
+	}
+	if i := tx.Nonce - a.nonce; i < uint64(len(a.pending)) {
+		a.pending[i] = tx
+		return
+	}
+	a.queued[tx.Nonce] = tx
+	a.promote()
+}
+
+// remove 移除nonce对应的交易，pending中之后的交易变为不连续
+func (a *account) remove(nonce uint64) *transaction.Transaction {
+	if nonce >= a.nonce && nonce-a.nonce < uint64(len(a.pending)) {
+		i := int(nonce - a.nonce)
+		tx := a.pending[i]
+		a.demote(i + 1)
+		a.pending = a.pending[:i]
+		return tx
+	}
+	tx := a.queued[nonce]
+	delete(a.queued, nonce)
+	return tx
+}
+
+// forward 移除nonce小于nonce的交易，并把下一笔可以上链的nonce设为nonce，返回被移除的交易
+func (a *account) forward(nonce uint64) []*transaction.Transaction {
+	if nonce <= a.nonce {
+		return nil
+	}
+
+	var removed []*transaction.Transaction
+	if n := nonce - a.nonce; n >= uint64(len(a.pending)) {
+		removed = a.pending
+		a.pending = nil
+	} else {
+		removed = append(removed, a.pending[:n]...)
+		a.pending = a.pending[n:]
+	}
+	for n, tx := range a.queued {
+		if n < nonce {
+			removed = append(removed, tx)
+			delete(a.queued, n)
+		}
+	}
+	a.nonce = nonce
+	a.promote()
+	return removed
+}
+
+// promote 把与pending的nonce连续的交易从queued移入pending
+func (a *account) promote() {
+	for {
+		next := a.nonce + uint64(len(a.pending))
+		tx, ok := a.queued[next]
+		if !ok {
+			return
+		}
+		delete(a.queued, next)
+		a.pending = append(a.pending, tx)
+	}
+}
+
+// demote 把pending中下标i及之后的交易移入queued
+func (a *account) demote(i int) {
+	for _, tx := range a.pending[i:] {
+		a.queued[tx.Nonce] = tx
+	}
+	a.pending = a.pending[:i]
+}
@@ -6,4 +6,6 @@ var (
 	errtx         = errors.New("tx is error")
 	errtomuch     = errors.New("recv tx to much,so refused")
 	errtxoutrange = errors.New("txpoll tx out of range,so refused")
+	errtxexist    = errors.New("tx already in txpool")
+	errreplace    = errors.New("replacement fee too low,so refused")
 )
@@ -0,0 +1,73 @@
+package txpool
+
+import "kortho/transaction"
+
+const (
+	// EventBufferSize 每个订阅者的事件缓冲大小，缓冲满时新事件被丢弃
+	EventBufferSize = 256
+)
+
+// EventType 交易池事件类型
+type EventType int32
+
+const (
+	// EventAdded 新交易加入交易池
+	EventAdded EventType = iota
+	// EventReplaced 手续费更高的交易替换了交易池中from和nonce相同的交易
+	EventReplaced
+	// EventCancelled 取消交易替换了交易池中from和nonce相同的交易
+	EventCancelled
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventAdded:
+		return "added"
+	case EventReplaced:
+		return "replaced"
+	case EventCancelled:
+		return "cancelled"
+	}
+	return "unknown"
+}
+
+// Event 交易池事件，Replaced为被替换的交易，仅在替换和取消时有值
+type Event struct {
+	Type     EventType
+	Tx       *transaction.Transaction
+	Replaced *transaction.Transaction
+}
+
+// Subscribe 订阅交易池事件，不再使用时需要调用Unsubscribe
+func (pool *TxPool) Subscribe() <-chan Event {
+	pool.Mutex.Lock()
+	defer pool.Mutex.Unlock()
+
+	ch := make(chan Event, EventBufferSize)
+	pool.subs = append(pool.subs, ch)
+	return ch
+}
+
+// Unsubscribe 取消订阅并关闭ch
+func (pool *TxPool) Unsubscribe(ch <-chan Event) {
+	pool.Mutex.Lock()
+	defer pool.Mutex.Unlock()
+
+	for i, sub := range pool.subs {
+		if sub == ch {
+			pool.subs = append(pool.subs[:i], pool.subs[i+1:]...)
+			close(sub)
+			return
+		}
+	}
+}
+
+// notify 把事件发送给所有订阅者，调用时需持有pool.Mutex，不会阻塞
+func (pool *TxPool) notify(ev Event) {
+	for _, sub := range pool.subs {
+		select {
+		case sub <- ev:
+		default:
+		}
+	}
+}
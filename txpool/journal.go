@@ -0,0 +1,99 @@
+package txpool
+
+import (
+	"io/ioutil"
+	"kortho/transaction"
+	"kortho/util/codec"
+	"os"
+)
+
+const (
+	// JournalName 交易池日志文件名称
+	JournalName = "txpool.journal"
+)
+
+// journal 交易池的追加写日志，每条记录为带长度前缀的序列化交易，重启时重新加载
+type journal struct {
+	path  string
+	w     *os.File
+	count int //日志中的交易数量
+}
+
+func newJournal(path string) *journal {
+	return &journal{path: path}
+}
+
+// load 依次把日志中的交易交给add，结尾不完整的记录被忽略
+func (j *journal) load(add func(*transaction.Transaction)) error {
+	data, err := ioutil.ReadFile(j.path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	d := codec.NewDecoder(data)
+	for d.Len() != 0 {
+		data := d.Bytes()
+		if d.Err() != nil {
+			//写入时崩溃
+			break
+		}
+		tx, err := transaction.Deserialize(data)
+		if err != nil {
+			continue
+		}
+		add(tx)
+	}
+	return nil
+}
+
+// insert 把交易追加到日志
+func (j *journal) insert(tx *transaction.Transaction) error {
+	if j.w == nil {
+		return os.ErrClosed
+	}
+	e := codec.NewEncoder()
+	e.Bytes(tx.Serialize())
+	if _, err := j.w.Write(e.Result()); err != nil {
+		return err
+	}
+	j.count++
+	return nil
+}
+
+// rotate 用txs重写日志，先写入临时文件再替换，之后继续追加写
+func (j *journal) rotate(txs []*transaction.Transaction) error {
+	if j.w != nil {
+		j.w.Close()
+		j.w = nil
+	}
+
+	e := codec.NewEncoder()
+	for _, tx := range txs {
+		e.Bytes(tx.Serialize())
+	}
+	tmp := j.path + ".new"
+	if err := ioutil.WriteFile(tmp, e.Result(), 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, j.path); err != nil {
+		return err
+	}
+
+	w, err := os.OpenFile(j.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	j.w, j.count = w, len(txs)
+	return nil
+}
+
+func (j *journal) close() error {
+	if j.w == nil {
+		return nil
+	}
+	err := j.w.Close()
+	j.w = nil
+	return err
+}
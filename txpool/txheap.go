@@ -1,9 +1,8 @@
 package txpool
 
 import (
-	"kortho/logger"
+	"bytes"
 	"kortho/transaction"
-	"kortho/types"
 )
 
 const (
@@ -11,65 +10,64 @@ const (
 	MaxAddrCount = 1000
 )
 
-// TxHeap 是交易的指针切片，实现了container/heap中的heap接口
-type TxHeap []*transaction.Transaction
+// feeLess 手续费高的在前，手续费相同时先到的在前
+func feeLess(a, b *transaction.Transaction) bool {
+	if a.Fee != b.Fee {
+		return a.Fee > b.Fee
+	}
+	if a.GetTime() != b.GetTime() {
+		return a.GetTime() < b.GetTime()
+	}
+	return bytes.Compare(a.Hash, b.Hash) < 0
+}
 
-// Len 获取txheap的长度
-func (h TxHeap) Len() int { return len(h) }
+// priceHeap 由pending不为空的地址组成，按pending中第一笔交易的手续费排列，实现了container/heap中的heap接口
+type priceHeap []*account
 
-// Less 索引i的元素小于索引j元素，返回ture,否则返回false
-func (h TxHeap) Less(i, j int) bool {
-	if h[i].From == h[j].From {
-		if h[i].GetNonce() == h[j].GetNonce() {
-			return h[i].GetTime() < h[j].GetTime()
-		}
-		return h[i].GetNonce() < h[j].GetNonce()
-	}
+func (h priceHeap) Len() int { return len(h) }
 
-	return h[i].GetTime() < h[j].GetTime()
-}
+func (h priceHeap) Less(i, j int) bool { return feeLess(h[i].pending[0], h[j].pending[0]) }
 
-func (h TxHeap) Swap(i, j int) {
+func (h priceHeap) Swap(i, j int) {
 	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
 }
 
-// Push 向txHeap中添加元素
-func (h *TxHeap) Push(x interface{}) {
-	*h = append(*h, x.(*transaction.Transaction))
+func (h *priceHeap) Push(x interface{}) {
+	a := x.(*account)
+	a.index = len(*h)
+	*h = append(*h, a)
 }
 
-// Pop 弹出元素，弹出后从TxHeap中移除
-func (h *TxHeap) Pop() interface{} {
+func (h *priceHeap) Pop() interface{} {
 	old := *h
 	n := len(old)
-	x := old[n-1]
+	a := old[n-1]
+	old[n-1] = nil
+	a.index = -1
 	*h = old[0 : n-1]
-	return x
+	return a
+}
+
+// timeHeap 交易池中的交易按发起时间排列，被移除的交易在过期时才从堆中删除
+type timeHeap []*transaction.Transaction
+
+func (h timeHeap) Len() int { return len(h) }
+
+func (h timeHeap) Less(i, j int) bool { return h[i].GetTime() < h[j].GetTime() }
+
+func (h timeHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *timeHeap) Push(x interface{}) {
+	*h = append(*h, x.(*transaction.Transaction))
 }
 
-// Get 获取下标为i的元素
-func (h *TxHeap) Get(i int) interface{} {
+func (h *timeHeap) Pop() interface{} {
 	old := *h
 	n := len(old)
-	x := old[n-i]
+	x := old[n-1]
+	old[n-1] = nil
+	*h = old[0 : n-1]
 	return x
 }
-
-func (h *TxHeap) check(fromAddr types.Address, nonce uint64) bool {
-	var count = 0
-	for _, tx := range *h {
-		if fromAddr == tx.From {
-			if nonce == tx.Nonce {
-				logger.Error("txpool check:nonce == tx.Nonce")
-				return false
-			}
-			count++
-		}
-	}
-
-	if count >= MaxAddrCount {
-		logger.Error("txpool check:count >= MaxAddrCount")
-		return false
-	}
-	return true
-}
@@ -1,17 +1,16 @@
 package txpool
 
 import (
-	"bytes"
 	"container/heap"
-	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"kortho/block"
 	"kortho/blockchain"
 	"kortho/logger"
 	"kortho/transaction"
 	"kortho/types"
 	"kortho/util"
-	"kortho/util/merkle"
 	"sync"
 	"time"
 
@@ -27,6 +26,9 @@ const (
 
 	// PoolListRange 交易池的最大容量
 	PoolListRange = 3000
+
+	// TxLifetime 默认的交易在交易池中的最长存在时间，以秒为单位，可以通过配置修改
+	TxLifetime = 10
 )
 
 const (
@@ -48,8 +50,17 @@ type CheckBlock struct {
 // TxPool 交易池结构体
 type TxPool struct {
 	Mutex sync.RWMutex
-	List  *TxHeap
 	Idhc  map[string]CheckBlock
+
+	all      map[string]*transaction.Transaction //按hash索引的全部交易
+	accounts map[types.Address]*account          //各地址的pending和queued交易
+	priced   priceHeap                           //pending不为空的地址，按手续费排列
+	byTime   timeHeap                            //按时间排列，用于移除过期交易
+	limit    int                                 //交易池的最大容量
+	lifetime int64                               //交易在交易池中的最长存在时间，以秒为单位
+	journal  *journal                            //交易池日志，重启时重新加载
+
+	subs []chan Event
 }
 
 type stateInfo struct {
@@ -57,13 +68,24 @@ type stateInfo struct {
 	balance uint64
 }
 
-// New 新建交易池，并传入趣淘鲸的地址
-func New(address string) (*TxPool, error) {
+// New 新建交易池，并传入趣淘鲸的地址，lifetime为交易在交易池中的最长存在时间(秒)，不大于0时使用TxLifetime，
+// 交易池日志中的交易按链上状态重新验证后放回交易池
+func New(address string, lifetime int64, bc blockchain.Blockchains) (*TxPool, error) {
+	return newPool(address, lifetime, JournalName, bc)
+}
+
+// newPool 新建交易池，path为空时不使用日志
+func newPool(address string, lifetime int64, path string, bc blockchain.Blockchains) (*TxPool, error) {
+	if lifetime <= 0 {
+		lifetime = TxLifetime
+	}
 	pool := &TxPool{
-		List: new(TxHeap),
-		Idhc: make(map[string]CheckBlock),
+		Idhc:     make(map[string]CheckBlock),
+		all:      make(map[string]*transaction.Transaction),
+		accounts: make(map[types.Address]*account),
+		limit:    PoolListRange,
+		lifetime: lifetime,
 	}
-	heap.Init(pool.List)
 
 	//TODO:判断Address是否符合条件
 	addr, err := types.StringToAddress(address)
@@ -72,205 +94,462 @@ func New(address string) (*TxPool, error) {
 		return nil, err
 	}
 	QTJPubKey = addr.ToPublicKey()
+
+	if path != "" {
+		if err = pool.loadJournal(path, bc); err != nil {
+			logger.Error("failed to load txpool journal", zap.Error(err), zap.String("path", path))
+			return nil, err
+		}
+	}
 	return pool, nil
 }
 
-// Add 添加交易到交易池
+// loadJournal 把日志中未过期且验证通过的交易放回交易池，然后用交易池中的交易重写日志
+func (pool *TxPool) loadJournal(path string, bc blockchain.Blockchains) error {
+	j := newJournal(path)
+	now := time.Now().UTC().Unix()
+	dropped := 0
+	err := j.load(func(tx *transaction.Transaction) {
+		if now-tx.Time > pool.lifetime {
+			dropped++
+			return
+		}
+		if err := pool.Add(tx, bc); err != nil && err != errtxexist {
+			dropped++
+		}
+	})
+	if err != nil {
+		return err
+	}
+	logger.Info("load txpool journal", zap.Int("transactions", len(pool.all)), zap.Int("dropped", dropped))
+
+	if err = j.rotate(pool.txs()); err != nil {
+		return err
+	}
+	pool.journal = j
+	return nil
+}
+
+// txs 交易池中的全部交易，同一地址按nonce顺序
+func (pool *TxPool) txs() []*transaction.Transaction {
+	txs := make([]*transaction.Transaction, 0, len(pool.all))
+	for _, a := range pool.accounts {
+		txs = append(txs, a.pending...)
+		for _, tx := range a.queued {
+			txs = append(txs, tx)
+		}
+	}
+	return txs
+}
+
+// Close 关闭交易池日志
+func (pool *TxPool) Close() error {
+	pool.Mutex.Lock()
+	defer pool.Mutex.Unlock()
+
+	if pool.journal == nil {
+		return nil
+	}
+	return pool.journal.close()
+}
+
+// Add 添加交易到交易池，from和nonce相同的交易只有手续费更高时才能替换
 func (pool *TxPool) Add(tx *transaction.Transaction, bc blockchain.Blockchains) error {
 	pool.Mutex.Lock()
 	defer pool.Mutex.Unlock()
 
-	if pool.List.Len() > PoolListRange {
-		return errtxoutrange
+	if _, ok := pool.all[string(tx.Hash)]; ok {
+		return errtxexist
 	}
 
-	if !verify(*tx, bc) {
+	//交易最早在下一个块上链，按本地时间检查有效期
+	height, err := bc.GetHeight()
+	if err != nil {
+		logger.Error("failed to get height", zap.Error(err))
+		return err
+	}
+	if !verify(*tx, bc, height+1, time.Now().Unix()) {
 		return errtx
 	}
 
-	if !pool.List.check(tx.From, tx.Nonce) {
+	nonce, err := bc.GetNonce(tx.From.Bytes())
+	if err != nil {
+		logger.Error("failed to get nonce", zap.Error(err), zap.String("from", tx.From.String()))
+		return err
+	}
+	return pool.add(tx, nonce)
+}
+
+// add 把验证过的交易加入交易池，nonce为from在链上的nonce
+func (pool *TxPool) add(tx *transaction.Transaction, nonce uint64) error {
+	a, ok := pool.accounts[tx.From]
+	if !ok {
+		a = newAccount(tx.From, nonce)
+		pool.accounts[tx.From] = a
+	} else if nonce > a.nonce {
+		//链上的nonce已经前进，移除nonce已被使用的交易
+		pool.drop(a.forward(nonce))
+	}
+	defer pool.fix(a)
+
+	old := a.get(tx.Nonce)
+	switch {
+	case old != nil && tx.Fee <= old.Fee:
+		logger.Info("replacement fee too low", zap.String("from", tx.From.String()), zap.Uint64("nonce", tx.Nonce),
+			zap.Uint64("fee", tx.Fee), zap.Uint64("pooled fee", old.Fee))
+		return errreplace
+	case old == nil && a.len() >= MaxAddrCount:
+		logger.Error("txpool check:count >= MaxAddrCount")
 		return errtomuch
+	case old == nil && len(pool.all) >= pool.limit:
+		return errtxoutrange
+	}
+
+	if old != nil {
+		delete(pool.all, string(old.Hash))
+	}
+	a.put(tx)
+	pool.all[string(tx.Hash)] = tx
+	heap.Push(&pool.byTime, tx)
+	if pool.journal != nil {
+		if err := pool.journal.insert(tx); err != nil {
+			logger.Error("failed to write txpool journal", zap.Error(err))
+		}
 	}
 
-	heap.Push(pool.List, tx)
+	if old == nil {
+		pool.notify(Event{Type: EventAdded, Tx: tx})
+		logger.Info("add info", zap.String("from", tx.From.String()), zap.String("to", tx.To.String()), zap.Uint64("amount", tx.Amount))
+		return nil
+	}
+
+	ev := Event{Type: EventReplaced, Tx: tx, Replaced: old}
+	if tx.IsCancelTransaction() {
+		ev.Type = EventCancelled
+	}
+	pool.notify(ev)
+
+	logger.Info("replace info", zap.String("from", tx.From.String()), zap.Uint64("nonce", tx.Nonce),
+		zap.String("hash", hex.EncodeToString(tx.Hash)), zap.String("replaced", hex.EncodeToString(old.Hash)),
+		zap.Uint64("fee", tx.Fee), zap.Uint64("replaced fee", old.Fee))
+	return nil
+}
+
+// drop 从hash索引中删除已从地址中移除的交易
+func (pool *TxPool) drop(txs []*transaction.Transaction) {
+	for _, tx := range txs {
+		delete(pool.all, string(tx.Hash))
+	}
+}
+
+// fix 地址的交易变化后调整其在priced中的位置，没有交易时删除该地址
+func (pool *TxPool) fix(a *account) {
+	switch {
+	case len(a.pending) == 0 && a.index >= 0:
+		heap.Remove(&pool.priced, a.index)
+	case len(a.pending) != 0 && a.index < 0:
+		heap.Push(&pool.priced, a)
+	case len(a.pending) != 0:
+		heap.Fix(&pool.priced, a.index)
+	}
+	if a.len() == 0 {
+		delete(pool.accounts, a.addr)
+	}
+}
+
+// GetByNonce 获取交易池中from和nonce对应的交易，不存在时返回nil
+func (pool *TxPool) GetByNonce(from types.Address, nonce uint64) *transaction.Transaction {
+	pool.Mutex.RLock()
+	defer pool.Mutex.RUnlock()
 
-	logger.Info("add info", zap.String("from", tx.From.String()), zap.String("to", tx.To.String()), zap.Uint64("amount", tx.Amount))
+	if a, ok := pool.accounts[from]; ok {
+		return a.get(nonce)
+	}
 	return nil
 }
 
+// Reinject 把回滚块中的交易重新放回交易池，返回放回的数量，验证不通过的交易被丢弃
+func (pool *TxPool) Reinject(txs []*transaction.Transaction, bc blockchain.Blockchains) int {
+	n := 0
+	for _, tx := range txs {
+		if pool.IsExist(tx.Hash) {
+			continue
+		}
+		if err := pool.Add(tx, bc); err != nil {
+			logger.Info("drop orphaned transaction", zap.String("hash", hex.EncodeToString(tx.Hash)), zap.Error(err))
+			continue
+		}
+		n++
+	}
+	return n
+}
+
 // IsExist 线程池中是否存在该hash对应的交易
 func (pool *TxPool) IsExist(hash []byte) bool {
 	pool.Mutex.RLock()
 	defer pool.Mutex.RUnlock()
 
-	for _, tx := range *pool.List {
-		if bytes.Compare(hash, tx.Hash) == 0 {
-			return true
-		}
+	_, ok := pool.all[string(hash)]
+	return ok
+}
+
+// Len 交易池中的交易数量
+func (pool *TxPool) Len() int {
+	pool.Mutex.RLock()
+	defer pool.Mutex.RUnlock()
+
+	return len(pool.all)
+}
+
+// Stats 返回可以上链(pending)和nonce不连续(queued)的交易数量
+func (pool *TxPool) Stats() (pending, queued int) {
+	pool.Mutex.RLock()
+	defer pool.Mutex.RUnlock()
+
+	for _, a := range pool.accounts {
+		pending += len(a.pending)
+		queued += len(a.queued)
 	}
-	return false
+	return
+}
+
+// Pending 从交易池中取出可以上链的交易，不同地址之间按手续费从高到低选取，同一地址按nonce顺序选取
+func (pool *TxPool) Pending(Bc blockchain.Blockchains) []*transaction.Transaction {
+	return pool.PendingN(Bc, ReadyTotal)
 }
 
-// Pending 从交易池中取出可以上链的交易
-func (pool *TxPool) Pending(Bc blockchain.Blockchains) (readyTxs []*transaction.Transaction) {
-	logger.Info("Into Pending...", zap.Int("pool list length", pool.List.Len()))
+// PendingN 与Pending相同，最多取出max个交易，max不大于0时为ReadyTotal
+func (pool *TxPool) PendingN(Bc blockchain.Blockchains, max int) (readyTxs []*transaction.Transaction) {
+	if max <= 0 {
+		max = ReadyTotal
+	}
 	pool.Mutex.Lock()
 	defer pool.Mutex.Unlock()
+	logger.Info("Into Pending...", zap.Int("pool list length", len(pool.all)), zap.Int("pending accounts", pool.priced.Len()))
+
+	height, err := Bc.GetHeight()
+	if err != nil {
+		logger.Error("failed to get height", zap.Error(err))
+		return
+	}
+	now := time.Now().Unix()
+
+	st := newPendingState()
+	var skipped []*account
+	for pool.priced.Len() != 0 && len(readyTxs) < max {
+		a := pool.priced[0]
+		tx := a.pending[0]
+		if tx.Expired(height+1, now) || tx.CheckVersion(height+1) != nil {
+			//已过有效期或旧格式交易已不再被接受，之后的交易nonce不再连续
+			pool.drop([]*transaction.Transaction{a.remove(tx.Nonce)})
+			pool.fix(a)
+			continue
+		}
+		ready, err := st.check(tx, Bc)
+		if err != nil {
+			break
+		}
+		switch ready {
+		case txReady:
+			readyTxs = append(readyTxs, tx)
+			pool.drop(a.forward(tx.Nonce + 1))
+		case txNotReady:
+			//nonce与链上不连续，该地址本次不再选取
+			heap.Pop(&pool.priced)
+			skipped = append(skipped, a)
+			continue
+		default:
+			nonce, err := Bc.GetNonce(a.addr.Bytes())
+			if err == nil && nonce > tx.Nonce {
+				//nonce已被使用
+				pool.drop(a.forward(nonce))
+			} else {
+				//余额不足，之后的交易nonce不再连续
+				pool.drop([]*transaction.Transaction{a.remove(tx.Nonce)})
+			}
+		}
+		pool.fix(a)
+	}
+
+	for _, a := range skipped {
+		pool.fix(a)
+	}
+	logger.Info("end to pending transaction")
+	return
+}
+
+const (
+	txReady = iota
+	txNotReady
+	txDropped
+)
+
+type pckDkto struct {
+	pck  uint64
+	dkto uint64
+}
+
+// pendingState Pending时各地址已选取交易之后的nonce和余额
+type pendingState struct {
+	nonceMap        map[string]uint64
+	frozenBalMap    map[string]uint64
+	avaliableBalMap map[string]uint64
+	pckDktoResults  map[string]pckDkto
+
+	validators       []string //验证者交易执行后的验证者集合
+	validatorsLoaded bool
+}
+
+func newPendingState() *pendingState {
+	return &pendingState{
+		nonceMap:        make(map[string]uint64),
+		frozenBalMap:    make(map[string]uint64),
+		avaliableBalMap: make(map[string]uint64),
+		pckDktoResults:  make(map[string]pckDkto),
+	}
+}
+
+// check 检查交易能否上链，可以上链时更新状态
+func (st *pendingState) check(tx *transaction.Transaction, Bc blockchain.Blockchains) (int, error) {
 	var err error
-	var noReadyTxs []*transaction.Transaction
-	nonceMap := make(map[string]uint64)
-	frozenBalMap := make(map[string]uint64)
-	avaliableBalMap := make(map[string]uint64)
-	pckDktoResults := make(map[string]struct {
-		pck  uint64
-		dkto uint64
-	})
+	var ok bool
+	var address types.Address
+	var avaliableBal, frozenBal uint64
+	var nonce uint64
+	var pckdkto pckDkto
+
+	if tx.IsFreezeTransaction() || tx.IsUnfreezeTransaction() {
+		address = tx.To
+	} else {
+		address = tx.From
+	}
 
-	for pool.List.Len() != 0 && len(readyTxs) < ReadyTotal {
-		var ok bool
-		var address types.Address
-		var avaliableBal, frozenBal uint64
-		var nonce uint64
-		var pckdkto struct {
-			pck  uint64
-			dkto uint64
+	if avaliableBal, ok = st.avaliableBalMap[address.String()]; !ok {
+		balance, err := Bc.GetBalance(address.Bytes())
+		if err != nil {
+			logger.Error("failed to get balance", zap.Error(err), zap.String("address", address.String()))
+			return txDropped, err
 		}
-		tx := heap.Pop(pool.List).(*transaction.Transaction)
 
-		if tx.IsFreezeTransaction() || tx.IsUnfreezeTransaction() {
-			address = tx.To
-		} else {
-			address = tx.From
+		frozenBal, err = Bc.GetFreezeBalance(address.Bytes())
+		if err != nil {
+			logger.Error("failed to get frozen amount", zap.Error(err), zap.String("address", address.String()))
+			return txDropped, err
 		}
 
-		if avaliableBal, ok = avaliableBalMap[address.String()]; !ok {
-			balance, err := Bc.GetBalance(address.Bytes())
-			if err != nil {
-				logger.Error("failed to get balance", zap.Error(err), zap.String("address", address.String()))
-				return
-			}
+		if util.Uint64SubOverflow(balance, frozenBal) {
+			logger.Error("balance is less than the frozen amount", zap.String("from", address.String()), zap.Uint64("balance", balance),
+				zap.Uint64("frozen amount", frozenBal))
+			return txDropped, errors.New("balance is less than the frozen amount")
+		}
+		avaliableBal = balance - frozenBal
+	}
 
-			frozenBal, err = Bc.GetFreezeBalance(address.Bytes())
-			if err != nil {
-				logger.Error("failed to get frozen amount", zap.Error(err), zap.String("address", address.String()))
-				return
-			}
+	if frozenBal, ok = st.frozenBalMap[address.String()]; !ok {
+		frozenBal, err = Bc.GetFreezeBalance(address.Bytes())
+		if err != nil {
+			logger.Error("failed to get frozen amount", zap.Error(err), zap.String("address", address.String()))
+			return txDropped, err
+		}
+	}
 
-			if util.Uint64SubOverflow(balance, frozenBal) {
-				logger.Error("balance is less than the frozen amount", zap.String("from", address.String()), zap.Uint64("balance", balance),
-					zap.Uint64("frozen amount", frozenBal))
-				return
-			}
-			avaliableBal = balance - frozenBal
+	if pckdkto, ok = st.pckDktoResults[address.String()]; !ok {
+		pckdkto.pck, err = Bc.GetPck(address.Bytes())
+		if err != nil {
+			logger.Error("failed to get pck balance", zap.Error(err), zap.String("address", address.String()))
+			return txDropped, err
 		}
 
-		if frozenBal, ok = frozenBalMap[address.String()]; !ok {
-			frozenBal, err = Bc.GetFreezeBalance(address.Bytes())
-			if err != nil {
-				logger.Error("failed to get frozen amount", zap.Error(err), zap.String("address", address.String()))
-				return
-			}
+		pckdkto.dkto, err = Bc.GetDKto(address.Bytes())
+		if err != nil {
+			logger.Error("failed to get pck balance", zap.Error(err), zap.String("address", address.String()))
+			return txDropped, err
 		}
+	}
 
-		if pckdkto, ok = pckDktoResults[address.String()]; !ok {
-			pckdkto.pck, err = Bc.GetPck(address.Bytes())
-			if err != nil {
-				logger.Error("failed to get pck balance", zap.Error(err), zap.String("address", address.String()))
-				return
+	if nonce, ok = st.nonceMap[tx.From.String()]; !ok {
+		nonce, err = Bc.GetNonce(tx.From.Bytes())
+		if err != nil {
+			logger.Error("failed to get nonce", zap.Error(err), zap.String("from", tx.From.String()))
+			return txDropped, err
+		}
+	}
+	logger.Debug("tag info", zap.Int32("tag", tx.Tag))
+	if tx.IsValidatorTransaction() {
+		if nonce == tx.Nonce {
+			if !st.validatorsLoaded {
+				if st.validators, err = Bc.GetValidators(); err != nil {
+					logger.Error("failed to get validators", zap.Error(err))
+					return txDropped, err
+				}
+				st.validatorsLoaded = true
 			}
-
-			pckdkto.dkto, err = Bc.GetDKto(address.Bytes())
+			vals, err := blockchain.ChangeValidators(st.validators, tx)
 			if err != nil {
-				logger.Error("failed to get pck balance", zap.Error(err), zap.String("address", address.String()))
-				return
+				logger.Error("failed to change validators", zap.Error(err), zap.String("validator", tx.Script))
+				return txDropped, nil
 			}
+			st.validators = vals
+			nonce++
+			st.nonceMap[tx.From.String()] = nonce
+			return txReady, nil
+		} else if tx.Nonce > nonce && tx.Nonce < nonce+NonceLimits {
+			return txNotReady, nil
 		}
+		return txDropped, nil
+	}
 
-		if nonce, ok = nonceMap[tx.From.String()]; !ok {
-			nonce, err = Bc.GetNonce(tx.From.Bytes())
-			if err != nil {
-				logger.Error("failed to get nonce", zap.Error(err), zap.String("from", tx.From.String()))
-				return
-			}
+	if tx.IsUnfreezeTransaction() {
+		//TODO:是解锁交易的处理情况
+		if nonce == tx.Nonce && !util.Uint64SubOverflow(frozenBal, tx.Amount) {
+			//if tx.Amount > frozenBal && nonce == tx.Nonce {
+			nonce++
+			st.nonceMap[tx.From.String()] = nonce
+			st.frozenBalMap[address.String()] = frozenBal - tx.Amount
+			st.avaliableBalMap[address.String()] = avaliableBal + tx.Amount
+			return txReady, nil
+		} else if tx.Nonce > nonce && nonce+NonceLimits < tx.Nonce { //TODO:要避免无法上链的tx越积越多,可以设置nonce的差距
+			return txNotReady, nil
 		}
-		logger.Debug("tag info", zap.Int32("tag", tx.Tag))
-		if tx.IsUnfreezeTransaction() {
-			//TODO:是解锁交易的处理情况
-			if nonce == tx.Nonce && !util.Uint64SubOverflow(frozenBal, tx.Amount) {
-				//if tx.Amount > frozenBal && nonce == tx.Nonce {
-				nonce++
-				nonceMap[tx.From.String()] = nonce
-				frozenBalMap[address.String()] = frozenBal - tx.Amount
-				avaliableBalMap[address.String()] = avaliableBal + tx.Amount
-				readyTxs = append(readyTxs, tx)
-				continue
-			} else if tx.Nonce > nonce && nonce+NonceLimits < tx.Nonce { //TODO:要避免无法上链的tx越积越多,可以设置nonce的差距
-				noReadyTxs = append(noReadyTxs, tx)
-				continue
-			}
 
+		logger.Error("nonce or amount error", zap.String("from", tx.From.String()), zap.Uint64("current nonce", nonce),
+			zap.Uint64("tx nonce", tx.Nonce), zap.Uint64("avaliable balance", avaliableBal), zap.Uint64("amount", tx.Amount))
+		return txDropped, nil
+	}
+
+	if nonce == tx.Nonce {
+		if (tx.IsTransferTrasnaction() || tx.IsFreezeTransaction()) && !util.Uint64SubOverflow(avaliableBal, tx.Amount, tx.Fee) {
+			logger.Debug("transfer or freeze", zap.String("from", tx.From.String()), zap.Uint64("avaliable balance", avaliableBal),
+				zap.Uint64("amount", tx.Amount), zap.Uint64("fee", tx.Fee))
+			st.avaliableBalMap[address.String()] = avaliableBal - tx.Amount - tx.Fee
+			if tx.IsFreezeTransaction() {
+				st.frozenBalMap[address.String()] = frozenBal + tx.Amount
+			}
+		} else if tx.IsConvertPckTransaction() && !util.Uint64SubOverflow(avaliableBal, tx.KtoNum) &&
+			!util.Uint64AddOverflow(pckdkto.pck, tx.PckNum) && !util.Uint64AddOverflow(pckdkto.dkto, tx.KtoNum) {
+			logger.Debug("tx info", zap.Bool("IsConvertPckTransaction", true), zap.Int32("tag", tx.Tag))
+			st.avaliableBalMap[address.String()] = avaliableBal - tx.KtoNum
+			st.pckDktoResults[address.String()] = pckDkto{pckdkto.pck + tx.PckNum, pckdkto.dkto + tx.KtoNum}
+		} else if tx.IsConvertKtoTransaction() && !util.Uint64SubOverflow(pckdkto.pck, tx.PckNum) &&
+			!util.Uint64AddOverflow(pckdkto.dkto, tx.KtoNum) && !util.Uint64AddOverflow(avaliableBal, tx.KtoNum) {
+			st.avaliableBalMap[address.String()] = avaliableBal + tx.KtoNum
+			st.pckDktoResults[address.String()] = pckDkto{pckdkto.pck - tx.PckNum, pckdkto.dkto - tx.KtoNum}
+		} else {
 			logger.Error("nonce or amount error", zap.String("from", tx.From.String()), zap.Uint64("current nonce", nonce),
 				zap.Uint64("tx nonce", tx.Nonce), zap.Uint64("avaliable balance", avaliableBal), zap.Uint64("amount", tx.Amount))
-		} else {
-			if nonce == tx.Nonce {
-				if (tx.IsTransferTrasnaction() || tx.IsFreezeTransaction()) && !util.Uint64SubOverflow(avaliableBal, tx.Amount, tx.Fee) {
-					logger.Debug("transfer or freeze", zap.String("from", tx.From.String()), zap.Uint64("avaliable balance", avaliableBal),
-						zap.Uint64("amount", tx.Amount), zap.Uint64("fee", tx.Fee))
-					avaliableBalMap[address.String()] = avaliableBal - tx.Amount - tx.Fee
-					if tx.IsFreezeTransaction() {
-						frozenBalMap[address.String()] = frozenBal + tx.Amount
-					}
-				} else if tx.IsConvertPckTransaction() && !util.Uint64SubOverflow(avaliableBal, tx.KtoNum) &&
-					!util.Uint64AddOverflow(pckdkto.pck, tx.PckNum) && !util.Uint64AddOverflow(pckdkto.dkto, tx.KtoNum) {
-					logger.Debug("tx info", zap.Bool("IsConvertPckTransaction", true), zap.Int32("tag", tx.Tag))
-					avaliableBalMap[address.String()] -= tx.KtoNum
-
-					pckDktoResults[address.String()] = struct {
-						pck  uint64
-						dkto uint64
-					}{
-						pckdkto.pck + tx.PckNum,
-						pckdkto.dkto + tx.KtoNum,
-					}
-				} else if tx.IsConvertKtoTransaction() && !util.Uint64SubOverflow(pckdkto.pck, tx.PckNum) &&
-					!util.Uint64AddOverflow(pckdkto.dkto, tx.KtoNum) && !util.Uint64AddOverflow(avaliableBal, tx.KtoNum) {
-					avaliableBalMap[address.String()] += tx.KtoNum
-
-					pckDktoResults[address.String()] = struct {
-						pck  uint64
-						dkto uint64
-					}{
-						pckdkto.pck - tx.PckNum,
-						pckdkto.dkto - tx.KtoNum,
-					}
-				} else {
-					logger.Error("nonce or amount error", zap.String("from", tx.From.String()), zap.Uint64("current nonce", nonce),
-						zap.Uint64("tx nonce", tx.Nonce), zap.Uint64("avaliable balance", avaliableBal), zap.Uint64("amount", tx.Amount))
-					continue
-				}
-				nonce++
-				nonceMap[tx.From.String()] = nonce
-
-				readyTxs = append(readyTxs, tx)
-				continue
-			} else if tx.Nonce > nonce && tx.Nonce < nonce+NonceLimits { //TODO:要避免无法上链的tx越积越多,可以设置nonce的差距
-				noReadyTxs = append(noReadyTxs, tx)
-				continue
-			}
-			// logger.Error("nonce or amount error", zap.String("from", tx.From.String()), zap.Uint64("current nonce", nonce),
-			// 	zap.Uint64("tx nonce", tx.Nonce), zap.Uint64("avaliable balance", avaliableBal), zap.Uint64("amount", tx.Amount))
+			return txDropped, nil
 		}
+		nonce++
+		st.nonceMap[tx.From.String()] = nonce
+		return txReady, nil
+	} else if tx.Nonce > nonce && tx.Nonce < nonce+NonceLimits { //TODO:要避免无法上链的tx越积越多,可以设置nonce的差距
+		return txNotReady, nil
 	}
-
-	//push the no ready txs into pool list
-	for _, tx := range noReadyTxs {
-		pool.List.Push(tx)
-	}
-	logger.Info("end to pending transaction")
-	return
+	return txDropped, nil
 }
 
-func verify(tx transaction.Transaction, bc blockchain.Blockchains) bool {
+// verify 检查交易能否在块高为height、时间戳为timestamp的块中上链
+func verify(tx transaction.Transaction, bc blockchain.Blockchains, height uint64, timestamp int64) bool {
 
 	//1、检查from
 	if !tx.IsCoinBaseTransaction() && !tx.From.Verify() {
@@ -310,11 +589,49 @@ func verify(tx transaction.Transaction, bc blockchain.Blockchains) bool {
 		return false
 	}
 
-	//5、检查余额
+	//5、检查链ID、交易版本和有效期，拒绝其他链的交易
+	if err := tx.CheckChainID(height); err != nil {
+		logger.Info("failed to verify chain id", zap.String("from", tx.From.String()),
+			zap.Uint64("transaction chain id", tx.ChainID), zap.Uint64("chain id", transaction.ChainID))
+		return false
+	}
+	if err := tx.CheckVersion(height); err != nil {
+		logger.Info("failed to verify version", zap.Error(err), zap.String("from", tx.From.String()),
+			zap.Uint32("version", tx.Version), zap.Uint64("height", height))
+		return false
+	}
+	if tx.Expired(height, timestamp) {
+		logger.Info("transaction expired", zap.String("from", tx.From.String()), zap.Uint64("height", height),
+			zap.Uint64("valid until height", tx.ValidUntilHeight), zap.Int64("valid until time", tx.ValidUntilTime))
+		return false
+	}
+
+	//只有转账和代币交易扣除手续费，其他交易的手续费不能用来在交易池中排序和替换
+	if tx.Fee > 0 && !tx.IsTransferTrasnaction() {
+		logger.Info("fee is only paid by transfer transactions", zap.String("from", tx.From.String()),
+			zap.Int32("tag", tx.Tag), zap.Uint64("fee", tx.Fee))
+		return false
+	}
+
+	//6、检查余额
 	if tx.IsCoinBaseTransaction() {
 		return true
 	}
 
+	//验证者交易不转账，签名必须覆盖Tag和Script，对验证者集合的修改在打包和执行时检查
+	if tx.IsValidatorTransaction() {
+		if tx.Amount != 0 || tx.Fee != 0 || tx.Version < transaction.VersionFull {
+			logger.Info("invalid validator transaction", zap.Uint64("amount", tx.Amount), zap.Uint64("fee", tx.Fee),
+				zap.Uint32("version", tx.Version))
+			return false
+		}
+		if err := blockchain.CheckValidator(tx.Script); err != nil {
+			logger.Info("invalid validator", zap.Error(err))
+			return false
+		}
+		return true
+	}
+
 	if tx.IsFreezeTransaction() {
 		//检查to的可用余额
 		balance, err := bc.GetBalance(tx.To.Bytes())
@@ -359,7 +676,8 @@ func verify(tx transaction.Transaction, bc blockchain.Blockchains) bool {
 		}
 
 		if tx.IsTransferTrasnaction() {
-			if tx.Amount < MinAmount || util.Uint64SubOverflow(balance, frozenBal, tx.Amount, tx.Fee) {
+			//取消交易金额为0，不受最小金额限制
+			if (tx.Amount < MinAmount && !tx.IsCancelTransaction()) || util.Uint64SubOverflow(balance, frozenBal, tx.Amount, tx.Fee) {
 				logger.Info("failed to verify amount", zap.String("from", tx.From.String()), zap.String("to", tx.To.String()),
 					zap.Uint64("amount", tx.Amount), zap.Uint64("unlockbalance", balance-frozenBal))
 				return false
@@ -400,47 +718,59 @@ func verify(tx transaction.Transaction, bc blockchain.Blockchains) bool {
 	return true
 }
 
-// VerifyBlock 检查区块的默克尔根
+// VerifyBlock 检查区块的hash、默克尔根和交易，交易的有效期按块高和块的时间戳检查
 func VerifyBlock(b block.Block, Bc blockchain.Blockchains) bool {
 
-	trans := make([][]byte, 0, len(b.Transactions))
-	for _, tx := range b.Transactions {
-		trans = append(trans, tx.Serialize())
+	if err := b.CheckHash(); err != nil {
+		logger.Error("Faile to verify block hash", zap.Error(err))
+		return false
 	}
 
-	if trans != nil {
-		tree := merkle.New(sha256.New(), trans)
-		if ok := tree.VerifyNode(b.Root); ok {
-			logger.Error("Faile to verify node")
+	for _, tx := range b.Transactions {
+		if !verify(*tx, Bc, b.Height, b.Timestamp) {
+			logger.Error("Failed to verify transaction")
 			return false
 		}
-
-		for _, tx := range b.Transactions {
-			if !verify(*tx, Bc) {
-				logger.Error("Failed to verify transaction")
-				return false
-			}
-		}
 	}
 	return true
 }
 
-// Filter 过滤掉不符合的交易
+// Filter 过滤掉与块中交易nonce相同或更小的交易，以及过期的交易
 func (pool *TxPool) Filter(block block.Block) {
-	txs := []*transaction.Transaction(*pool.List)
-	txsLenght := len(txs)
-	now := time.Now().UTC().Unix()
-	for j := 0; j < txsLenght; j++ {
-		for _, btx := range block.Transactions {
-			//去除与块中重叠或存在超过10s的交易
-			if txs[j].EqualNonce(btx) || now-txs[j].Time > 10 {
-				txs = append(txs[:j], txs[j+1:]...)
-				txsLenght--
-				break
-			}
+	pool.Mutex.Lock()
+	defer pool.Mutex.Unlock()
+
+	for _, btx := range block.Transactions {
+		if btx.IsCoinBaseTransaction() {
+			continue
+		}
+		if a, ok := pool.accounts[btx.From]; ok {
+			pool.drop(a.forward(btx.Nonce + 1))
+			pool.fix(a)
+		}
+	}
+	pool.expire(time.Now().UTC().Unix())
+
+	//日志中已移除的交易过多时重写日志
+	if pool.journal != nil && pool.journal.count > 2*len(pool.all)+ReadyTotal {
+		if err := pool.journal.rotate(pool.txs()); err != nil {
+			logger.Error("failed to rotate txpool journal", zap.Error(err))
+		}
+	}
+}
+
+// expire 移除存在超过lifetime的交易
+func (pool *TxPool) expire(now int64) {
+	for pool.byTime.Len() != 0 && now-pool.byTime[0].Time > pool.lifetime {
+		tx := heap.Pop(&pool.byTime).(*transaction.Transaction)
+		if pool.all[string(tx.Hash)] != tx {
+			//已上链或被替换
+			continue
 		}
+		a := pool.accounts[tx.From]
+		pool.drop([]*transaction.Transaction{a.remove(tx.Nonce)})
+		pool.fix(a)
 	}
-	*pool.List = TxHeap(txs[:txsLenght])
 }
 
 // SetCheckData 设置检查的数据
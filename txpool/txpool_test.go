@@ -0,0 +1,418 @@
+package txpool
+
+import (
+	"crypto/ed25519"
+	"io/ioutil"
+	"kortho/block"
+	"kortho/blockchain"
+	"kortho/logger"
+	"kortho/transaction"
+	"kortho/types"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	benchSenders = 1000
+	benchNonces  = 100
+)
+
+// testChain 所有地址余额充足，nonce为0
+type testChain struct {
+	blockchain.Blockchains
+}
+
+func (testChain) GetHeight() (uint64, error)              { return 0, nil }
+func (testChain) GetNonce([]byte) (uint64, error)         { return 0, nil }
+func (testChain) GetBalance([]byte) (uint64, error)       { return 1 << 60, nil }
+func (testChain) GetFreezeBalance([]byte) (uint64, error) { return 0, nil }
+func (testChain) GetPck([]byte) (uint64, error)           { return 0, nil }
+func (testChain) GetDKto([]byte) (uint64, error)          { return 0, nil }
+
+type testKey struct {
+	addr types.Address
+	priv ed25519.PrivateKey
+}
+
+func newTestKey(t testing.TB) testKey {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, err := types.StringToAddress(types.PublicKeyToAddress(pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testKey{addr: *addr, priv: priv}
+}
+
+func (k testKey) tx(t testing.TB, nonce, fee uint64) *transaction.Transaction {
+	tx := transaction.ZNewTransaction(nonce, MinAmount, k.addr, k.addr, transaction.WithFee(fee))
+	if err := tx.Sign(k.priv); err != nil {
+		t.Fatal(err)
+	}
+	return tx
+}
+
+func newTestPool(t testing.TB) *TxPool {
+	logger.Logger = zap.NewNop()
+	k := newTestKey(t)
+	pool, err := newPool(k.addr.String(), 0, "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pool
+}
+
+func TestPendingQueued(t *testing.T) {
+	pool := newTestPool(t)
+	a, b := newTestKey(t), newTestKey(t)
+
+	for _, tx := range []*transaction.Transaction{a.tx(t, 0, 1), a.tx(t, 2, 1), b.tx(t, 0, 5)} {
+		if err := pool.Add(tx, testChain{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 2 || queued != 1 {
+		t.Fatalf("pending %d queued %d", pending, queued)
+	}
+
+	//补上nonce 1后nonce 2变为pending
+	if err := pool.Add(a.tx(t, 1, 1), testChain{}); err != nil {
+		t.Fatal(err)
+	}
+	if pending, queued := pool.Stats(); pending != 4 || queued != 0 {
+		t.Fatalf("pending %d queued %d", pending, queued)
+	}
+
+	txs := pool.PendingN(testChain{}, 1)
+	if len(txs) != 1 || txs[0].From != b.addr {
+		t.Fatalf("pending txs: %v", txs)
+	}
+	txs = pool.Pending(testChain{})
+	if len(txs) != 3 {
+		t.Fatalf("pending txs: %v", txs)
+	}
+	for i, tx := range txs {
+		if tx.From != a.addr || tx.Nonce != uint64(i) {
+			t.Fatalf("tx %d: nonce %d", i, tx.Nonce)
+		}
+	}
+	if pool.Len() != 0 {
+		t.Fatalf("pool length %d", pool.Len())
+	}
+}
+
+func TestReplaceByFee(t *testing.T) {
+	pool := newTestPool(t)
+	a := newTestKey(t)
+	events := pool.Subscribe()
+	defer pool.Unsubscribe(events)
+
+	tx := a.tx(t, 0, 10)
+	if err := pool.Add(tx, testChain{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pool.Add(tx, testChain{}); err != errtxexist {
+		t.Fatalf("duplicate: %v", err)
+	}
+
+	cancel := transaction.ZNewTransaction(0, 0, a.addr, a.addr, transaction.WithFee(10))
+	cancel.Sign(a.priv)
+	if err := pool.Add(cancel, testChain{}); err != errreplace {
+		t.Fatalf("same fee: %v", err)
+	}
+	cancel = transaction.ZNewTransaction(0, 0, a.addr, a.addr, transaction.WithFee(11))
+	cancel.Sign(a.priv)
+	if err := pool.Add(cancel, testChain{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if pool.IsExist(tx.Hash) || pool.GetByNonce(a.addr, 0) != cancel {
+		t.Fatal("transaction was not replaced")
+	}
+	<-events
+	if ev := <-events; ev.Type != EventCancelled || ev.Replaced != tx || ev.Tx != cancel {
+		t.Fatalf("event: %v", ev)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	pool := newTestPool(t)
+	a := newTestKey(t)
+	for i := uint64(0); i < 3; i++ {
+		if err := pool.Add(a.tx(t, i, 1), testChain{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pool.Filter(block.Block{Transactions: []*transaction.Transaction{a.tx(t, 1, 1)}})
+	if pool.Len() != 1 || pool.GetByNonce(a.addr, 2) == nil {
+		t.Fatalf("pool length %d", pool.Len())
+	}
+
+	pool.expire(time.Now().Unix() + TxLifetime + 1)
+	if pool.Len() != 0 || len(pool.accounts) != 0 || pool.priced.Len() != 0 {
+		t.Fatalf("pool length %d", pool.Len())
+	}
+}
+
+func TestValidUntil(t *testing.T) {
+	pool := newTestPool(t)
+	a := newTestKey(t)
+
+	expired := transaction.ZNewTransaction(0, MinAmount, a.addr, a.addr, transaction.WithValidUntil(0, time.Now().Unix()-1))
+	expired.Sign(a.priv)
+	if err := pool.Add(expired, testChain{}); err != errtx {
+		t.Fatalf("expired: %v", err)
+	}
+
+	//有效期参与签名，修改后签名验证失败
+	tx := transaction.ZNewTransaction(0, MinAmount, a.addr, a.addr, transaction.WithValidUntil(1, 0))
+	tx.Sign(a.priv)
+	tx.ValidUntilHeight = 2
+	if tx.Verify() {
+		t.Fatal("ValidUntilHeight is not signed")
+	}
+	tx.ValidUntilHeight = 1
+	if err := pool.Add(tx, testChain{}); err != nil {
+		t.Fatal(err)
+	}
+
+	//块中交易的有效期按块的时间戳检查，有效期已过的历史块仍能通过检查
+	b := block.Block{Height: 1, Timestamp: expired.ValidUntilTime - 10, Transactions: []*transaction.Transaction{expired}}
+	b.SetHash()
+	if !VerifyBlock(b, testChain{}) {
+		t.Fatal("historical block with an expired transaction")
+	}
+	b.Timestamp = expired.ValidUntilTime + 1
+	b.SetHash()
+	if VerifyBlock(b, testChain{}) {
+		t.Fatal("block after the transaction expired")
+	}
+}
+
+func TestChainID(t *testing.T) {
+	pool := newTestPool(t)
+	a := newTestKey(t)
+
+	foreign := a.tx(t, 0, 1)
+	transaction.InitChainID(7)
+	defer transaction.InitChainID(0)
+	if err := pool.Add(foreign, testChain{}); err != errtx {
+		t.Fatalf("foreign chain: %v", err)
+	}
+
+	tx := a.tx(t, 0, 1)
+	if tx.ChainID != 7 {
+		t.Fatalf("chain id %d", tx.ChainID)
+	}
+	if err := pool.Add(tx, testChain{}); err != nil {
+		t.Fatal(err)
+	}
+	//链ID参与签名
+	tx.ChainID = 8
+	if tx.Verify() {
+		t.Fatal("chain id is not signed")
+	}
+
+	//启用块高之前接受没有链ID的旧格式交易
+	transaction.InitChainID(1)
+	legacy := a.tx(t, 1, 1)
+	legacy.ChainID, legacy.Version = 0, transaction.VersionLegacy
+	legacy.HashTransaction()
+	legacy.Sign(a.priv)
+	if err := pool.Add(legacy, testChain{}); err != nil {
+		t.Fatalf("legacy transaction: %v", err)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	pool := newTestPool(t)
+	a := newTestKey(t)
+
+	//所有影响执行的字段都参与签名
+	for _, modify := range []func(tx *transaction.Transaction){
+		func(tx *transaction.Transaction) { tx.Tag = transaction.FreezeTag },
+		func(tx *transaction.Transaction) { tx.Fee++ },
+		func(tx *transaction.Transaction) { tx.Script = "transfer" },
+		func(tx *transaction.Transaction) { tx.Root = []byte{1} },
+		func(tx *transaction.Transaction) { tx.KtoNum++ },
+		func(tx *transaction.Transaction) { tx.Order = &transaction.Order{Signature: []byte{1}} },
+		func(tx *transaction.Transaction) { tx.Version = transaction.VersionLegacy },
+	} {
+		tx := a.tx(t, 0, 1)
+		modify(tx)
+		if tx.Verify() {
+			t.Fatalf("modified transaction verified: %+v", tx)
+		}
+	}
+
+	legacy := a.tx(t, 0, 1)
+	legacy.Version = transaction.VersionLegacy
+	legacy.HashTransaction()
+	legacy.Sign(a.priv)
+	defer transaction.InitVersionHeight(0)
+	transaction.InitVersionHeight(1)
+	if err := pool.Add(legacy, testChain{}); err != errtx {
+		t.Fatalf("legacy transaction: %v", err)
+	}
+	//启用块高之前仍接受旧格式交易
+	transaction.InitVersionHeight(2)
+	if err := pool.Add(legacy, testChain{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestJournal(t *testing.T) {
+	dir, err := ioutil.TempDir("", "txpool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, JournalName)
+
+	logger.Logger = zap.NewNop()
+	qtj := newTestKey(t)
+	pool, err := newPool(qtj.addr.String(), 0, path, testChain{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newTestKey(t)
+	txs := []*transaction.Transaction{a.tx(t, 0, 1), a.tx(t, 2, 1), a.tx(t, 1, 1)}
+	for _, tx := range txs {
+		if err := pool.Add(tx, testChain{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pool.Close()
+
+	//模拟写入时崩溃留下的不完整记录
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte{0xff, 0, 0, 0, 1})
+	f.Close()
+
+	pool, err = newPool(qtj.addr.String(), 0, path, testChain{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.Close()
+	for _, tx := range txs {
+		if !pool.IsExist(tx.Hash) {
+			t.Fatalf("nonce %d was not reloaded", tx.Nonce)
+		}
+	}
+	if pending, queued := pool.Stats(); pending != 3 || queued != 0 {
+		t.Fatalf("pending %d queued %d", pending, queued)
+	}
+	if pool.journal.count != 3 {
+		t.Fatalf("journal count %d", pool.journal.count)
+	}
+}
+
+var (
+	benchOnce sync.Once
+	benchKeys []testKey
+	benchTxs  []*transaction.Transaction
+)
+
+// benchPool 交易池中有benchSenders个地址，每个地址benchNonces笔交易，共10万笔
+func benchPool(b *testing.B) *TxPool {
+	benchOnce.Do(func() {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < benchSenders; i++ {
+			k := newTestKey(b)
+			benchKeys = append(benchKeys, k)
+			for n := uint64(0); n < benchNonces; n++ {
+				benchTxs = append(benchTxs, k.tx(b, n, uint64(r.Intn(1000))))
+			}
+		}
+	})
+
+	pool := newTestPool(b)
+	pool.limit = 1 << 30
+	pool.lifetime = 1 << 30
+	for _, tx := range benchTxs {
+		if err := pool.add(tx, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+	return pool
+}
+
+func BenchmarkAdd(b *testing.B) {
+	pool := benchPool(b)
+	if b.N > benchSenders*(MaxAddrCount-benchNonces) {
+		b.N = benchSenders * (MaxAddrCount - benchNonces)
+	}
+	txs := make([]*transaction.Transaction, b.N)
+	for i := range txs {
+		txs[i] = benchKeys[i%benchSenders].tx(b, uint64(benchNonces+i/benchSenders), 1)
+	}
+
+	b.ResetTimer()
+	for _, tx := range txs {
+		if err := pool.Add(tx, testChain{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkIsExist(b *testing.B) {
+	pool := benchPool(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if !pool.IsExist(benchTxs[i%len(benchTxs)].Hash) {
+			b.Fatal("not exist")
+		}
+	}
+}
+
+func BenchmarkPending(b *testing.B) {
+	pool := benchPool(b)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		txs := pool.Pending(testChain{})
+		if len(txs) != ReadyTotal {
+			b.Fatalf("pending %d", len(txs))
+		}
+
+		b.StopTimer()
+		for _, tx := range txs {
+			pool.add(tx, 0)
+		}
+		b.StartTimer()
+	}
+}
+
+func BenchmarkFilter(b *testing.B) {
+	pool := benchPool(b)
+	blk := block.Block{}
+	for i := 0; i < ReadyTotal; i++ {
+		blk.Transactions = append(blk.Transactions, benchTxs[i*benchNonces/5])
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pool.Filter(blk)
+
+		b.StopTimer()
+		for _, tx := range benchTxs {
+			if !pool.IsExist(tx.Hash) {
+				pool.add(tx, 0)
+			}
+		}
+		b.StartTimer()
+	}
+}
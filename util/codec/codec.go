@@ -0,0 +1,191 @@
+// Package codec 实现了确定性的二进制编码，整数为8字节小端，变长数据带4字节小端长度前缀
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+var (
+	// ErrShort 数据长度不够
+	ErrShort = errors.New("codec: unexpected end of data")
+	// ErrTrailing 解码后还有多余的数据
+	ErrTrailing = errors.New("codec: trailing data")
+)
+
+// Encoder 按写入的顺序编码字段
+type Encoder struct {
+	buf []byte
+}
+
+// NewEncoder 创建编码器
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+// Byte 写入一个字节
+func (e *Encoder) Byte(v byte) {
+	e.buf = append(e.buf, v)
+}
+
+// Bool 写入一个字节的0或1
+func (e *Encoder) Bool(v bool) {
+	if v {
+		e.Byte(1)
+	} else {
+		e.Byte(0)
+	}
+}
+
+// Uint32 写入4字节小端整数
+func (e *Encoder) Uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+// Uint64 写入8字节小端整数
+func (e *Encoder) Uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf = append(e.buf, b[:]...)
+}
+
+// Int64 写入8字节小端整数
+func (e *Encoder) Int64(v int64) {
+	e.Uint64(uint64(v))
+}
+
+// Fixed 写入定长数据，不带长度前缀
+func (e *Encoder) Fixed(v []byte) {
+	e.buf = append(e.buf, v...)
+}
+
+// Bytes 写入带长度前缀的数据，nil和空切片编码相同
+func (e *Encoder) Bytes(v []byte) {
+	e.Uint32(uint32(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// String 写入带长度前缀的字符串
+func (e *Encoder) String(v string) {
+	e.Uint32(uint32(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+// Result 返回编码结果
+func (e *Encoder) Result() []byte {
+	return e.buf
+}
+
+// Decoder 按编码的顺序读取字段，出错后后续读取都返回零值，错误通过Err获取
+type Decoder struct {
+	data []byte
+	err  error
+}
+
+// NewDecoder 创建解码器
+func NewDecoder(data []byte) *Decoder {
+	return &Decoder{data: data}
+}
+
+func (d *Decoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || len(d.data) < n {
+		d.err = ErrShort
+		return nil
+	}
+	v := d.data[:n]
+	d.data = d.data[n:]
+	return v
+}
+
+// Byte 读取一个字节
+func (d *Decoder) Byte() byte {
+	if v := d.next(1); v != nil {
+		return v[0]
+	}
+	return 0
+}
+
+// Bool 读取一个字节的0或1
+func (d *Decoder) Bool() bool {
+	switch d.Byte() {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+	if d.err == nil {
+		d.err = errors.New("codec: invalid bool")
+	}
+	return false
+}
+
+// Uint32 读取4字节小端整数
+func (d *Decoder) Uint32() uint32 {
+	if v := d.next(4); v != nil {
+		return binary.LittleEndian.Uint32(v)
+	}
+	return 0
+}
+
+// Uint64 读取8字节小端整数
+func (d *Decoder) Uint64() uint64 {
+	if v := d.next(8); v != nil {
+		return binary.LittleEndian.Uint64(v)
+	}
+	return 0
+}
+
+// Int64 读取8字节小端整数
+func (d *Decoder) Int64() int64 {
+	return int64(d.Uint64())
+}
+
+// Fixed 读取n字节的定长数据
+func (d *Decoder) Fixed(n int) []byte {
+	return d.next(n)
+}
+
+// Bytes 读取带长度前缀的数据，长度为0时返回nil
+func (d *Decoder) Bytes() []byte {
+	n := d.Uint32()
+	if n == 0 {
+		return nil
+	}
+	v := d.next(int(n))
+	if v == nil {
+		return nil
+	}
+	return append([]byte{}, v...)
+}
+
+// String 读取带长度前缀的字符串
+func (d *Decoder) String() string {
+	n := d.Uint32()
+	return string(d.next(int(n)))
+}
+
+// Len 返回未读取的字节数
+func (d *Decoder) Len() int {
+	return len(d.data)
+}
+
+// Err 返回解码中的错误
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// Finish 检查解码错误以及是否读完了所有数据
+func (d *Decoder) Finish() error {
+	if d.err != nil {
+		return d.err
+	}
+	if len(d.data) != 0 {
+		return ErrTrailing
+	}
+	return nil
+}
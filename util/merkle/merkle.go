@@ -1,9 +1,14 @@
 package merkle
 
 import (
+	"bytes"
+	"errors"
 	"hash"
 )
 
+// ErrNotFound the leaf is not in the tree
+var ErrNotFound = errors.New("merkle: data not found")
+
 func GenHash(h hash.Hash, data []byte) []byte {
 	h.Reset()
 	h.Write(data)
@@ -55,6 +60,7 @@ func New(hash hash.Hash, data [][]byte) *MerkleTree {
 	}
 	r := &MerkleTree{
 		hash: hash,
+		size: n,
 	}
 	r.tree = r.mkMerkleTreeRoot(n, data)
 	return r
@@ -80,8 +86,9 @@ func constructPath(proof MerkleProof, leaf MKNode, node MKNode) MerkleProof {
 		nodeRoot:    rn.getMKRoot(),
 		siblingRoot: ln.getMKRoot(),
 	}
-	lpath := constructPath(append(proof, lProofElem), ln, node)
-	rpath := constructPath(append(proof, rProofElem), rn, node)
+	//copy the path so that the two branches don't share the backing array
+	lpath := constructPath(append(proof[:len(proof):len(proof)], lProofElem), ln, node)
+	rpath := constructPath(append(proof[:len(proof):len(proof)], rProofElem), rn, node)
 	return append(lpath, rpath...)
 }
 
@@ -124,3 +131,50 @@ func (t *MerkleTree) VerifyNode(data []byte) bool {
 	proof := t.merkleProof(node)
 	return t.validateMerkleProof(proof, node)
 }
+
+// ProofStep is one step of a proof path, Hash is the hash of the sibling node
+// and Left tells whether the sibling is on the left
+type ProofStep struct {
+	Hash []byte
+	Left bool
+}
+
+// GenerateProof returns the proof path of the index-th leaf from the leaf up to the root
+func (t *MerkleTree) GenerateProof(index int) ([]ProofStep, error) {
+	if t == nil || index < 0 || index >= t.size {
+		return nil, ErrNotFound
+	}
+	var steps []ProofStep
+	node, n := t.GetMtRoot(), t.size
+	for !node.isLeafNode() {
+		//same split as mkMerkleTreeRoot
+		i := powerOfTwo(n)
+		if index < i {
+			steps = append(steps, ProofStep{Hash: node.rightNode().getMKHash(), Left: false})
+			node, n = node.leftNode(), i
+		} else {
+			steps = append(steps, ProofStep{Hash: node.leftNode().getMKHash(), Left: true})
+			node, n, index = node.rightNode(), n-i, index-i
+		}
+	}
+	for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+		steps[i], steps[j] = steps[j], steps[i]
+	}
+	return steps, nil
+}
+
+// VerifyProof checks whether data is in the tree with the given root hash
+func VerifyProof(h hash.Hash, root, data []byte, proof []ProofStep) bool {
+	if len(data) == 0 {
+		return false
+	}
+	cur := GenHash(h, data)
+	for _, step := range proof {
+		if step.Left {
+			cur = GenHash(h, append(append([]byte{}, step.Hash...), cur...))
+		} else {
+			cur = GenHash(h, append(cur, step.Hash...))
+		}
+	}
+	return bytes.Equal(cur, root)
+}
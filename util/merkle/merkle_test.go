@@ -12,3 +12,29 @@ func TestMerkle(t *testing.T) {
 	fmt.Printf("hash = %x\n", hash)
 	fmt.Printf("VerifyNode = %v\n", tree.VerifyNode([]byte("6")))
 }
+
+func TestProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		var data [][]byte
+		for i := 0; i < n; i++ {
+			data = append(data, []byte(fmt.Sprintf("tx%d", i)))
+		}
+		tree := New(sha256.New(), data)
+		root := tree.GetMtHash()
+		for i, d := range data {
+			proof, err := tree.GenerateProof(i)
+			if err != nil {
+				t.Fatalf("n=%d %s: %v", n, d, err)
+			}
+			if !VerifyProof(sha256.New(), root, d, proof) {
+				t.Fatalf("n=%d %s: proof not valid", n, d)
+			}
+			if VerifyProof(sha256.New(), root, []byte("other"), proof) {
+				t.Fatalf("n=%d %s: proof valid for other data", n, d)
+			}
+		}
+		if _, err := tree.GenerateProof(n); err != ErrNotFound {
+			t.Fatalf("n=%d: err = %v", n, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package bg
 
 import (
 	"fmt"
+	"io"
 	"kortho/util/miscellaneous"
 	"kortho/util/store"
 
@@ -265,7 +266,76 @@ func (db *bgStore) Zrange(k []byte, start, end int32) ([][]byte, error) {
 
 func (db *bgStore) NewTransaction() store.Transaction {
 	tx := db.db.NewTransaction(true)
-	return &bgTransaction{tx}
+	t := &tracker{txn: tx, seen: make(map[string]bool)}
+	return &bgTransaction{tx: tx, t: t, w: t}
+}
+
+func (db *bgStore) NewSnapshot() store.Snapshot {
+	tx := db.db.NewTransaction(false)
+	return &bgSnapshot{tx}
+}
+
+// Restore drops every key and loads the pairs written by Snapshot.Dump,
+// the caller should Scan the stream first since a broken stream leaves the store partly loaded
+func (db *bgStore) Restore(r io.Reader) error {
+	if err := db.db.DropAll(); err != nil {
+		return err
+	}
+	wb := db.db.NewWriteBatch()
+	defer wb.Cancel()
+	if err := Scan(r, wb.Set); err != nil {
+		return err
+	}
+	return wb.Flush()
+}
+
+// Scan reads the pairs written by Snapshot.Dump up to the empty key and calls f for each pair,
+// f may be nil to only check the stream
+func Scan(r io.Reader, f func(k, v []byte) error) error {
+	for {
+		k, err := readSlice(r)
+		if err != nil {
+			return err
+		}
+		if len(k) == 0 {
+			return nil
+		}
+		v, err := readSlice(r)
+		if err != nil {
+			return err
+		}
+		if f != nil {
+			if err := f(k, v); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Dump writes every pair visible to the snapshot as klen + k + vlen + v,
+// terminated by an empty key
+func (s *bgSnapshot) Dump(w io.Writer) error {
+	itr := s.tx.NewIterator(badger.DefaultIteratorOptions)
+	defer itr.Close()
+	for itr.Rewind(); itr.Valid(); itr.Next() {
+		item := itr.Item()
+		v, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(miscellaneous.Eslice(item.Key())); err != nil {
+			return err
+		}
+		if _, err := w.Write(miscellaneous.Eslice(v)); err != nil {
+			return err
+		}
+	}
+	_, err := w.Write(miscellaneous.Eslice([]byte{}))
+	return err
+}
+
+func (s *bgSnapshot) Release() {
+	s.tx.Discard()
 }
 
 func (tx *bgTransaction) Cancel() error {
@@ -277,131 +347,185 @@ func (tx *bgTransaction) Commit() error {
 	return tx.tx.Commit()
 }
 
+// StartJournal records the previous value of every key written from now on
+func (tx *bgTransaction) StartJournal() {
+	tx.j = &journal{txn: tx.t, seen: make(map[string]bool)}
+	tx.w = tx.j
+}
+
+// Journal stops journaling and returns the records in the order the keys were first written
+func (tx *bgTransaction) Journal() []*store.Undo {
+	if tx.j == nil {
+		return nil
+	}
+	undos := tx.j.undos
+	tx.j, tx.w = nil, tx.t
+	return undos
+}
+
+// Changes returns the current value of every key written in the transaction
+func (tx *bgTransaction) Changes() ([]*store.Undo, error) {
+	changes := make([]*store.Undo, 0, len(tx.t.keys))
+	for _, k := range tx.t.keys {
+		c := &store.Undo{Key: k}
+		item, err := tx.tx.Get(k)
+		switch err {
+		case nil:
+			if c.Value, err = item.ValueCopy(nil); err != nil {
+				return nil, err
+			}
+			c.Exist = true
+		case badger.ErrKeyNotFound:
+		default:
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// Revert writes back the saved values in reverse order
+func (tx *bgTransaction) Revert(undos []*store.Undo) error {
+	for i := len(undos) - 1; i >= 0; i-- {
+		u := undos[i]
+		var err error
+		if u.Exist {
+			err = set(tx.w, u.Key, u.Value)
+		} else {
+			err = del(tx.w, u.Key)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (tx *bgTransaction) Del(k []byte) error {
-	return del(tx.tx, k)
+	return del(tx.w, k)
 }
 
 func (tx *bgTransaction) Set(k, v []byte) error {
-	return set(tx.tx, k, v)
+	return set(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Get(k []byte) ([]byte, error) {
-	return get(tx.tx, k)
+	return get(tx.w, k)
 }
 
 func (tx *bgTransaction) Mclear(m []byte) error {
-	return mclear(tx.tx, m)
+	return mclear(tx.w, m)
 }
 
 func (tx *bgTransaction) Mdel(m, k []byte) error {
-	return mdel(tx.tx, m, k)
+	return mdel(tx.w, m, k)
 }
 
 func (tx *bgTransaction) Mset(m, k, v []byte) error {
-	return mset(tx.tx, m, k, v)
+	return mset(tx.w, m, k, v)
 }
 
 func (tx *bgTransaction) Mget(m, k []byte) ([]byte, error) {
-	return mget(tx.tx, m, k)
+	return mget(tx.w, m, k)
 }
 
 func (tx *bgTransaction) Mkeys(m []byte) ([][]byte, error) {
-	return mkeys(tx.tx, m)
+	return mkeys(tx.w, m)
 }
 
 func (tx *bgTransaction) Mvals(m []byte) ([][]byte, error) {
-	return mvals(tx.tx, m)
+	return mvals(tx.w, m)
 }
 
 func (tx *bgTransaction) Mkvs(m []byte) ([][]byte, [][]byte, error) {
-	return mkvs(tx.tx, m)
+	return mkvs(tx.w, m)
 }
 
 func (tx *bgTransaction) Llen(k []byte) int64 {
-	return llen(tx.tx, k)
+	return llen(tx.w, k)
 }
 
 func (tx *bgTransaction) Lclear(k []byte) error {
-	return llclear(tx.tx, k)
+	return llclear(tx.w, k)
 }
 
 func (tx *bgTransaction) Llpush(k, v []byte) (int64, error) {
-	return llpush(tx.tx, k, v)
+	return llpush(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Llpop(k []byte) ([]byte, error) {
-	return llpop(tx.tx, k)
+	return llpop(tx.w, k)
 }
 
 func (tx *bgTransaction) Lrpush(k, v []byte) (int64, error) {
-	return lrpush(tx.tx, k, v)
+	return lrpush(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Lrpop(k []byte) ([]byte, error) {
-	return lrpop(tx.tx, k)
+	return lrpop(tx.w, k)
 }
 
 func (tx *bgTransaction) Lrange(k []byte, start, end int64) ([][]byte, error) {
-	return lrange(tx.tx, k, start, end)
+	return lrange(tx.w, k, start, end)
 }
 
 func (tx *bgTransaction) Lset(k []byte, idx int64, v []byte) error {
-	return lset(tx.tx, k, idx, v)
+	return lset(tx.w, k, idx, v)
 }
 
 func (tx *bgTransaction) Lindex(k []byte, idx int64) ([]byte, error) {
-	return lindex(tx.tx, k, idx)
+	return lindex(tx.w, k, idx)
 }
 
 func (tx *bgTransaction) Sclear(k []byte) error {
-	return sclear(tx.tx, k)
+	return sclear(tx.w, k)
 }
 
 func (tx *bgTransaction) Sdel(k, v []byte) error {
-	return sdel(tx.tx, k, v)
+	return sdel(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Sadd(k, v []byte) error {
-	return sadd(tx.tx, k, v)
+	return sadd(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Selem(k, v []byte) (bool, error) {
-	return selem(tx.tx, k, v)
+	return selem(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Smembers(k []byte) ([][]byte, error) {
-	return smembers(tx.tx, k)
+	return smembers(tx.w, k)
 }
 
 func (tx *bgTransaction) Zclear(k []byte) error {
-	return zclear(tx.tx, k)
+	return zclear(tx.w, k)
 }
 
 func (tx *bgTransaction) Zdel(k, v []byte) error {
-	return zdel(tx.tx, k, v)
+	return zdel(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Zadd(k []byte, score int32, v []byte) error {
-	return zadd(tx.tx, k, score, v)
+	return zadd(tx.w, k, score, v)
 }
 
 func (tx *bgTransaction) Zscore(k, v []byte) (int32, error) {
-	return zscore(tx.tx, k, v)
+	return zscore(tx.w, k, v)
 }
 
 func (tx *bgTransaction) Zrange(k []byte, start, end int32) ([][]byte, error) {
-	return zrange(tx.tx, k, start, end)
+	return zrange(tx.w, k, start, end)
 }
 
-func del(tx *badger.Txn, k []byte) error {
+func del(tx txn, k []byte) error {
 	return tx.Delete(k)
 }
 
-func set(tx *badger.Txn, k, v []byte) error {
+func set(tx txn, k, v []byte) error {
 	return tx.Set(k, v)
 }
 
-func get(tx *badger.Txn, k []byte) ([]byte, error) {
+func get(tx txn, k []byte) ([]byte, error) {
 	it, err := tx.Get(k)
 	if err == badger.ErrKeyNotFound {
 		err = store.NotExist
@@ -412,7 +536,7 @@ func get(tx *badger.Txn, k []byte) ([]byte, error) {
 	return it.ValueCopy(nil)
 }
 
-func mclear(tx *badger.Txn, m []byte) error {
+func mclear(tx txn, m []byte) error {
 	k := eMapKey(m, []byte{})
 	opt := badger.DefaultIteratorOptions
 	opt.Prefix = k
@@ -427,19 +551,19 @@ func mclear(tx *badger.Txn, m []byte) error {
 	return nil
 }
 
-func mdel(tx *badger.Txn, m, k []byte) error {
+func mdel(tx txn, m, k []byte) error {
 	return del(tx, eMapKey(m, k))
 }
 
-func mset(tx *badger.Txn, m, k, v []byte) error {
+func mset(tx txn, m, k, v []byte) error {
 	return set(tx, eMapKey(m, k), v)
 }
 
-func mget(tx *badger.Txn, m, k []byte) ([]byte, error) {
+func mget(tx txn, m, k []byte) ([]byte, error) {
 	return get(tx, eMapKey(m, k))
 }
 
-func mkeys(tx *badger.Txn, m []byte) ([][]byte, error) {
+func mkeys(tx txn, m []byte) ([][]byte, error) {
 	var ks [][]byte
 
 	k := eMapKey(m, []byte{})
@@ -454,7 +578,7 @@ func mkeys(tx *badger.Txn, m []byte) ([][]byte, error) {
 	return ks, nil
 }
 
-func mvals(tx *badger.Txn, m []byte) ([][]byte, error) {
+func mvals(tx txn, m []byte) ([][]byte, error) {
 	var vs [][]byte
 
 	k := eMapKey(m, []byte{})
@@ -473,7 +597,7 @@ func mvals(tx *badger.Txn, m []byte) ([][]byte, error) {
 	return vs, nil
 }
 
-func mkvs(tx *badger.Txn, m []byte) ([][]byte, [][]byte, error) {
+func mkvs(tx txn, m []byte) ([][]byte, [][]byte, error) {
 	var ks, vs [][]byte
 
 	k := eMapKey(m, []byte{})
@@ -493,11 +617,11 @@ func mkvs(tx *badger.Txn, m []byte) ([][]byte, [][]byte, error) {
 	return ks, vs, nil
 }
 
-func lnew(tx *badger.Txn, k []byte) error {
+func lnew(tx txn, k []byte) error {
 	return set(tx, eListMetaKey(k), eListMetaValue(0, 0))
 }
 
-func llen(tx *badger.Txn, k []byte) int64 {
+func llen(tx txn, k []byte) int64 {
 	if start, end, err := listStartEnd(tx, k); err != nil {
 		return 0
 	} else {
@@ -505,7 +629,7 @@ func llen(tx *badger.Txn, k []byte) int64 {
 	}
 }
 
-func llclear(tx *badger.Txn, k []byte) error {
+func llclear(tx txn, k []byte) error {
 	start, end, err := listStartEnd(tx, k)
 	if err != nil {
 		return err
@@ -518,7 +642,7 @@ func llclear(tx *badger.Txn, k []byte) error {
 	return del(tx, eListMetaKey(k))
 }
 
-func llpush(tx *badger.Txn, k, v []byte) (int64, error) {
+func llpush(tx txn, k, v []byte) (int64, error) {
 	start, end, err := listStartEnd(tx, k)
 	if err != nil {
 		if err = lnew(tx, k); err != nil {
@@ -537,7 +661,7 @@ func llpush(tx *badger.Txn, k, v []byte) (int64, error) {
 	return end - start + 1, nil
 }
 
-func llpop(tx *badger.Txn, k []byte) ([]byte, error) {
+func llpop(tx txn, k []byte) ([]byte, error) {
 	start, end, err := listStartEnd(tx, k)
 	if err != nil {
 		return nil, err
@@ -555,7 +679,7 @@ func llpop(tx *badger.Txn, k []byte) ([]byte, error) {
 	return v, nil
 }
 
-func lrpush(tx *badger.Txn, k, v []byte) (int64, error) {
+func lrpush(tx txn, k, v []byte) (int64, error) {
 	start, end, err := listStartEnd(tx, k)
 	if err != nil {
 		if err = lnew(tx, k); err != nil {
@@ -574,7 +698,7 @@ func lrpush(tx *badger.Txn, k, v []byte) (int64, error) {
 	return end - start + 1, nil
 }
 
-func lrpop(tx *badger.Txn, k []byte) ([]byte, error) {
+func lrpop(tx txn, k []byte) ([]byte, error) {
 	start, end, err := listStartEnd(tx, k)
 	if err != nil {
 		return nil, err
@@ -592,7 +716,7 @@ func lrpop(tx *badger.Txn, k []byte) ([]byte, error) {
 	return v, nil
 }
 
-func lset(tx *badger.Txn, k []byte, idx int64, v []byte) error {
+func lset(tx txn, k []byte, idx int64, v []byte) error {
 	start, end, err := listStartEnd(tx, k)
 	if err != nil {
 		return err
@@ -609,7 +733,7 @@ func lset(tx *badger.Txn, k []byte, idx int64, v []byte) error {
 	return set(tx, eListKey(k, idx+start), v)
 }
 
-func lindex(tx *badger.Txn, k []byte, idx int64) ([]byte, error) {
+func lindex(tx txn, k []byte, idx int64) ([]byte, error) {
 	start, end, err := listStartEnd(tx, k)
 	if err != nil {
 		return nil, err
@@ -626,7 +750,7 @@ func lindex(tx *badger.Txn, k []byte, idx int64) ([]byte, error) {
 	return get(tx, eListKey(k, idx))
 }
 
-func lrange(tx *badger.Txn, k []byte, start, end int64) ([][]byte, error) {
+func lrange(tx txn, k []byte, start, end int64) ([][]byte, error) {
 	var vs [][]byte
 
 	x, y, err := listStartEnd(tx, k)
@@ -659,7 +783,7 @@ func lrange(tx *badger.Txn, k []byte, start, end int64) ([][]byte, error) {
 	return vs, nil
 }
 
-func sclear(tx *badger.Txn, k []byte) error {
+func sclear(tx txn, k []byte) error {
 	k = eSetKey(k, []byte{})
 	opt := badger.DefaultIteratorOptions
 	opt.Prefix = k
@@ -674,15 +798,15 @@ func sclear(tx *badger.Txn, k []byte) error {
 	return nil
 }
 
-func sdel(tx *badger.Txn, k, v []byte) error {
+func sdel(tx txn, k, v []byte) error {
 	return del(tx, eSetKey(k, v))
 }
 
-func sadd(tx *badger.Txn, k, v []byte) error {
+func sadd(tx txn, k, v []byte) error {
 	return set(tx, eSetKey(k, v), []byte{})
 }
 
-func selem(tx *badger.Txn, k, v []byte) (bool, error) {
+func selem(tx txn, k, v []byte) (bool, error) {
 	_, err := get(tx, eSetKey(k, v))
 	switch {
 	case err == nil:
@@ -694,7 +818,7 @@ func selem(tx *badger.Txn, k, v []byte) (bool, error) {
 	}
 }
 
-func smembers(tx *badger.Txn, k []byte) ([][]byte, error) {
+func smembers(tx txn, k []byte) ([][]byte, error) {
 	var vs [][]byte
 
 	k = eSetKey(k, []byte{})
@@ -709,7 +833,7 @@ func smembers(tx *badger.Txn, k []byte) ([][]byte, error) {
 	return vs, nil
 }
 
-func zclear(tx *badger.Txn, k []byte) error {
+func zclear(tx txn, k []byte) error {
 	key := []byte{}
 	key = append([]byte("sz"), miscellaneous.E32func(uint32(len(k)))...)
 	key = append(key, k...)
@@ -731,7 +855,7 @@ func zclear(tx *badger.Txn, k []byte) error {
 	return nil
 }
 
-func zdel(tx *badger.Txn, k, v []byte) error {
+func zdel(tx txn, k, v []byte) error {
 	key := eZetKey(k, v)
 	buf, err := get(tx, key)
 	if err != nil {
@@ -747,7 +871,7 @@ func zdel(tx *badger.Txn, k, v []byte) error {
 	return nil
 }
 
-func zscore(tx *badger.Txn, k, v []byte) (int32, error) {
+func zscore(tx txn, k, v []byte) (int32, error) {
 	if buf, err := get(tx, eZetKey(k, v)); err != nil {
 		return -1, err
 	} else {
@@ -756,7 +880,7 @@ func zscore(tx *badger.Txn, k, v []byte) (int32, error) {
 	}
 }
 
-func zadd(tx *badger.Txn, k []byte, score int32, v []byte) error {
+func zadd(tx txn, k []byte, score int32, v []byte) error {
 	if err := set(tx, eZetKey(k, v), miscellaneous.E32func(uint32(score))); err != nil {
 		return err
 	}
@@ -766,7 +890,7 @@ func zadd(tx *badger.Txn, k []byte, score int32, v []byte) error {
 	return nil
 }
 
-func zrange(tx *badger.Txn, k []byte, start, end int32) ([][]byte, error) {
+func zrange(tx txn, k []byte, start, end int32) ([][]byte, error) {
 	var vs [][]byte
 
 	key := []byte{}
@@ -788,7 +912,26 @@ func zrange(tx *badger.Txn, k []byte, start, end int32) ([][]byte, error) {
 	return vs, nil
 }
 
-func listStartEnd(tx *badger.Txn, k []byte) (int64, int64, error) {
+// maxSliceSize is the largest key or value read from a dump
+const maxSliceSize = 64 << 20
+
+func readSlice(r io.Reader) ([]byte, error) {
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	n, _ := miscellaneous.D32func(buf)
+	if n > maxSliceSize {
+		return nil, fmt.Errorf("slice length %d exceeds %d", n, maxSliceSize)
+	}
+	buf = make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+func listStartEnd(tx txn, k []byte) (int64, int64, error) {
 	if v, err := get(tx, eListMetaKey(k)); err != nil {
 		return 0, 0, err
 	} else {
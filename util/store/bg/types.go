@@ -1,11 +1,96 @@
 package bg
 
-import "github.com/dgraph-io/badger"
+import (
+	"kortho/util/store"
+
+	"github.com/dgraph-io/badger"
+)
 
 type bgStore struct {
 	db *badger.DB
 }
 
+// txn is implemented by *badger.Txn and journal
+type txn interface {
+	Get([]byte) (*badger.Item, error)
+	Set([]byte, []byte) error
+	Delete([]byte) error
+	NewIterator(badger.IteratorOptions) *badger.Iterator
+}
+
 type bgTransaction struct {
 	tx *badger.Txn
+	t  *tracker
+	w  txn //t or j
+	j  *journal
+}
+
+// tracker remembers every key written in the transaction
+type tracker struct {
+	txn
+	seen map[string]bool
+	keys [][]byte
+}
+
+// journal saves the value of a key before its first write
+type journal struct {
+	txn
+	seen  map[string]bool
+	undos []*store.Undo
+}
+
+type bgSnapshot struct {
+	tx *badger.Txn
+}
+
+func (t *tracker) track(k []byte) {
+	if !t.seen[string(k)] {
+		t.seen[string(k)] = true
+		t.keys = append(t.keys, append([]byte{}, k...))
+	}
+}
+
+func (t *tracker) Set(k, v []byte) error {
+	t.track(k)
+	return t.txn.Set(k, v)
+}
+
+func (t *tracker) Delete(k []byte) error {
+	t.track(k)
+	return t.txn.Delete(k)
+}
+
+func (j *journal) record(k []byte) error {
+	if j.seen[string(k)] {
+		return nil
+	}
+	u := &store.Undo{Key: append([]byte{}, k...)}
+	item, err := j.txn.Get(k)
+	switch err {
+	case nil:
+		if u.Value, err = item.ValueCopy(nil); err != nil {
+			return err
+		}
+		u.Exist = true
+	case badger.ErrKeyNotFound:
+	default:
+		return err
+	}
+	j.seen[string(k)] = true
+	j.undos = append(j.undos, u)
+	return nil
+}
+
+func (j *journal) Set(k, v []byte) error {
+	if err := j.record(k); err != nil {
+		return err
+	}
+	return j.txn.Set(k, v)
+}
+
+func (j *journal) Delete(k []byte) error {
+	if err := j.record(k); err != nil {
+		return err
+	}
+	return j.txn.Delete(k)
 }
@@ -1,6 +1,9 @@
 package store
 
-import "errors"
+import (
+	"errors"
+	"io"
+)
 
 var (
 	NotExist  = errors.New("NotExist")
@@ -49,6 +52,22 @@ type DB interface {
 	Zrange([]byte, int32, int32) ([][]byte, error)
 
 	NewTransaction() Transaction
+
+	// snapshot
+	NewSnapshot() Snapshot
+	Restore(io.Reader) error
+}
+
+// Undo 记录key的数据，Exist为false表示key不存在，用于撤销和重做
+type Undo struct {
+	Key   []byte
+	Value []byte
+	Exist bool
+}
+
+type Snapshot interface {
+	Dump(io.Writer) error
+	Release()
 }
 
 type Transaction interface {
@@ -93,4 +112,10 @@ type Transaction interface {
 	Zadd([]byte, int32, []byte) error
 	Zscore([]byte, []byte) (int32, error)
 	Zrange([]byte, int32, int32) ([][]byte, error)
+
+	// journal
+	StartJournal()
+	Journal() []*Undo
+	Revert([]*Undo) error
+	Changes() ([]*Undo, error)
 }
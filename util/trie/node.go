@@ -0,0 +1,154 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+	"kortho/util/miscellaneous"
+
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	leafTag byte = iota
+	extTag
+	branchTag
+)
+
+var errBadNode = errors.New("trie: malformed node")
+
+type node interface{}
+
+// leaf node: the rest of the key path and the value
+type leafNode struct {
+	path  []byte
+	value []byte
+	hash  []byte
+}
+
+// extension node: a shared path to a branch
+type extNode struct {
+	path  []byte
+	child node
+	hash  []byte
+}
+
+// branch node: one child for each nibble and the value ends here
+type branchNode struct {
+	children [16]node
+	value    []byte
+	hash     []byte
+}
+
+// reference to a node in the database
+type hashNode []byte
+
+// encode a node,children are referenced by their hashes
+func encode(n node) []byte {
+	var buf bytes.Buffer
+	switch n := n.(type) {
+	case *leafNode:
+		buf.WriteByte(leafTag)
+		buf.Write(miscellaneous.Eslice(n.path))
+		buf.Write(miscellaneous.Eslice(n.value))
+	case *extNode:
+		buf.WriteByte(extTag)
+		buf.Write(miscellaneous.Eslice(n.path))
+		buf.Write(miscellaneous.Eslice(hashOf(n.child)))
+	case *branchNode:
+		buf.WriteByte(branchTag)
+		for _, c := range n.children {
+			buf.Write(miscellaneous.Eslice(hashOf(c)))
+		}
+		buf.Write(miscellaneous.Eslice(n.value))
+	}
+	return buf.Bytes()
+}
+
+// decode a node,children are left as hash nodes
+func decode(data []byte) (node, error) {
+	if len(data) == 0 {
+		return nil, errBadNode
+	}
+	tag, data := data[0], data[1:]
+	var fields [][]byte
+	for len(data) > 0 {
+		f, rest, err := miscellaneous.Dslice(data)
+		if err != nil {
+			return nil, errBadNode
+		}
+		fields = append(fields, f)
+		data = rest
+	}
+
+	switch {
+	case tag == leafTag && len(fields) == 2:
+		return &leafNode{path: fields[0], value: fields[1]}, nil
+	case tag == extTag && len(fields) == 2 && len(fields[0]) > 0 && len(fields[1]) > 0:
+		return &extNode{path: fields[0], child: hashNode(fields[1])}, nil
+	case tag == branchTag && len(fields) == 17:
+		n := &branchNode{value: fields[16]}
+		for i := 0; i < 16; i++ {
+			if len(fields[i]) > 0 {
+				n.children[i] = hashNode(fields[i])
+			}
+		}
+		if len(n.value) == 0 {
+			n.value = nil
+		}
+		return n, nil
+	}
+	return nil, errBadNode
+}
+
+// hash of a node,nil for an empty node
+func hashOf(n node) []byte {
+	switch n := n.(type) {
+	case nil:
+		return nil
+	case hashNode:
+		return n
+	case *leafNode:
+		if n.hash == nil {
+			n.hash = sum(encode(n))
+		}
+		return n.hash
+	case *extNode:
+		if n.hash == nil {
+			n.hash = sum(encode(n))
+		}
+		return n.hash
+	case *branchNode:
+		if n.hash == nil {
+			n.hash = sum(encode(n))
+		}
+		return n.hash
+	}
+	return nil
+}
+
+func sum(data []byte) []byte {
+	h := sha3.Sum256(data)
+	return h[:]
+}
+
+// convert key bytes to nibbles
+func keyToNibbles(key []byte) []byte {
+	nibbles := make([]byte, 0, len(key)*2)
+	for _, b := range key {
+		nibbles = append(nibbles, b>>4, b&0x0f)
+	}
+	return nibbles
+}
+
+func prefixLen(a, b []byte) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+func concat(a, b []byte) []byte {
+	r := make([]byte, 0, len(a)+len(b))
+	return append(append(r, a...), b...)
+}
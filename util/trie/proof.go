@@ -0,0 +1,86 @@
+package trie
+
+import (
+	"bytes"
+	"errors"
+)
+
+// ErrBadProof 证明无效
+var ErrBadProof = errors.New("trie: invalid proof")
+
+// Prove 返回从根到key路径上所有节点的编码，key不存在时返回证明其不存在的路径
+func (t *Trie) Prove(key []byte) ([][]byte, error) {
+	var proof [][]byte
+	n := t.root
+	path := keyToNibbles(key)
+	for {
+		var err error
+		if n, err = t.resolve(n); err != nil {
+			return nil, err
+		}
+		if n == nil {
+			return proof, nil
+		}
+		proof = append(proof, encode(n))
+		switch nd := n.(type) {
+		case *leafNode:
+			return proof, nil
+		case *extNode:
+			if !bytes.HasPrefix(path, nd.path) {
+				return proof, nil
+			}
+			path, n = path[len(nd.path):], nd.child
+		case *branchNode:
+			if len(path) == 0 {
+				return proof, nil
+			}
+			path, n = path[1:], nd.children[path[0]]
+		}
+	}
+}
+
+// VerifyProof 用根hash验证证明，返回key对应的值，key不存在时返回nil
+func VerifyProof(root, key []byte, proof [][]byte) ([]byte, error) {
+	nodes := make(map[string][]byte, len(proof))
+	for _, p := range proof {
+		nodes[string(sum(p))] = p
+	}
+
+	if bytes.Equal(root, EmptyRoot) {
+		return nil, nil
+	}
+	want := root
+	path := keyToNibbles(key)
+	for {
+		data, ok := nodes[string(want)]
+		if !ok {
+			return nil, ErrBadProof
+		}
+		n, err := decode(data)
+		if err != nil {
+			return nil, ErrBadProof
+		}
+		var next node
+		switch nd := n.(type) {
+		case *leafNode:
+			if bytes.Equal(nd.path, path) {
+				return nd.value, nil
+			}
+			return nil, nil
+		case *extNode:
+			if !bytes.HasPrefix(path, nd.path) {
+				return nil, nil
+			}
+			path, next = path[len(nd.path):], nd.child
+		case *branchNode:
+			if len(path) == 0 {
+				return nd.value, nil
+			}
+			path, next = path[1:], nd.children[path[0]]
+		}
+		if next == nil {
+			return nil, nil
+		}
+		want = next.(hashNode)
+	}
+}
@@ -0,0 +1,307 @@
+// Package trie 实现了持久化的Merkle-Patricia树，用于对账户状态进行认证
+package trie
+
+import (
+	"bytes"
+	"errors"
+)
+
+// NodePrefix 数据库中trie节点key的前缀，key为前缀+节点hash
+var NodePrefix = []byte("trie")
+
+// EmptyRoot 空树的根hash
+var EmptyRoot = sum(nil)
+
+// ErrNotFound 数据库中缺少节点
+var ErrNotFound = errors.New("trie: missing node")
+
+// Reader 读取trie节点
+type Reader interface {
+	Get([]byte) ([]byte, error)
+}
+
+// Writer 写入trie节点
+type Writer interface {
+	Set([]byte, []byte) error
+}
+
+// Trie 修改只在内存中进行，调用Commit后写入数据库
+type Trie struct {
+	db   Reader
+	root node
+}
+
+// New 打开根为root的树，root为空或者EmptyRoot时为空树
+func New(root []byte, db Reader) *Trie {
+	t := &Trie{db: db}
+	if len(root) > 0 && !bytes.Equal(root, EmptyRoot) {
+		t.root = hashNode(root)
+	}
+	return t
+}
+
+// Get 获取key对应的值，不存在时返回nil
+func (t *Trie) Get(key []byte) ([]byte, error) {
+	n := t.root
+	path := keyToNibbles(key)
+	for {
+		var err error
+		if n, err = t.resolve(n); err != nil {
+			return nil, err
+		}
+		switch nd := n.(type) {
+		case nil:
+			return nil, nil
+		case *leafNode:
+			if bytes.Equal(nd.path, path) {
+				return nd.value, nil
+			}
+			return nil, nil
+		case *extNode:
+			if !bytes.HasPrefix(path, nd.path) {
+				return nil, nil
+			}
+			path, n = path[len(nd.path):], nd.child
+		case *branchNode:
+			if len(path) == 0 {
+				return nd.value, nil
+			}
+			path, n = path[1:], nd.children[path[0]]
+		}
+	}
+}
+
+// Update 设置key对应的值，值为空时删除key
+func (t *Trie) Update(key, value []byte) error {
+	var n node
+	var err error
+	if len(value) == 0 {
+		n, err = t.delete(t.root, keyToNibbles(key))
+	} else {
+		n, err = t.insert(t.root, keyToNibbles(key), append([]byte{}, value...))
+	}
+	if err != nil {
+		return err
+	}
+	t.root = n
+	return nil
+}
+
+// Delete 删除key
+func (t *Trie) Delete(key []byte) error {
+	return t.Update(key, nil)
+}
+
+// Hash 树的根hash
+func (t *Trie) Hash() []byte {
+	if t.root == nil {
+		return EmptyRoot
+	}
+	return hashOf(t.root)
+}
+
+// Commit 把新的节点写入w，返回根hash
+func (t *Trie) Commit(w Writer) ([]byte, error) {
+	if err := commit(t.root, w); err != nil {
+		return nil, err
+	}
+	root := t.Hash()
+	if t.root != nil {
+		t.root = hashNode(root)
+	}
+	return root, nil
+}
+
+func commit(n node, w Writer) error {
+	switch n := n.(type) {
+	case *extNode:
+		if err := commit(n.child, w); err != nil {
+			return err
+		}
+	case *branchNode:
+		for _, c := range n.children {
+			if err := commit(c, w); err != nil {
+				return err
+			}
+		}
+	case *leafNode:
+	default:
+		//nil or already in the database
+		return nil
+	}
+	return w.Set(append(append([]byte{}, NodePrefix...), hashOf(n)...), encode(n))
+}
+
+func (t *Trie) resolve(n node) (node, error) {
+	hn, ok := n.(hashNode)
+	if !ok {
+		return n, nil
+	}
+	data, err := t.db.Get(append(append([]byte{}, NodePrefix...), hn...))
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	nd, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	setHash(nd, hn)
+	return nd, nil
+}
+
+// the loaded node is not dirty,keep its hash to avoid writing it again
+func setHash(n node, h []byte) {
+	switch n := n.(type) {
+	case *leafNode:
+		n.hash = h
+	case *extNode:
+		n.hash = h
+	case *branchNode:
+		n.hash = h
+	}
+}
+
+func (t *Trie) insert(n node, path, value []byte) (node, error) {
+	n, err := t.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	switch n := n.(type) {
+	case nil:
+		return &leafNode{path: path, value: value}, nil
+	case *leafNode:
+		if bytes.Equal(n.path, path) {
+			return &leafNode{path: path, value: value}, nil
+		}
+		c := prefixLen(n.path, path)
+		b := &branchNode{}
+		if c == len(n.path) {
+			b.value = n.value
+		} else {
+			b.children[n.path[c]] = &leafNode{path: n.path[c+1:], value: n.value}
+		}
+		if c == len(path) {
+			b.value = value
+		} else {
+			b.children[path[c]] = &leafNode{path: path[c+1:], value: value}
+		}
+		if c > 0 {
+			return &extNode{path: path[:c], child: b}, nil
+		}
+		return b, nil
+	case *extNode:
+		c := prefixLen(n.path, path)
+		if c == len(n.path) {
+			child, err := t.insert(n.child, path[c:], value)
+			if err != nil {
+				return nil, err
+			}
+			return &extNode{path: n.path, child: child}, nil
+		}
+		b := &branchNode{}
+		if c+1 == len(n.path) {
+			b.children[n.path[c]] = n.child
+		} else {
+			b.children[n.path[c]] = &extNode{path: n.path[c+1:], child: n.child}
+		}
+		if c == len(path) {
+			b.value = value
+		} else {
+			b.children[path[c]] = &leafNode{path: path[c+1:], value: value}
+		}
+		if c > 0 {
+			return &extNode{path: path[:c], child: b}, nil
+		}
+		return b, nil
+	case *branchNode:
+		b := &branchNode{children: n.children, value: n.value}
+		if len(path) == 0 {
+			b.value = value
+			return b, nil
+		}
+		child, err := t.insert(n.children[path[0]], path[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		b.children[path[0]] = child
+		return b, nil
+	}
+	return nil, errBadNode
+}
+
+func (t *Trie) delete(n node, path []byte) (node, error) {
+	n, err := t.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	switch n := n.(type) {
+	case nil:
+		return nil, nil
+	case *leafNode:
+		if bytes.Equal(n.path, path) {
+			return nil, nil
+		}
+		return n, nil
+	case *extNode:
+		if !bytes.HasPrefix(path, n.path) {
+			return n, nil
+		}
+		child, err := t.delete(n.child, path[len(n.path):])
+		if err != nil {
+			return nil, err
+		}
+		return t.join(n.path, child)
+	case *branchNode:
+		b := &branchNode{children: n.children, value: n.value}
+		if len(path) == 0 {
+			b.value = nil
+		} else {
+			child, err := t.delete(n.children[path[0]], path[1:])
+			if err != nil {
+				return nil, err
+			}
+			b.children[path[0]] = child
+		}
+		return t.collapse(b)
+	}
+	return nil, errBadNode
+}
+
+// a branch with only one child or only a value is replaced by a shorter node
+func (t *Trie) collapse(b *branchNode) (node, error) {
+	pos, count := -1, 0
+	for i, c := range b.children {
+		if c != nil {
+			pos = i
+			count++
+		}
+	}
+	switch {
+	case count == 0 && b.value == nil:
+		return nil, nil
+	case count == 0:
+		return &leafNode{path: []byte{}, value: b.value}, nil
+	case count == 1 && b.value == nil:
+		return t.join([]byte{byte(pos)}, b.children[pos])
+	}
+	return b, nil
+}
+
+// prepend a path to a node
+func (t *Trie) join(path []byte, n node) (node, error) {
+	n, err := t.resolve(n)
+	if err != nil {
+		return nil, err
+	}
+	switch n := n.(type) {
+	case nil:
+		return nil, nil
+	case *leafNode:
+		return &leafNode{path: concat(path, n.path), value: n.value}, nil
+	case *extNode:
+		return &extNode{path: concat(path, n.path), child: n.child}, nil
+	case *branchNode:
+		return &extNode{path: path, child: n}, nil
+	}
+	return nil, errBadNode
+}
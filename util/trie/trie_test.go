@@ -0,0 +1,114 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type memDB map[string][]byte
+
+func (db memDB) Get(k []byte) ([]byte, error) {
+	v, ok := db[string(k)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return v, nil
+}
+
+func (db memDB) Set(k, v []byte) error {
+	db[string(k)] = v
+	return nil
+}
+
+func TestTrie(t *testing.T) {
+	db := memDB{}
+	tr := New(nil, db)
+	kvs := make(map[string]string)
+	r := rand.New(rand.NewSource(1))
+
+	var root []byte
+	for i := 0; i < 2000; i++ {
+		k := fmt.Sprintf("key%d", r.Intn(500))
+		if r.Intn(4) == 0 {
+			delete(kvs, k)
+			if err := tr.Delete([]byte(k)); err != nil {
+				t.Fatal(err)
+			}
+		} else {
+			v := fmt.Sprintf("val%d", i)
+			kvs[k] = v
+			if err := tr.Update([]byte(k), []byte(v)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		//reopen from the database from time to time
+		if i%100 == 99 {
+			var err error
+			if root, err = tr.Commit(db); err != nil {
+				t.Fatal(err)
+			}
+			tr = New(root, db)
+		}
+	}
+	root, _ = tr.Commit(db)
+
+	//the root only depends on the content
+	keys := make([]string, 0, len(kvs))
+	for k := range kvs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fresh := New(nil, memDB{})
+	for _, k := range keys {
+		fresh.Update([]byte(k), []byte(kvs[k]))
+	}
+	if !bytes.Equal(fresh.Hash(), root) {
+		t.Fatalf("root %x != %x", root, fresh.Hash())
+	}
+
+	tr = New(root, db)
+	for i := 0; i < 500; i++ {
+		k := []byte(fmt.Sprintf("key%d", i))
+		v, err := tr.Get(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(v) != kvs[string(k)] {
+			t.Fatalf("get %s = %s, want %s", k, v, kvs[string(k)])
+		}
+
+		proof, err := tr.Prove(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pv, err := VerifyProof(root, k, proof)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(pv) != kvs[string(k)] {
+			t.Fatalf("proof of %s = %s, want %s", k, pv, kvs[string(k)])
+		}
+	}
+
+	//deleting everything gives an empty trie
+	for _, k := range keys {
+		tr.Delete([]byte(k))
+	}
+	if !bytes.Equal(tr.Hash(), EmptyRoot) {
+		t.Fatal("trie is not empty")
+	}
+}
+
+func TestBadProof(t *testing.T) {
+	tr := New(nil, memDB{})
+	tr.Update([]byte("a"), []byte("1"))
+	tr.Update([]byte("b"), []byte("2"))
+	proof, _ := tr.Prove([]byte("a"))
+	proof[len(proof)-1] = []byte{leafTag}
+	if _, err := VerifyProof(tr.Hash(), []byte("a"), proof); err != ErrBadProof {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"kortho/block"
 	"kortho/blockchain"
@@ -55,8 +54,8 @@ func Recovery(args []string) {
 	for i, e := range ents {
 		if e.Type == raftpb.EntryNormal {
 			if len(e.Data) > 0 {
-				b := &block.Block{}
-				if err := json.Unmarshal(e.Data, b); err != nil {
+				b, err := block.Deserialize(e.Data)
+				if err != nil {
 					fmt.Printf("failed to Unmarshal e.Data: %v\n", err)
 					continue
 				}